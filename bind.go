@@ -0,0 +1,205 @@
+package apikit
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// bindSources are the struct tags bound from the request, in binding order
+var bindSources = []string{"path", "query", "header", "cookie"}
+
+// requestBinding describes how the fields of a request struct are populated,
+// mirroring the code generated for apikit:handler functions
+type requestBinding struct {
+	params []boundParam
+
+	// body is the index of the json:"body" field, nil when the whole
+	// request is decoded from the body
+	body []int
+}
+
+// boundParam is a struct field bound from a path, query, header or cookie value
+type boundParam struct {
+	index    []int
+	field    string
+	source   string
+	name     string
+	defValue string
+}
+
+// newRequestBinding inspects the request type once so handlers don't pay for
+// reflection on tags per request
+// Returns nil for types without bound fields
+func newRequestBinding(t reflect.Type) *requestBinding {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	b := &requestBinding{}
+	b.collect(t, nil)
+	if len(b.params) == 0 && b.body == nil {
+		return nil
+	}
+	return b
+}
+
+// collect records the bound fields of t, expanding embedded structs
+func (b *requestBinding) collect(t reflect.Type, index []int) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		fieldIndex := append(index[:len(index):len(index)], i)
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			b.collect(f.Type, fieldIndex)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if jsonTag, ok := f.Tag.Lookup("json"); ok && jsonTag == "body" {
+			if b.body == nil {
+				b.body = fieldIndex
+			}
+			continue
+		}
+
+		for _, source := range bindSources {
+			value, ok := f.Tag.Lookup(source)
+			if !ok {
+				continue
+			}
+			// Options like "style=pipeDelimited" follow the name
+			name, _, _ := strings.Cut(value, ",")
+			if name == "" {
+				name = strings.ToLower(f.Name[:1]) + f.Name[1:]
+			}
+			b.params = append(b.params, boundParam{
+				index:    fieldIndex,
+				field:    f.Name,
+				source:   source,
+				name:     name,
+				defValue: f.Tag.Get("default"),
+			})
+			break
+		}
+	}
+}
+
+// bodyTarget returns a pointer to the value the JSON body is decoded into
+func (b *requestBinding) bodyTarget(req reflect.Value) any {
+	if b == nil || b.body == nil {
+		return req.Addr().Interface()
+	}
+	return req.FieldByIndex(b.body).Addr().Interface()
+}
+
+// bind populates the bound fields of req from the request
+// Missing values keep their zero value or fall back to the default tag
+func (b *requestBinding) bind(r *http.Request, req reflect.Value) error {
+	if b == nil {
+		return nil
+	}
+
+	query := r.URL.Query()
+	for _, p := range b.params {
+		var values []string
+		switch p.source {
+		case "path":
+			if v := r.PathValue(p.name); v != "" {
+				values = []string{v}
+			}
+		case "query":
+			values = query[p.name]
+		case "header":
+			values = r.Header.Values(p.name)
+		case "cookie":
+			if c, err := r.Cookie(p.name); err == nil {
+				values = []string{c.Value}
+			}
+		}
+		if len(values) == 0 && p.defValue != "" {
+			values = []string{p.defValue}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setBoundValue(req.FieldByIndex(p.index), values); err != nil {
+			return fmt.Errorf("invalid %s: %w", p.field, err)
+		}
+	}
+	return nil
+}
+
+// setBoundValue converts the request values to the field type
+// Slices take every value, other types the first one
+func setBoundValue(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setScalarValue(slice.Index(i), value); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return setScalarValue(v, values[0])
+}
+
+// setScalarValue parses a single string into v
+// Supports encoding.TextUnmarshaler, strings, booleans, integers, floats and pointers to them
+func setScalarValue(v reflect.Value, value string) error {
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := setScalarValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
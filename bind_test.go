@@ -0,0 +1,87 @@
+package apikit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetBoundValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  any
+		values  []string
+		want    any
+		wantErr string
+	}{
+		{name: "string", target: new(string), values: []string{"rex"}, want: "rex"},
+		{name: "bool", target: new(bool), values: []string{"true"}, want: true},
+		{name: "int64", target: new(int64), values: []string{"-7"}, want: int64(-7)},
+		{name: "uint16", target: new(uint16), values: []string{"65535"}, want: uint16(65535)},
+		{name: "float32", target: new(float32), values: []string{"1.5"}, want: float32(1.5)},
+		{name: "first value for scalars", target: new(int), values: []string{"1", "2"}, want: 1},
+		{name: "slice", target: new([]int), values: []string{"1", "2"}, want: []int{1, 2}},
+		{name: "pointer", target: new(*int), values: []string{"3"}, want: func() *int { i := 3; return &i }()},
+		{name: "text unmarshaler", target: new(time.Time), values: []string{"2024-01-02T03:04:05Z"}, want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{name: "int8 overflow", target: new(int8), values: []string{"300"}, wantErr: "out of range"},
+		{name: "invalid slice element", target: new([]int), values: []string{"1", "x"}, wantErr: "[1]"},
+		{name: "unsupported type", target: new(map[string]string), values: []string{"x"}, wantErr: "unsupported type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := reflect.ValueOf(tt.target).Elem()
+			err := setBoundValue(v, tt.values)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := v.Interface(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRequestBinding(t *testing.T) {
+	type Page struct {
+		Limit int `query:"limit"`
+	}
+	type request struct {
+		Page
+		ID      string `path:""`
+		Payload []byte `json:"body"`
+		Name    string `json:"name"`
+		secret  string `query:"secret"`
+	}
+
+	b := newRequestBinding(reflect.TypeFor[*request]())
+	if b == nil {
+		t.Fatal("expected a binding")
+	}
+
+	var got []string
+	for _, p := range b.params {
+		got = append(got, p.source+":"+p.name)
+	}
+	if want := []string{"query:limit", "path:iD"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("params = %v, want %v", got, want)
+	}
+	if want := []int{2}; !reflect.DeepEqual(b.body, want) {
+		t.Errorf("body index = %v, want %v", b.body, want)
+	}
+
+	if newRequestBinding(reflect.TypeFor[struct{ Name string }]()) != nil {
+		t.Error("expected no binding for a struct without bound fields")
+	}
+	if newRequestBinding(reflect.TypeFor[[]string]()) != nil {
+		t.Error("expected no binding for a slice request")
+	}
+}
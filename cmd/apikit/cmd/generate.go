@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"syscall"
 
 	"github.com/reation-io/apikit/handler/checksum"
 	"github.com/reation-io/apikit/handler/codegen"
@@ -18,6 +23,10 @@ var (
 	sourceFile string
 	outputFile string
 	force      bool
+	jobs       int
+	watch      bool
+	strict     bool
+	exclude    []string
 )
 
 // generateCmd represents the generate command
@@ -42,7 +51,19 @@ Examples:
   apikit generate --verbose
 
   # Dry run (show output without writing)
-  apikit generate --dry-run`,
+  apikit generate --dry-run
+
+  # Parse many files with 4 workers
+  apikit generate --jobs 4 *.go
+
+  # Regenerate whenever the source changes
+  apikit generate --watch handlers.go
+
+  # Fail on warnings (e.g. mis-annotated handlers) without writing output
+  apikit generate --strict
+
+  # Skip generated files and tests
+  apikit generate --exclude '*_apikit.go' --exclude '*_test.go' *.go`,
 	RunE: runGenerate,
 }
 
@@ -52,6 +73,10 @@ func init() {
 	generateCmd.Flags().StringVarP(&sourceFile, "file", "f", "", "source file to process (defaults to GOFILE env var)")
 	generateCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output file (defaults to <source>_apikit.go)")
 	generateCmd.Flags().BoolVar(&force, "force", false, "force regeneration even if source hasn't changed")
+	generateCmd.Flags().IntVarP(&jobs, "jobs", "j", runtime.NumCPU(), "number of files to parse in parallel")
+	generateCmd.Flags().BoolVarP(&watch, "watch", "w", false, "watch source files and regenerate on change")
+	generateCmd.Flags().BoolVar(&strict, "strict", false, "fail on warnings without writing output")
+	generateCmd.Flags().StringArrayVar(&exclude, "exclude", nil, "skip source files matching this glob (repeatable)")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
@@ -85,6 +110,14 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		sourceFiles = append(sourceFiles, goFile)
 	}
 
+	sourceFiles, err := excludeFiles(sourceFiles, exclude)
+	if err != nil {
+		return err
+	}
+	if len(sourceFiles) == 0 {
+		return fmt.Errorf("no source files left after --exclude")
+	}
+
 	// Get current directory
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -105,17 +138,70 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		log.Printf("Processing %d file(s)...", len(resolvedFiles))
 	}
 
-	// Create a single parser instance to share cache across all files
-	p := parser.New()
+	if err := generateFiles(resolvedFiles, jobs); err != nil {
+		return err
+	}
+
+	if !watch {
+		return nil
+	}
+
+	// Watch until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return watchFiles(ctx, resolvedFiles)
+}
+
+// excludeFiles drops the files matching any of the glob patterns
+// A pattern matches either the path as given or its base name
+// Example: "*_test.go" excludes "api/handlers_test.go"
+func excludeFiles(files, patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid --exclude pattern %q: %w", pattern, err)
+		}
+	}
+
+	var kept []string
+	for _, file := range files {
+		excluded := false
+		for _, pattern := range patterns {
+			matchPath, _ := filepath.Match(pattern, file)
+			matchBase, _ := filepath.Match(pattern, filepath.Base(file))
+			if matchPath || matchBase {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, file)
+		}
+	}
+	return kept, nil
+}
+
+// parsedSource is the result of the parsing phase for a single source file
+type parsedSource struct {
+	path      string
+	output    string
+	result    *parser.ParseResult
+	unchanged bool
+	err       error
+}
 
-	// Process each file
-	for i, sourceFilePath := range resolvedFiles {
+// generateFiles parses the source files in parallel and then generates
+// their wrappers sequentially in input order, so output is deterministic
+func generateFiles(files []string, jobs int) error {
+	parsed := parseSources(files, jobs)
+
+	for i, source := range parsed {
 		if verbose {
-			log.Printf("[%d/%d] Processing %s", i+1, len(resolvedFiles), sourceFilePath)
+			log.Printf("[%d/%d] Processing %s", i+1, len(parsed), source.path)
 		}
 
-		if err := generateWithParser(p, sourceFilePath); err != nil {
-			return fmt.Errorf("processing %s: %w", sourceFilePath, err)
+		if err := generateFromSource(source); err != nil {
+			return fmt.Errorf("processing %s: %w", source.path, err)
 		}
 	}
 
@@ -126,25 +212,52 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func generateWithParser(p *parser.Parser, sourceFilePath string) error {
-	// Determine output file name
-	output := outputFile
-	if output == "" {
-		output = strings.TrimSuffix(sourceFilePath, ".go") + "_apikit.go"
+// parseSources parses each file with its own parser using up to jobs workers
+// Results are returned in the same order as files
+func parseSources(files []string, jobs int) []parsedSource {
+	jobs = max(1, min(jobs, len(files)))
+
+	parsed := make([]parsedSource, len(files))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Go(func() {
+			for i := range indexes {
+				parsed[i] = parseSource(files[i])
+			}
+		})
+	}
+
+	for i := range files {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return parsed
+}
+
+// parseSource parses a single source file unless its generated output is up to date
+func parseSource(sourceFilePath string) parsedSource {
+	source := parsedSource{path: sourceFilePath, output: outputFile}
+	if source.output == "" {
+		source.output = strings.TrimSuffix(sourceFilePath, ".go") + "_apikit.go"
 	}
 
 	// Check if source has changed (unless --force is used)
+	// Strict mode still parses unchanged sources to report their warnings
 	if !force {
-		changed, err := checksum.HasSourceChanged(sourceFilePath, output)
+		changed, err := checksum.HasSourceChanged(sourceFilePath, source.output)
 		if err != nil {
 			if verbose {
 				log.Printf("Warning: could not check if source changed: %v", err)
 			}
 		} else if !changed {
-			if verbose {
-				log.Printf("Source unchanged, skipping %s", sourceFilePath)
+			source.unchanged = true
+			if !strict {
+				return source
 			}
-			return nil
 		}
 	}
 
@@ -153,17 +266,42 @@ func generateWithParser(p *parser.Parser, sourceFilePath string) error {
 		log.Printf("Parsing %s...", sourceFilePath)
 	}
 
-	result, err := p.ParseFile(sourceFilePath)
+	result, err := parser.New().ParseFile(sourceFilePath)
 	if err != nil {
-		return fmt.Errorf("parsing file: %w", err)
+		source.err = fmt.Errorf("parsing file: %w", err)
+		return source
 	}
+	source.result = result
 
-	// Print warnings if any
-	if len(result.Warnings) > 0 && verbose {
+	return source
+}
+
+// generateFromSource generates and writes the wrappers for a parsed source file
+func generateFromSource(source parsedSource) error {
+	if source.err != nil {
+		return source.err
+	}
+
+	sourceFilePath := source.path
+	output := source.output
+	result := source.result
+
+	if source.unchanged && !strict {
+		if verbose {
+			log.Printf("Source unchanged, skipping %s", sourceFilePath)
+		}
+		return nil
+	}
+
+	// Print warnings if any (always in strict mode)
+	if len(result.Warnings) > 0 && (verbose || strict) {
 		for _, warning := range result.Warnings {
 			log.Printf("Warning: %s", warning)
 		}
 	}
+	if strict && len(result.Warnings) > 0 {
+		return fmt.Errorf("%d warning(s) in strict mode", len(result.Warnings))
+	}
 
 	// Check if any handlers were found
 	if len(result.Handlers) == 0 {
@@ -202,6 +340,24 @@ func generateWithParser(p *parser.Parser, sourceFilePath string) error {
 		return fmt.Errorf("generating code: %w", err)
 	}
 
+	// Print warnings found while generating (always in strict mode)
+	if verbose || strict {
+		for _, warning := range gen.Warnings() {
+			log.Printf("Warning: %s", warning)
+		}
+	}
+	if strict && len(gen.Warnings()) > 0 {
+		return fmt.Errorf("%d warning(s) in strict mode", len(gen.Warnings()))
+	}
+
+	// Unchanged sources were only checked for warnings, their output is up to date
+	if source.unchanged {
+		if verbose {
+			log.Printf("Source unchanged, skipping %s", sourceFilePath)
+		}
+		return nil
+	}
+
 	// Calculate source checksum and add to generated code
 	sourceChecksum, err := checksum.CalculateFileChecksum(sourceFilePath)
 	if err != nil {
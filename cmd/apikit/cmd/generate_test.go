@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeHandlerFiles creates n source files with one annotated handler each
+func writeHandlerFiles(t *testing.T, dir string, n int) []string {
+	t.Helper()
+
+	var files []string
+	for i := range n {
+		content := fmt.Sprintf(`package test
+
+import "context"
+
+type Request%[1]d struct {
+	// in:query
+	Name string `+"`json:\"name\" validate:\"required\"`"+`
+}
+
+type Response%[1]d struct {
+	Message string `+"`json:\"message\"`"+`
+}
+
+// apikit:handler
+func Handler%[1]d(ctx context.Context, req Request%[1]d) (Response%[1]d, error) {
+	return Response%[1]d{Message: req.Name}, nil
+}
+`, i)
+
+		path := filepath.Join(dir, fmt.Sprintf("handler%d.go", i))
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+		files = append(files, path)
+	}
+	return files
+}
+
+// readGenerated reads the generated output of each source file
+func readGenerated(t *testing.T, files []string) []string {
+	t.Helper()
+
+	var outputs []string
+	for _, file := range files {
+		code, err := os.ReadFile(strings.TrimSuffix(file, ".go") + "_apikit.go")
+		if err != nil {
+			t.Fatalf("failed to read generated code: %v", err)
+		}
+		outputs = append(outputs, string(code))
+	}
+	return outputs
+}
+
+func TestGenerateFiles_ParallelMatchesSequential(t *testing.T) {
+	force, outputFile, dryRun = true, "", false
+	defer func() { force = false }()
+
+	sequentialFiles := writeHandlerFiles(t, t.TempDir(), 8)
+	if err := generateFiles(sequentialFiles, 1); err != nil {
+		t.Fatalf("sequential generation failed: %v", err)
+	}
+
+	parallelFiles := writeHandlerFiles(t, t.TempDir(), 8)
+	if err := generateFiles(parallelFiles, 4); err != nil {
+		t.Fatalf("parallel generation failed: %v", err)
+	}
+
+	sequential := readGenerated(t, sequentialFiles)
+	parallel := readGenerated(t, parallelFiles)
+	for i := range sequential {
+		if sequential[i] != parallel[i] {
+			t.Errorf("output %d differs between sequential and parallel generation:\nsequential:\n%s\nparallel:\n%s",
+				i, sequential[i], parallel[i])
+		}
+	}
+}
+
+func TestParseSources_SkipsUnchanged(t *testing.T) {
+	force, outputFile, dryRun = true, "", false
+	defer func() { force = false }()
+
+	files := writeHandlerFiles(t, t.TempDir(), 3)
+	if err := generateFiles(files, 2); err != nil {
+		t.Fatalf("generation failed: %v", err)
+	}
+
+	force = false
+	parsed := parseSources(files, 2)
+	for i, source := range parsed {
+		if source.path != files[i] {
+			t.Errorf("expected result %d for %s, got %s", i, files[i], source.path)
+		}
+		if !source.unchanged || source.result != nil {
+			t.Errorf("expected %s to be skipped as unchanged", source.path)
+		}
+	}
+}
+
+func TestGenerateFiles_Strict(t *testing.T) {
+	force, outputFile, dryRun = true, "", false
+	defer func() { force, strict = false, false }()
+
+	source := filepath.Join(t.TempDir(), "handlers.go")
+	content := `package test
+
+import "context"
+
+type Request struct {
+	Name string ` + "`query:\"name\"`" + `
+}
+
+// apikit:handler
+func Valid(ctx context.Context, req Request) (string, error) {
+	return req.Name, nil
+}
+
+// apikit:handler
+func MissingContext(req Request) (string, error) {
+	return req.Name, nil
+}
+`
+	if err := os.WriteFile(source, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+	output := strings.TrimSuffix(source, ".go") + "_apikit.go"
+
+	strict = true
+	err := generateFiles([]string{source}, 1)
+	if err == nil || !strings.Contains(err.Error(), "strict mode") {
+		t.Fatalf("expected strict mode failure, got: %v", err)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("expected no output in strict mode, got stat error: %v", err)
+	}
+
+	strict = false
+	if err := generateFiles([]string{source}, 1); err != nil {
+		t.Fatalf("expected generation to succeed without --strict, got: %v", err)
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("expected output without --strict: %v", err)
+	}
+
+	// Unchanged sources are still checked in strict mode
+	force, strict = false, true
+	err = generateFiles([]string{source}, 1)
+	if err == nil || !strings.Contains(err.Error(), "strict mode") {
+		t.Errorf("expected strict mode failure for an unchanged source, got: %v", err)
+	}
+}
+
+func TestGenerateFiles_StrictUnchanged(t *testing.T) {
+	force, outputFile, dryRun = false, "", false
+	defer func() { strict = false }()
+
+	files := writeHandlerFiles(t, t.TempDir(), 1)
+	if err := generateFiles(files, 1); err != nil {
+		t.Fatalf("generateFiles failed: %v", err)
+	}
+
+	// Mark the up-to-date output to detect a rewrite
+	output := strings.TrimSuffix(files[0], ".go") + "_apikit.go"
+	f, err := os.OpenFile(output, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open output: %v", err)
+	}
+	fmt.Fprintln(f, "// marker")
+	f.Close()
+
+	strict = true
+	if err := generateFiles(files, 1); err != nil {
+		t.Fatalf("expected a clean unchanged source to pass in strict mode, got: %v", err)
+	}
+	if code := readGenerated(t, files)[0]; !strings.Contains(code, "// marker") {
+		t.Error("expected strict mode to leave the output of an unchanged source alone")
+	}
+}
+
+func TestExcludeFiles(t *testing.T) {
+	files := []string{"handlers.go", "handlers_apikit.go", "api/users_test.go", "testdata/fixture.go"}
+
+	kept, err := excludeFiles(files, []string{"*_apikit.go", "*_test.go", "testdata/*"})
+	if err != nil {
+		t.Fatalf("excludeFiles failed: %v", err)
+	}
+	if len(kept) != 1 || kept[0] != "handlers.go" {
+		t.Errorf("expected only handlers.go to be kept, got %v", kept)
+	}
+
+	if _, err := excludeFiles(files, []string{"[a-"}); err == nil {
+		t.Error("expected error for an invalid pattern")
+	}
+}
+
+func TestRunGenerate_Exclude(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := writeHandlerFiles(t, tmpDir, 2)
+
+	force, outputFile, dryRun = true, "", false
+	exclude = []string{"handler1.go"}
+	defer func() { force, exclude = false, nil }()
+
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	if err := runGenerate(nil, []string{"handler0.go", "handler1.go"}); err != nil {
+		t.Fatalf("runGenerate failed: %v", err)
+	}
+
+	readGenerated(t, files[:1])
+	if _, err := os.Stat(strings.TrimSuffix(files[1], ".go") + "_apikit.go"); !os.IsNotExist(err) {
+		t.Errorf("expected excluded %s not to be generated", files[1])
+	}
+
+	exclude = []string{"*.go"}
+	if err := runGenerate(nil, []string{"handler0.go"}); err == nil || !strings.Contains(err.Error(), "--exclude") {
+		t.Errorf("expected error when every file is excluded, got: %v", err)
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	coreast "github.com/reation-io/apikit/core/ast"
 	"github.com/reation-io/apikit/openapi/builder"
+	"github.com/reation-io/apikit/openapi/spec"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -18,8 +22,12 @@ var (
 	openapiFormat    string
 	openapiTitle     string
 	openapiVer       string
-	openapiMultiSpec bool   // Enable multi-spec mode
-	openapiOutputDir string // Output directory for multi-spec mode
+	openapiMultiSpec bool     // Enable multi-spec mode
+	openapiOutputDir string   // Output directory for multi-spec mode
+	openapiEmitEnums string   // Go file to write enum types and constants to
+	openapiBasePath  string   // Prefix added to every route path
+	openapiConfig    string   // YAML file with info, servers and security settings
+	openapiExclude   []string // Globs of source files to skip
 )
 
 // openapiCmd represents the openapi command
@@ -44,6 +52,9 @@ Examples:
   # Generate from specific files
   apikit openapi handlers.go models.go
 
+  # Generate from every Go file in this directory and its subdirectories
+  apikit openapi ./...
+
   # Generate with custom output file
   apikit openapi --output openapi.json *.go
 
@@ -51,7 +62,19 @@ Examples:
   apikit openapi --format yaml --output openapi.yaml *.go
 
   # Override API metadata
-  apikit openapi --title "My API" --version "2.0.0" *.go`,
+  apikit openapi --title "My API" --version "2.0.0" *.go
+
+  # Set contact, license, servers and security from a config file
+  apikit openapi --config apikit.yaml *.go
+
+  # Mount every route under /api/v1
+  apikit openapi --base-path /api/v1 *.go
+
+  # Also generate Go constants for enum fields
+  apikit openapi --emit-enums enums_apikit.go *.go
+
+  # Skip generated files and tests
+  apikit openapi --exclude '*_apikit.go' --exclude '*_test.go'`,
 	RunE: runOpenAPI,
 }
 
@@ -64,6 +87,10 @@ func init() {
 	openapiCmd.Flags().StringVar(&openapiVer, "version", "", "override API version")
 	openapiCmd.Flags().BoolVar(&openapiMultiSpec, "multi-spec", false, "generate multiple spec files based on Spec: tags")
 	openapiCmd.Flags().StringVar(&openapiOutputDir, "output-dir", ".", "output directory for multi-spec mode")
+	openapiCmd.Flags().StringVar(&openapiEmitEnums, "emit-enums", "", "write Go types and constants for enum fields to this file, in the package of the sources")
+	openapiCmd.Flags().StringVar(&openapiBasePath, "base-path", "", "prefix added to every route path (e.g. /api/v1)")
+	openapiCmd.Flags().StringVar(&openapiConfig, "config", "", "YAML file with info, servers, security and securitySchemes (flags take precedence)")
+	openapiCmd.Flags().StringArrayVar(&openapiExclude, "exclude", nil, "skip source files matching this glob (repeatable)")
 }
 
 func runOpenAPI(cmd *cobra.Command, args []string) error {
@@ -72,12 +99,32 @@ func runOpenAPI(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid format %q, must be 'json' or 'yaml'", openapiFormat)
 	}
 
+	// Load the config file before parsing so errors are reported early
+	var config *openAPIConfig
+	if openapiConfig != "" {
+		var err error
+		config, err = loadOpenAPIConfig(openapiConfig)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Collect source files
 	var sourceFiles []string
 
 	if len(args) > 0 {
-		// Use provided arguments
-		sourceFiles = args
+		// Use provided arguments, scanning subdirectories for ./... and ** patterns
+		for _, arg := range args {
+			if !builder.IsRecursivePattern(arg) {
+				sourceFiles = append(sourceFiles, arg)
+				continue
+			}
+			matches, err := builder.FindFiles(arg)
+			if err != nil {
+				return fmt.Errorf("failed to find Go files: %w", err)
+			}
+			sourceFiles = append(sourceFiles, matches...)
+		}
 	} else {
 		// Default to all Go files in current directory
 		matches, err := filepath.Glob("*.go")
@@ -91,6 +138,14 @@ func runOpenAPI(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no Go files found\nUsage: apikit openapi [files...]")
 	}
 
+	sourceFiles, err := excludeFiles(sourceFiles, openapiExclude)
+	if err != nil {
+		return err
+	}
+	if len(sourceFiles) == 0 {
+		return fmt.Errorf("no Go files left after --exclude")
+	}
+
 	// Get current directory
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -128,6 +183,16 @@ func runOpenAPI(cmd *cobra.Command, args []string) error {
 		parseResults = append(parseResults, result)
 	}
 
+	// Check the enums package before writing anything
+	var enumsPkg string
+	var enumsReserved []string
+	if openapiEmitEnums != "" {
+		enumsPkg, enumsReserved, err = enumsPackage(parseResults)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Extract OpenAPI specification(s)
 	if openapiMultiSpec {
 		// Multi-spec mode
@@ -140,6 +205,11 @@ func runOpenAPI(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("extracting OpenAPI specs: %w", err)
 		}
 
+		for _, spec := range specs {
+			applyBasePath(spec, openapiBasePath)
+			config.apply(spec)
+		}
+
 		// Override metadata if provided
 		if openapiTitle != "" || openapiVer != "" {
 			for _, spec := range specs {
@@ -152,6 +222,13 @@ func runOpenAPI(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		// Models are shared across specs, so the default spec carries all enums
+		if openapiEmitEnums != "" {
+			if err := writeEnums(specs["default"], enumsPkg, enumsReserved); err != nil {
+				return err
+			}
+		}
+
 		// Write each spec to its own file
 		for specName, spec := range specs {
 			// Skip empty specs (no routes)
@@ -212,6 +289,9 @@ func runOpenAPI(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("extracting OpenAPI spec: %w", err)
 		}
 
+		applyBasePath(spec, openapiBasePath)
+		config.apply(spec)
+
 		// Override metadata if provided
 		if openapiTitle != "" {
 			spec.Info.Title = openapiTitle
@@ -240,6 +320,12 @@ func runOpenAPI(cmd *cobra.Command, args []string) error {
 		}
 
 		fmt.Printf("✓ Generated OpenAPI specification: %s\n", openapiOutput)
+
+		if openapiEmitEnums != "" {
+			if err := writeEnums(spec, enumsPkg, enumsReserved); err != nil {
+				return err
+			}
+		}
 		if verbose {
 			log.Printf("  Format: %s", openapiFormat)
 			log.Printf("  Title: %s", spec.Info.Title)
@@ -253,3 +339,76 @@ func runOpenAPI(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// applyBasePath prefixes every path of the spec with basePath
+// Path parameters are kept as they are, and the root path maps to the base path itself
+// Example: "api/v1/" + "/users/{id}" -> "/api/v1/users/{id}"
+func applyBasePath(openapi *spec.OpenAPI, basePath string) {
+	basePath = strings.TrimRight(basePath, "/")
+	if basePath == "" || openapi.Paths == nil {
+		return
+	}
+	if !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+
+	pathItems := make(map[string]*spec.PathItem, len(openapi.Paths.PathItems))
+	for path, item := range openapi.Paths.PathItems {
+		if path == "/" {
+			pathItems[basePath] = item
+			continue
+		}
+		pathItems[basePath+path] = item
+	}
+	openapi.Paths.PathItems = pathItems
+}
+
+// enumsPackage returns the package of the --emit-enums file and the identifiers
+// the package already declares, which generated enum names must avoid
+// The sources must form a single package; the enums file itself is skipped,
+// so its previous output doesn't reserve the names it regenerates
+func enumsPackage(parseResults []*coreast.ParseResult) (string, []string, error) {
+	enumsFile, err := filepath.Abs(openapiEmitEnums)
+	if err != nil {
+		return "", nil, fmt.Errorf("resolving %s: %w", openapiEmitEnums, err)
+	}
+
+	var sources []*coreast.ParseResult
+	packages := make(map[string]bool)
+	for _, result := range parseResults {
+		if filename, err := filepath.Abs(result.Filename); err == nil && filename == enumsFile {
+			continue
+		}
+		sources = append(sources, result)
+		packages[filepath.Dir(result.Filename)+" ("+result.Package+")"] = true
+	}
+	if len(packages) > 1 {
+		return "", nil, fmt.Errorf("--emit-enums needs source files of a single package, got %s",
+			strings.Join(slices.Sorted(maps.Keys(packages)), ", "))
+	}
+
+	var packageName string
+	if len(sources) > 0 {
+		packageName = sources[0].Package
+	}
+	return packageName, builder.DeclaredNames(sources), nil
+}
+
+// writeEnums writes the Go enum types of the spec's models to the --emit-enums file
+func writeEnums(openapi *spec.OpenAPI, packageName string, reserved []string) error {
+	src, err := builder.GenerateEnums(openapi, packageName, reserved)
+	if err != nil {
+		return fmt.Errorf("generating enums: %w", err)
+	}
+	if src == nil {
+		fmt.Println("No enum fields found, skipping", openapiEmitEnums)
+		return nil
+	}
+
+	if err := os.WriteFile(openapiEmitEnums, src, 0644); err != nil {
+		return fmt.Errorf("writing enums file: %w", err)
+	}
+
+	fmt.Printf("✓ Generated enum constants: %s\n", openapiEmitEnums)
+	return nil
+}
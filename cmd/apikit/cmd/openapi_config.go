@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/reation-io/apikit/openapi/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIConfig is the --config file of the openapi command
+// Example:
+//
+//	info:
+//	  title: Pet Store
+//	  contact:
+//	    email: api@example.com
+//	servers:
+//	  - url: https://api.example.com
+//	security:
+//	  - bearerAuth: []
+//	securitySchemes:
+//	  bearerAuth:
+//	    type: http
+//	    scheme: bearer
+type openAPIConfig struct {
+	Info            *spec.Info                      `yaml:"info"`
+	Servers         []*spec.Server                  `yaml:"servers"`
+	Security        []spec.SecurityRequirement      `yaml:"security"`
+	SecuritySchemes map[string]*spec.SecurityScheme `yaml:"securitySchemes"`
+}
+
+// loadOpenAPIConfig reads the --config file
+// Unknown keys are rejected so typos don't go unnoticed
+func loadOpenAPIConfig(path string) (*openAPIConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	defer f.Close()
+
+	var config openAPIConfig
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&config); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// apply merges the config over the extracted spec
+// Info fields set in the config replace the annotated ones, servers and
+// security replace the annotated lists, and security schemes are added
+// to (or replace) the annotated schemes of the same name
+func (c *openAPIConfig) apply(openapi *spec.OpenAPI) {
+	if c == nil {
+		return
+	}
+
+	if c.Info != nil {
+		if openapi.Info == nil {
+			openapi.Info = &spec.Info{}
+		}
+		mergeInfo(openapi.Info, c.Info)
+	}
+
+	if len(c.Servers) > 0 {
+		openapi.Servers = c.Servers
+	}
+	if len(c.Security) > 0 {
+		openapi.Security = c.Security
+	}
+
+	if len(c.SecuritySchemes) > 0 {
+		if openapi.Components == nil {
+			openapi.Components = &spec.Components{}
+		}
+		if openapi.Components.SecuritySchemes == nil {
+			openapi.Components.SecuritySchemes = make(map[string]*spec.SecurityScheme)
+		}
+		for name, scheme := range c.SecuritySchemes {
+			openapi.Components.SecuritySchemes[name] = scheme
+		}
+	}
+}
+
+// mergeInfo copies the fields set in src over dst
+func mergeInfo(dst, src *spec.Info) {
+	if src.Title != "" {
+		dst.Title = src.Title
+	}
+	if src.Description != "" {
+		dst.Description = src.Description
+	}
+	if src.TermsOfService != "" {
+		dst.TermsOfService = src.TermsOfService
+	}
+	if src.Contact != nil {
+		dst.Contact = src.Contact
+	}
+	if src.License != nil {
+		dst.License = src.License
+	}
+	if src.Version != "" {
+		dst.Version = src.Version
+	}
+	for k, v := range src.Extensions {
+		if dst.Extensions == nil {
+			dst.Extensions = make(map[string]any)
+		}
+		dst.Extensions[k] = v
+	}
+}
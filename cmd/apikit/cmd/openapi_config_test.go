@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestOpenAPICommandConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "test.go")
+	content := `package test
+
+// swagger:meta
+// Title: Annotated API
+// Version: 1.0.0
+// Description: From the annotations
+type Meta struct{}
+
+// swagger:route GET /pets pets listPets
+type ListPetsRequest struct{}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	configFile := filepath.Join(tmpDir, "apikit.yaml")
+	config := `info:
+  title: Config API
+  contact:
+    email: api@example.com
+servers:
+  - url: https://api.example.com
+    description: Production
+security:
+  - bearerAuth: []
+securitySchemes:
+  bearerAuth:
+    type: http
+    scheme: bearer
+`
+	if err := os.WriteFile(configFile, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to create config file: %v", err)
+	}
+
+	openapiOutput = filepath.Join(tmpDir, "openapi.json")
+	openapiFormat = "json"
+	openapiTitle = ""
+	openapiVer = "2.0.0"
+	openapiConfig = configFile
+	defer func() {
+		openapiVer = ""
+		openapiConfig = ""
+	}()
+
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	if err := runOpenAPI(nil, []string{"test.go"}); err != nil {
+		t.Fatalf("runOpenAPI failed: %v", err)
+	}
+
+	data, err := os.ReadFile(openapiOutput)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	var openapi spec.OpenAPI
+	if err := json.Unmarshal(data, &openapi); err != nil {
+		t.Fatalf("failed to parse OpenAPI JSON: %v", err)
+	}
+
+	if openapi.Info.Title != "Config API" {
+		t.Errorf("expected config title, got %q", openapi.Info.Title)
+	}
+	if openapi.Info.Version != "2.0.0" {
+		t.Errorf("expected --version to take precedence, got %q", openapi.Info.Version)
+	}
+	if openapi.Info.Description != "From the annotations" {
+		t.Errorf("expected annotated description to be kept, got %q", openapi.Info.Description)
+	}
+	if openapi.Info.Contact == nil || openapi.Info.Contact.Email != "api@example.com" {
+		t.Errorf("expected contact email from config, got %+v", openapi.Info.Contact)
+	}
+	if len(openapi.Servers) != 1 || openapi.Servers[0].URL != "https://api.example.com" {
+		t.Errorf("expected server from config, got %+v", openapi.Servers)
+	}
+	if len(openapi.Security) != 1 || openapi.Security[0]["bearerAuth"] == nil {
+		t.Errorf("expected bearerAuth security requirement, got %+v", openapi.Security)
+	}
+	if openapi.Components == nil || openapi.Components.SecuritySchemes["bearerAuth"] == nil ||
+		openapi.Components.SecuritySchemes["bearerAuth"].Scheme != "bearer" {
+		t.Errorf("expected bearerAuth security scheme, got %+v", openapi.Components)
+	}
+}
+
+func TestLoadOpenAPIConfig_UnknownKey(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "apikit.yaml")
+	if err := os.WriteFile(configFile, []byte("server:\n  - url: https://api.example.com\n"), 0644); err != nil {
+		t.Fatalf("failed to create config file: %v", err)
+	}
+
+	_, err := loadOpenAPIConfig(configFile)
+	if err == nil || !strings.Contains(err.Error(), "server") {
+		t.Errorf("expected an error for the unknown key, got %v", err)
+	}
+}
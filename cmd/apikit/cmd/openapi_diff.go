@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/reation-io/apikit/openapi/spec"
+	"github.com/spf13/cobra"
+)
+
+// openapiDiffCmd represents the openapi diff command
+var openapiDiffCmd = &cobra.Command{
+	Use:   "diff <old> <new>",
+	Short: "Report changes between two OpenAPI specifications",
+	Long: `Compare two OpenAPI specification files (JSON or YAML) and report the
+changes relevant to API clients.
+
+The following changes are breaking:
+  • Removed paths, operations and response codes
+  • Added required parameters or request bodies
+  • Optional parameters or request bodies becoming required
+  • Incompatible schema type changes
+
+Added paths, operations, optional parameters and response codes are reported
+as non-breaking. The command exits with a non-zero status when a breaking
+change is found, which makes it suitable for CI.
+
+Examples:
+  apikit openapi diff old.json new.json
+  apikit openapi diff main/openapi.yaml openapi.yaml`,
+	Args: cobra.ExactArgs(2),
+	RunE: runOpenAPIDiff,
+}
+
+func init() {
+	openapiCmd.AddCommand(openapiDiffCmd)
+}
+
+func runOpenAPIDiff(cmd *cobra.Command, args []string) error {
+	oldSpec, err := loadSpec(args[0])
+	if err != nil {
+		return err
+	}
+	newSpec, err := loadSpec(args[1])
+	if err != nil {
+		return err
+	}
+
+	changes := spec.Diff(oldSpec, newSpec)
+	if len(changes) == 0 {
+		fmt.Println("✓ No changes")
+		return nil
+	}
+
+	breaking := 0
+	for _, change := range changes {
+		if change.Breaking {
+			breaking++
+			fmt.Fprintf(os.Stderr, "  ✗ breaking: %s\n", change.Message)
+		} else {
+			fmt.Printf("  • %s\n", change.Message)
+		}
+	}
+
+	if breaking > 0 {
+		return fmt.Errorf("%d breaking change(s) found", breaking)
+	}
+
+	fmt.Printf("✓ %d non-breaking change(s)\n", len(changes))
+	return nil
+}
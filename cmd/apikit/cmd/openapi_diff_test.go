@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const diffBaseSpec = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {"200": {"description": "OK"}}
+      }
+    },
+    "/pets/{id}": {
+      "delete": {
+        "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+        "responses": {"204": {"description": "No Content"}}
+      }
+    }
+  }
+}`
+
+// writeDiffSpecs writes the old and new specs to a temp directory
+func writeDiffSpecs(t *testing.T, oldContent, newContent string) []string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	files := []string{filepath.Join(tmpDir, "old.json"), filepath.Join(tmpDir, "new.json")}
+	for i, content := range []string{oldContent, newContent} {
+		if err := os.WriteFile(files[i], []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write spec: %v", err)
+		}
+	}
+	return files
+}
+
+func TestOpenAPIDiff_RemovedEndpoint(t *testing.T) {
+	newContent := `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.1.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  }
+}`
+
+	err := runOpenAPIDiff(nil, writeDiffSpecs(t, diffBaseSpec, newContent))
+	if err == nil || !strings.Contains(err.Error(), "1 breaking change(s)") {
+		t.Errorf("expected 1 breaking change, got: %v", err)
+	}
+}
+
+func TestOpenAPIDiff_AddedOptionalParameter(t *testing.T) {
+	newContent := strings.Replace(diffBaseSpec,
+		`"get": {`,
+		`"get": {
+        "parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer"}}],`, 1)
+
+	if err := runOpenAPIDiff(nil, writeDiffSpecs(t, diffBaseSpec, newContent)); err != nil {
+		t.Errorf("expected added optional parameter to be non-breaking, got: %v", err)
+	}
+}
@@ -2,8 +2,15 @@ package cmd
 
 import (
 	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/reation-io/apikit/openapi/spec"
@@ -180,3 +187,358 @@ type GetTestRequest struct{}
 		t.Errorf("expected version '2.0.0', got %q", openapi.Info.Version)
 	}
 }
+
+func TestOpenAPICommandEmitEnums(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "models.go")
+	content := `package store
+
+// Order is a store order
+// swagger:model
+type Order struct {
+	// enum: placed,approved,delivered
+	Status string ` + "`json:\"status\"`" + `
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	openapiOutput = filepath.Join(tmpDir, "openapi.json")
+	openapiFormat = "json"
+	openapiTitle = ""
+	openapiVer = ""
+	openapiEmitEnums = filepath.Join(tmpDir, "enums_apikit.go")
+	defer func() { openapiEmitEnums = "" }()
+
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	if err := runOpenAPI(nil, []string{"models.go"}); err != nil {
+		t.Fatalf("runOpenAPI failed: %v", err)
+	}
+
+	data, err := os.ReadFile(openapiEmitEnums)
+	if err != nil {
+		t.Fatalf("failed to read enums file: %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), openapiEmitEnums, data, 0); err != nil {
+		t.Fatalf("generated enums don't parse: %v\n%s", err, data)
+	}
+
+	generated := string(data)
+	for _, want := range []string{
+		"package store",
+		"type OrderStatus string",
+		`OrderStatusPlaced    OrderStatus = "placed"`,
+		`OrderStatusApproved  OrderStatus = "approved"`,
+		`OrderStatusDelivered OrderStatus = "delivered"`,
+	} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("expected %q in generated enums:\n%s", want, generated)
+		}
+	}
+}
+
+func TestOpenAPICommandEmitEnumsReservedNames(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// OrderStatus is declared by the package, but inlined as a string in the spec
+	testFile := filepath.Join(tmpDir, "models.go")
+	content := `package store
+
+type OrderStatus string
+
+// Order is a store order
+// swagger:model
+type Order struct {
+	// enum: placed,delivered
+	Status OrderStatus ` + "`json:\"status\"`" + `
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	// A module lets the named type be resolved to its underlying string
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/store\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatalf("failed to create go.mod: %v", err)
+	}
+
+	openapiOutput = filepath.Join(tmpDir, "openapi.json")
+	openapiFormat = "json"
+	openapiTitle = ""
+	openapiVer = ""
+	openapiEmitEnums = filepath.Join(tmpDir, "enums_apikit.go")
+	defer func() { openapiEmitEnums = "" }()
+
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	// The second run checks that the previous enums file doesn't reserve its own names
+	for _, args := range [][]string{{"models.go"}, {"models.go", "enums_apikit.go"}} {
+		if err := runOpenAPI(nil, args); err != nil {
+			t.Fatalf("runOpenAPI(%v) failed: %v", args, err)
+		}
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range []string{testFile, openapiEmitEnums} {
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", name, err)
+		}
+		files = append(files, file)
+	}
+	if _, err := (&types.Config{}).Check("store", fset, files, nil); err != nil {
+		t.Errorf("generated enums don't compile with the package: %v", err)
+	}
+
+	data, _ := os.ReadFile(openapiEmitEnums)
+	if !strings.Contains(string(data), "type OrderStatus2 string") {
+		t.Errorf("expected the enum type to avoid OrderStatus:\n%s", data)
+	}
+}
+
+func TestOpenAPICommandEmitEnumsSeveralPackages(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"store/models.go": "package store\n\n// swagger:model\ntype Order struct{}\n",
+		"users/models.go": "package users\n\n// swagger:model\ntype User struct{}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	openapiOutput = filepath.Join(tmpDir, "openapi.json")
+	openapiFormat = "json"
+	openapiTitle = ""
+	openapiVer = ""
+	openapiEmitEnums = filepath.Join(tmpDir, "enums_apikit.go")
+	defer func() { openapiEmitEnums = "" }()
+
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	err := runOpenAPI(nil, []string{"./..."})
+	if err == nil || !strings.Contains(err.Error(), "single package") {
+		t.Fatalf("expected a single package error, got %v", err)
+	}
+	if _, err := os.Stat(openapiOutput); !os.IsNotExist(err) {
+		t.Errorf("expected no spec to be written, got %v", err)
+	}
+}
+
+func TestOpenAPICommandBasePath(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "test.go")
+	content := `package test
+
+// swagger:route GET / meta getRoot
+type GetRootRequest struct{}
+
+// swagger:route GET /users users listUsers
+type ListUsersRequest struct{}
+
+// swagger:route GET /users/{id} users getUser
+// Spec: public
+type GetUserRequest struct {
+	// in: path
+	ID string ` + "`path:\"id\"`" + `
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	openapiFormat = "json"
+	openapiTitle = ""
+	openapiVer = ""
+	openapiBasePath = "api/v1/"
+	defer func() { openapiBasePath = "" }()
+
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	readPaths := func(t *testing.T, file string) []string {
+		t.Helper()
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read output file: %v", err)
+		}
+		var openapi spec.OpenAPI
+		if err := json.Unmarshal(data, &openapi); err != nil {
+			t.Fatalf("failed to parse OpenAPI JSON: %v", err)
+		}
+		return slices.Sorted(maps.Keys(openapi.Paths.PathItems))
+	}
+
+	t.Run("single spec", func(t *testing.T) {
+		openapiOutput = filepath.Join(tmpDir, "openapi.json")
+		if err := runOpenAPI(nil, []string{"test.go"}); err != nil {
+			t.Fatalf("runOpenAPI failed: %v", err)
+		}
+
+		want := []string{"/api/v1", "/api/v1/users", "/api/v1/users/{id}"}
+		if got := readPaths(t, openapiOutput); !slices.Equal(got, want) {
+			t.Errorf("paths = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("multi spec", func(t *testing.T) {
+		openapiMultiSpec = true
+		openapiOutputDir = filepath.Join(tmpDir, "specs")
+		defer func() {
+			openapiMultiSpec = false
+			openapiOutputDir = "."
+		}()
+		if err := os.Mkdir(openapiOutputDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := runOpenAPI(nil, []string{"test.go"}); err != nil {
+			t.Fatalf("runOpenAPI failed: %v", err)
+		}
+
+		want := []string{"/api/v1", "/api/v1/users"}
+		if got := readPaths(t, filepath.Join(openapiOutputDir, "default.json")); !slices.Equal(got, want) {
+			t.Errorf("default paths = %v, want %v", got, want)
+		}
+		want = []string{"/api/v1/users/{id}"}
+		if got := readPaths(t, filepath.Join(openapiOutputDir, "public.json")); !slices.Equal(got, want) {
+			t.Errorf("public paths = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestOpenAPICommandExclude(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	sources := map[string]string{
+		"models.go": `package store
+
+// Order is a store order
+// swagger:model
+type Order struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+`,
+		"fixtures_test.go": `package store
+
+// Fixture is only used by tests
+// swagger:model
+type Fixture struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+	}
+	for name, content := range sources {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	openapiOutput = filepath.Join(tmpDir, "openapi.json")
+	openapiFormat = "json"
+	openapiTitle = ""
+	openapiVer = ""
+	openapiExclude = []string{"*_test.go"}
+	defer func() { openapiExclude = nil }()
+
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	// No arguments scans every Go file in the directory
+	if err := runOpenAPI(nil, nil); err != nil {
+		t.Fatalf("runOpenAPI failed: %v", err)
+	}
+
+	data, err := os.ReadFile(openapiOutput)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if !strings.Contains(string(data), `"Order"`) {
+		t.Errorf("expected Order schema, got:\n%s", data)
+	}
+	if strings.Contains(string(data), `"Fixture"`) {
+		t.Errorf("expected excluded Fixture schema to be skipped, got:\n%s", data)
+	}
+}
+
+func TestOpenAPICommandRecursive(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	sources := map[string]string{
+		"meta.go": `package api
+
+// swagger:meta
+// Title: Nested API
+// Version: 1.0.0
+type Meta struct{}
+`,
+		"internal/models/order.go": `package models
+
+// Order is a store order
+// swagger:model
+type Order struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+`,
+		"vendor/dep/dep.go": `package dep
+
+// Vendored is a vendored model
+// swagger:model
+type Vendored struct{}
+`,
+	}
+	for name, content := range sources {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	openapiOutput = filepath.Join(tmpDir, "openapi.json")
+	openapiFormat = "json"
+	openapiTitle = ""
+	openapiVer = ""
+
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	if err := runOpenAPI(nil, []string{"./..."}); err != nil {
+		t.Fatalf("runOpenAPI failed: %v", err)
+	}
+
+	data, err := os.ReadFile(openapiOutput)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	for _, want := range []string{`"Nested API"`, `"Order"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in output, got:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), `"Vendored"`) {
+		t.Errorf("expected vendor/ to be skipped, got:\n%s", data)
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/reation-io/apikit/openapi/spec"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// openapiValidateCmd represents the openapi validate command
+var openapiValidateCmd = &cobra.Command{
+	Use:   "validate <file>",
+	Short: "Validate an OpenAPI specification",
+	Long: `Validate an OpenAPI specification file (JSON or YAML).
+
+The following problems are reported:
+  • References to schemas missing from #/components/schemas
+  • Operations without responses
+  • Duplicate operationIds
+  • Missing required fields (openapi, info.title, info.version, ...)
+
+The command exits with a non-zero status when the spec is invalid.
+
+Examples:
+  apikit openapi validate openapi.json
+  apikit openapi validate openapi.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOpenAPIValidate,
+}
+
+func init() {
+	openapiCmd.AddCommand(openapiValidateCmd)
+}
+
+func runOpenAPIValidate(cmd *cobra.Command, args []string) error {
+	file := args[0]
+
+	openapi, err := loadSpec(file)
+	if err != nil {
+		return err
+	}
+
+	if err := openapi.Validate(); err != nil {
+		var validationErr *spec.ValidationError
+		if errors.As(err, &validationErr) {
+			fmt.Fprintf(os.Stderr, "%s is invalid:\n", file)
+			for _, problem := range validationErr.Problems {
+				fmt.Fprintf(os.Stderr, "  • %s\n", problem)
+			}
+			return fmt.Errorf("%d validation error(s) found in %s", len(validationErr.Problems), file)
+		}
+		return err
+	}
+
+	fmt.Printf("✓ %s is valid\n", file)
+	return nil
+}
+
+// loadSpec reads a JSON or YAML spec file, choosing the format by extension
+func loadSpec(file string) (*spec.OpenAPI, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec: %w", err)
+	}
+
+	openapi := &spec.OpenAPI{}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, openapi)
+	default:
+		err = json.Unmarshal(data, openapi)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing spec %s: %w", file, err)
+	}
+
+	return openapi, nil
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestOpenAPIValidate_Clean(t *testing.T) {
+	tmpDir := t.TempDir()
+	specFile := filepath.Join(tmpDir, "openapi.json")
+	content := `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"name": {"type": "string"}}}
+    }
+  }
+}`
+	if err := os.WriteFile(specFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	if err := runOpenAPIValidate(nil, []string{specFile}); err != nil {
+		t.Errorf("expected clean spec to be valid, got: %v", err)
+	}
+}
+
+func TestOpenAPIValidate_DanglingRef(t *testing.T) {
+	tmpDir := t.TempDir()
+	specFile := filepath.Join(tmpDir, "openapi.json")
+	content := `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "post": {
+        "operationId": "createPet",
+        "requestBody": {
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/NewPet"}}}
+        },
+        "responses": {
+          "201": {"description": "Created"}
+        }
+      }
+    }
+  },
+  "components": {"schemas": {"Pet": {"type": "object"}}}
+}`
+	if err := os.WriteFile(specFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	err := runOpenAPIValidate(nil, []string{specFile})
+	if err == nil {
+		t.Fatal("expected validation error for dangling ref")
+	}
+
+	openapi, err := loadSpec(specFile)
+	if err != nil {
+		t.Fatalf("loadSpec failed: %v", err)
+	}
+	problems := validationProblems(t, openapi)
+	if len(problems) != 1 || !strings.Contains(problems[0], "#/components/schemas/NewPet not found") {
+		t.Errorf("expected a single dangling ref problem, got %v", problems)
+	}
+}
+
+func TestOpenAPIValidate_YAMLProblems(t *testing.T) {
+	tmpDir := t.TempDir()
+	specFile := filepath.Join(tmpDir, "openapi.yaml")
+	content := `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: pets
+      responses:
+        "200":
+          description: OK
+  /pets/{id}:
+    get:
+      operationId: pets
+`
+	if err := os.WriteFile(specFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	openapi, err := loadSpec(specFile)
+	if err != nil {
+		t.Fatalf("loadSpec failed: %v", err)
+	}
+
+	problems := strings.Join(validationProblems(t, openapi), "\n")
+	if !strings.Contains(problems, "GET /pets/{id}: operation has no responses") {
+		t.Errorf("expected missing responses problem, got:\n%s", problems)
+	}
+	if !strings.Contains(problems, `duplicate operationId "pets": GET /pets, GET /pets/{id}`) {
+		t.Errorf("expected duplicate operationId problem, got:\n%s", problems)
+	}
+}
+
+func TestOpenAPIValidate_GeneratedSpec(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+	content := `package test
+
+// swagger:meta
+type Meta struct{}
+
+// Title: Test API
+// Version: 1.0.0
+
+// User represents a user
+// swagger:model
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// CreateUserRequest creates a user
+// swagger:route POST /users user createUser
+// Summary: Create user
+// Responses:
+// - 201: User
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	openapiOutput = filepath.Join(tmpDir, "openapi.yaml")
+	openapiFormat = "yaml"
+	openapiTitle = ""
+	openapiVer = ""
+	defer func() { openapiFormat = "json" }()
+
+	// Change to temp directory so relative paths work
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	if err := runOpenAPI(nil, []string{"test.go"}); err != nil {
+		t.Fatalf("runOpenAPI failed: %v", err)
+	}
+
+	if err := runOpenAPIValidate(nil, []string{openapiOutput}); err != nil {
+		t.Errorf("expected generated spec to be valid, got: %v", err)
+	}
+}
+
+// validationProblems returns the problems reported by Validate
+func validationProblems(t *testing.T, openapi *spec.OpenAPI) []string {
+	t.Helper()
+
+	err := openapi.Validate()
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *spec.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *spec.ValidationError, got %T", err)
+	}
+	return validationErr.Problems
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/reation-io/apikit/handler/checksum"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [dir]",
+	Short: "Check that generated files are up to date",
+	Long: `Check that every *_apikit.go file under a directory was generated from
+the current version of its source file.
+
+Each generated file embeds the checksum of its source (<name>.go). The command
+recomputes it and reports generated files whose source changed, is missing,
+or that carry no checksum. Files written with a custom --output name are not
+checked. Directories starting with "." or "_", and vendor, are skipped.
+
+The command exits with a non-zero status when a stale file is found, which
+makes it suitable for CI.
+
+Examples:
+  # Verify the current directory tree
+  apikit verify
+
+  # Verify a single package
+  apikit verify ./internal/api`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runVerify,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	generated, stale, err := findStaleGenerated(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(stale) > 0 {
+		fmt.Fprintln(os.Stderr, "Stale generated files (run go generate):")
+		for _, problem := range stale {
+			fmt.Fprintf(os.Stderr, "  • %s\n", problem)
+		}
+		return fmt.Errorf("%d of %d generated file(s) are stale", len(stale), generated)
+	}
+
+	fmt.Printf("✓ %d generated file(s) up to date\n", generated)
+	return nil
+}
+
+// findStaleGenerated walks dir for *_apikit.go files and compares their embedded
+// checksum with their source file
+// Returns the number of generated files and a description of each stale one
+func findStaleGenerated(dir string) (int, []string, error) {
+	generated := 0
+	var stale []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != dir && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, "_apikit.go") {
+			return nil
+		}
+
+		generated++
+		if problem, err := checkGenerated(path); err != nil {
+			return err
+		} else if problem != "" {
+			stale = append(stale, fmt.Sprintf("%s: %s", path, problem))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("walking %s: %w", dir, err)
+	}
+
+	return generated, stale, nil
+}
+
+// checkGenerated reports why a generated file is stale, or empty string if it is up to date
+func checkGenerated(generatedFile string) (string, error) {
+	sourceFile := strings.TrimSuffix(generatedFile, "_apikit.go") + ".go"
+
+	stored, err := checksum.ExtractChecksum(generatedFile)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", generatedFile, err)
+	}
+	if stored == "" {
+		return "no embedded checksum", nil
+	}
+
+	current, err := checksum.CalculateFileChecksum(sourceFile)
+	if os.IsNotExist(err) {
+		return fmt.Sprintf("source %s not found", filepath.Base(sourceFile)), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("calculating checksum of %s: %w", sourceFile, err)
+	}
+
+	if current != stored {
+		return fmt.Sprintf("source %s changed", filepath.Base(sourceFile)), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/handler/checksum"
+)
+
+// writeGeneratedPair writes a source file and a generated file carrying its checksum
+func writeGeneratedPair(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	source := filepath.Join(dir, name+".go")
+	if err := os.WriteFile(source, []byte("package api\n"), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	sum, err := checksum.CalculateFileChecksum(source)
+	if err != nil {
+		t.Fatalf("failed to checksum source: %v", err)
+	}
+
+	code := checksum.AddChecksumToGenerated([]byte("// Code generated by apikit. DO NOT EDIT.\n\npackage api\n"), sum)
+	if err := os.WriteFile(filepath.Join(dir, name+"_apikit.go"), code, 0644); err != nil {
+		t.Fatalf("failed to write generated file: %v", err)
+	}
+	return source
+}
+
+func TestVerify_UpToDate(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeGeneratedPair(t, tmpDir, "users")
+	writeGeneratedPair(t, tmpDir, "pets")
+
+	generated, stale, err := findStaleGenerated(tmpDir)
+	if err != nil {
+		t.Fatalf("findStaleGenerated failed: %v", err)
+	}
+	if generated != 2 || len(stale) != 0 {
+		t.Errorf("expected 2 up-to-date files, got %d generated, stale %v", generated, stale)
+	}
+
+	if err := runVerify(nil, []string{tmpDir}); err != nil {
+		t.Errorf("expected verify to pass, got: %v", err)
+	}
+}
+
+func TestVerify_Stale(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeGeneratedPair(t, tmpDir, "users")
+
+	nested := filepath.Join(tmpDir, "internal")
+	if err := os.Mkdir(nested, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	source := writeGeneratedPair(t, nested, "pets")
+	if err := os.WriteFile(source, []byte("package api\n\n// edited\n"), 0644); err != nil {
+		t.Fatalf("failed to edit source: %v", err)
+	}
+
+	// A generated file without checksum and one without source are stale too
+	if err := os.WriteFile(filepath.Join(tmpDir, "orders_apikit.go"), []byte("package api\n"), 0644); err != nil {
+		t.Fatalf("failed to write generated file: %v", err)
+	}
+	if err := os.Remove(writeGeneratedPair(t, tmpDir, "stores")); err != nil {
+		t.Fatalf("failed to remove source: %v", err)
+	}
+
+	generated, stale, err := findStaleGenerated(tmpDir)
+	if err != nil {
+		t.Fatalf("findStaleGenerated failed: %v", err)
+	}
+	if generated != 4 {
+		t.Errorf("expected 4 generated files, got %d", generated)
+	}
+
+	got := strings.Join(stale, "\n")
+	for _, want := range []string{"pets_apikit.go: source pets.go changed", "orders_apikit.go: no embedded checksum", "stores_apikit.go: source stores.go not found"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in stale files, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "users_apikit.go") {
+		t.Errorf("expected users_apikit.go to be up to date, got:\n%s", got)
+	}
+
+	err = runVerify(nil, []string{tmpDir})
+	if err == nil || !strings.Contains(err.Error(), "3 of 4") {
+		t.Errorf("expected verify to fail with 3 of 4 stale files, got: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/reation-io/apikit/handler/checksum"
+)
+
+var (
+	// watchInterval is how often watched files are checked for changes
+	watchInterval = 500 * time.Millisecond
+
+	// watchDebounce is how long a file must stay unchanged before it's regenerated,
+	// so a burst of saves triggers a single regeneration
+	watchDebounce = 300 * time.Millisecond
+)
+
+// watchFiles polls the source files and regenerates their wrappers when their
+// checksum changes, until ctx is cancelled
+func watchFiles(ctx context.Context, files []string) error {
+	checksums := make(map[string]string)
+	for _, file := range files {
+		checksums[file], _ = checksum.CalculateFileChecksum(file)
+	}
+
+	// pending holds changed files and the time of their latest change
+	pending := make(map[string]time.Time)
+
+	log.Printf("Watching %d file(s) for changes (Ctrl+C to stop)", len(files))
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Stopped watching")
+			return nil
+		case now := <-ticker.C:
+			for _, file := range files {
+				sum, err := checksum.CalculateFileChecksum(file)
+				if err != nil || sum == checksums[file] {
+					continue
+				}
+				checksums[file] = sum
+				pending[file] = now
+			}
+
+			// Keep input order so regenerations are logged deterministically
+			for _, file := range files {
+				changedAt, ok := pending[file]
+				if !ok || now.Sub(changedAt) < watchDebounce {
+					continue
+				}
+				delete(pending, file)
+				regenerate(file)
+			}
+		}
+	}
+}
+
+// regenerate parses and generates a single changed file, logging the outcome
+// Errors are logged instead of returned so watching continues after a bad edit
+func regenerate(file string) {
+	source := parseSource(file)
+	if source.unchanged {
+		// The edit was reverted before it was picked up
+		return
+	}
+	if err := generateFromSource(source); err != nil {
+		log.Printf("Error regenerating %s: %v", file, err)
+		return
+	}
+	log.Printf("Regenerated %s", source.output)
+}
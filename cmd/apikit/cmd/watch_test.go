@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchFiles_RegeneratesOnChange(t *testing.T) {
+	force, outputFile, dryRun = false, "", false
+
+	oldInterval, oldDebounce := watchInterval, watchDebounce
+	watchInterval, watchDebounce = 10*time.Millisecond, 30*time.Millisecond
+	defer func() { watchInterval, watchDebounce = oldInterval, oldDebounce }()
+
+	files := writeHandlerFiles(t, t.TempDir(), 1)
+	if err := generateFiles(files, 1); err != nil {
+		t.Fatalf("initial generation failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- watchFiles(ctx, files)
+	}()
+
+	// Add a second handler to the watched file
+	source, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("failed to read source: %v", err)
+	}
+	source = append(source, []byte(`
+// apikit:handler
+func AddedHandler(ctx context.Context, req Request0) (Response0, error) {
+	return Response0{}, nil
+}
+`)...)
+	time.Sleep(2 * watchInterval)
+	if err := os.WriteFile(files[0], source, 0644); err != nil {
+		t.Fatalf("failed to modify source: %v", err)
+	}
+
+	output := strings.TrimSuffix(files[0], ".go") + "_apikit.go"
+	deadline := time.Now().Add(5 * time.Second)
+	regenerated := false
+	for time.Now().Before(deadline) {
+		code, err := os.ReadFile(output)
+		if err == nil && strings.Contains(string(code), "AddedHandler") {
+			regenerated = true
+			break
+		}
+		time.Sleep(watchInterval)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watchFiles returned error: %v", err)
+	}
+
+	if !regenerated {
+		t.Error("expected modified file to be regenerated")
+	}
+}
@@ -0,0 +1,119 @@
+package apikit
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WriteJSONGzip writes a JSON response with default 200 OK status,
+// gzip-compressed when the request's Accept-Encoding allows it
+// Clients without gzip support get plain JSON
+func WriteJSONGzip(w http.ResponseWriter, r *http.Request, data any) {
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	if !AcceptsGzip(r) {
+		WriteJSON(w, data)
+		return
+	}
+
+	// Encode first so encoding errors can still be reported uncompressed
+	body, err := json.Marshal(data)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Encoding", "gzip")
+	// The compressed length is unknown upfront
+	w.Header().Del("Content-Length")
+
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	gz.Write(append(body, '\n'))
+}
+
+// GzipResponseWriter gzip-compresses the response body written through it when
+// the request's Accept-Encoding allows it, keeping the status and headers set by
+// the caller
+// Close must be called to flush the compressed stream
+// Example:
+//
+//	gw := apikit.NewGzipResponseWriter(w, r)
+//	defer gw.Close()
+//	apikit.HandleResponse(gw, response, err)
+type GzipResponseWriter struct {
+	http.ResponseWriter
+	accepted    bool
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+// NewGzipResponseWriter wraps w, compressing the body when r accepts gzip
+func NewGzipResponseWriter(w http.ResponseWriter, r *http.Request) *GzipResponseWriter {
+	w.Header().Add("Vary", "Accept-Encoding")
+	return &GzipResponseWriter{ResponseWriter: w, accepted: AcceptsGzip(r)}
+}
+
+// WriteHeader starts compression for statuses that carry a body
+func (g *GzipResponseWriter) WriteHeader(status int) {
+	if g.wroteHeader {
+		return
+	}
+	g.wroteHeader = true
+
+	if g.accepted && bodyAllowed(status) {
+		g.Header().Set("Content-Encoding", "gzip")
+		// The compressed length is unknown upfront
+		g.Header().Del("Content-Length")
+		g.gz = gzip.NewWriter(g.ResponseWriter)
+	}
+	g.ResponseWriter.WriteHeader(status)
+}
+
+// Write compresses data when gzip was negotiated
+func (g *GzipResponseWriter) Write(data []byte) (int, error) {
+	if !g.wroteHeader {
+		g.WriteHeader(http.StatusOK)
+	}
+	if g.gz != nil {
+		return g.gz.Write(data)
+	}
+	return g.ResponseWriter.Write(data)
+}
+
+// Close flushes the compressed stream; it is a no-op for uncompressed responses
+func (g *GzipResponseWriter) Close() error {
+	if g.gz == nil {
+		return nil
+	}
+	return g.gz.Close()
+}
+
+// bodyAllowed reports whether a response with the status may have a body
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// AcceptsGzip reports whether the request's Accept-Encoding header allows gzip
+func AcceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+
+		// q=0 means "not acceptable"
+		if key, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && key == "q" {
+			if q, err := strconv.ParseFloat(value, 64); err == nil && q == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package apikit
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteJSONGzip(t *testing.T) {
+	data := map[string]any{"items": []string{"a", "b", "c"}}
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		compressed     bool
+	}{
+		{name: "gzip", acceptEncoding: "gzip", compressed: true},
+		{name: "gzip among others", acceptEncoding: "deflate, gzip;q=0.8, br", compressed: true},
+		{name: "wildcard", acceptEncoding: "*", compressed: true},
+		{name: "gzip disabled", acceptEncoding: "gzip;q=0", compressed: false},
+		{name: "no accept encoding", acceptEncoding: "", compressed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/items", nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+
+			WriteJSONGzip(w, r, data)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("expected status 200, got %d", w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected Content-Type 'application/json', got %q", ct)
+			}
+			if cl := w.Header().Get("Content-Length"); cl != "" {
+				t.Errorf("expected no Content-Length, got %q", cl)
+			}
+
+			var reader io.Reader = w.Body
+			if tt.compressed {
+				if ce := w.Header().Get("Content-Encoding"); ce != "gzip" {
+					t.Fatalf("expected Content-Encoding 'gzip', got %q", ce)
+				}
+				gz, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("failed to create gzip reader: %v", err)
+				}
+				defer gz.Close()
+				reader = gz
+			} else if ce := w.Header().Get("Content-Encoding"); ce != "" {
+				t.Fatalf("expected no Content-Encoding, got %q", ce)
+			}
+
+			body, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+
+			expected, _ := json.Marshal(data)
+			var actual any
+			if err := json.Unmarshal(body, &actual); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			actualJSON, _ := json.Marshal(actual)
+			if string(actualJSON) != string(expected) {
+				t.Errorf("expected body %s, got %s", expected, actualJSON)
+			}
+		})
+	}
+}
+
+func TestGzipResponseWriter_HttpResponse(t *testing.T) {
+	response := NewHttpResponse(http.StatusCreated, map[string]string{"id": "42"}).
+		WithHeader("Location", "/items/42")
+
+	for _, acceptEncoding := range []string{"gzip", ""} {
+		t.Run("accept "+acceptEncoding, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/items", nil)
+			if acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+
+			gw := NewGzipResponseWriter(w, r)
+			HandleResponse(gw, response, nil)
+			if err := gw.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("expected status 201, got %d", w.Code)
+			}
+			if location := w.Header().Get("Location"); location != "/items/42" {
+				t.Errorf("expected Location header, got %q", location)
+			}
+			if vary := w.Header().Get("Vary"); vary != "Accept-Encoding" {
+				t.Errorf("expected Vary: Accept-Encoding, got %q", vary)
+			}
+
+			var reader io.Reader = w.Body
+			if acceptEncoding != "" {
+				if ce := w.Header().Get("Content-Encoding"); ce != "gzip" {
+					t.Fatalf("expected Content-Encoding 'gzip', got %q", ce)
+				}
+				gz, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("failed to create gzip reader: %v", err)
+				}
+				defer gz.Close()
+				reader = gz
+			} else if ce := w.Header().Get("Content-Encoding"); ce != "" {
+				t.Fatalf("expected no Content-Encoding, got %q", ce)
+			}
+
+			var body map[string]string
+			if err := json.NewDecoder(reader).Decode(&body); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			if body["id"] != "42" {
+				t.Errorf("expected the HttpResponse body, got %v", body)
+			}
+		})
+	}
+}
+
+func TestGzipResponseWriter_NoContent(t *testing.T) {
+	r := httptest.NewRequest(http.MethodDelete, "/items/42", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+
+	gw := NewGzipResponseWriter(w, r)
+	gw.WriteHeader(http.StatusNoContent)
+	gw.Close()
+
+	if ce := w.Header().Get("Content-Encoding"); ce != "" {
+		t.Errorf("expected no Content-Encoding for 204, got %q", ce)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %q", w.Body.String())
+	}
+}
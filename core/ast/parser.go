@@ -264,6 +264,15 @@ func (p *Parser) typeToString(expr ast.Expr) string {
 		return "map[" + p.typeToString(e.Key) + "]" + p.typeToString(e.Value)
 	case *ast.InterfaceType:
 		return "any"
+	case *ast.ChanType:
+		switch e.Dir {
+		case ast.RECV:
+			return "<-chan " + p.typeToString(e.Value)
+		case ast.SEND:
+			return "chan<- " + p.typeToString(e.Value)
+		default:
+			return "chan " + p.typeToString(e.Value)
+		}
 	case *ast.Ellipsis:
 		return "..." + p.typeToString(e.Elt)
 	default:
@@ -0,0 +1,80 @@
+package apikit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// DecodeJSONError translates an error from decoding a JSON request body into a
+// 400 error whose message names the offending field and the expected type
+// Type mismatches carry {"field", "expected", "actual"} details
+// Examples:
+//   - {"age": "ten"} into an int field -> `field "age" must be an integer, got string`
+//   - {"name": } -> "malformed JSON at offset 10: invalid character '}' looking for beginning of value"
+func DecodeJSONError(err error) *Error {
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	switch {
+	case errors.As(err, &typeErr):
+		expected := jsonTypeName(typeErr.Type)
+		if typeErr.Field == "" {
+			return BadRequest(fmt.Sprintf("request body must be %s %s, got %s", article(expected), expected, typeErr.Value)).
+				WithCause(err)
+		}
+		return BadRequest(fmt.Sprintf("field %q must be %s %s, got %s", typeErr.Field, article(expected), expected, typeErr.Value)).
+			WithDetails(map[string]string{"field": typeErr.Field, "expected": expected, "actual": typeErr.Value}).
+			WithCause(err)
+	case errors.As(err, &syntaxErr):
+		return BadRequest(fmt.Sprintf("malformed JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error())).WithCause(err)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return BadRequest("malformed JSON: unexpected end of body").WithCause(err)
+	}
+
+	// Returned by decoders with DisallowUnknownFields
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return BadRequest("unknown field " + field).WithCause(err)
+	}
+
+	return BadRequest("invalid JSON body").WithCause(err)
+}
+
+// jsonTypeName describes a Go type with its JSON type name
+// Example: int64 -> "integer", []string -> "array", map[string]any -> "object"
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	}
+	return t.String()
+}
+
+// article returns the indefinite article for a type name ("an integer", "a string")
+func article(name string) string {
+	if name != "" && strings.ContainsRune("aeiou", rune(name[0])) {
+		return "an"
+	}
+	return "a"
+}
@@ -0,0 +1,97 @@
+package apikit
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type decodePet struct {
+	Name string   `json:"name"`
+	Age  int      `json:"age"`
+	Tags []string `json:"tags"`
+}
+
+func TestDecodeJSONError(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantMessage string
+	}{
+		{
+			name:        "type mismatch",
+			body:        `{"name":"Rex","age":"three"}`,
+			wantMessage: `field "age" must be an integer, got string`,
+		},
+		{
+			name:        "array expected",
+			body:        `{"tags":"cute"}`,
+			wantMessage: `field "tags" must be an array, got string`,
+		},
+		{
+			name:        "wrong top-level type",
+			body:        `["Rex"]`,
+			wantMessage: "request body must be an object, got array",
+		},
+		{
+			name:        "syntax error",
+			body:        `{"name": }`,
+			wantMessage: "malformed JSON at offset 10: invalid character '}' looking for beginning of value",
+		},
+		{
+			name:        "truncated body",
+			body:        `{"name":"Rex"`,
+			wantMessage: "malformed JSON at offset 13: unexpected end of JSON input",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pet decodePet
+			err := json.Unmarshal([]byte(tt.body), &pet)
+			if err == nil {
+				t.Fatal("expected decode error")
+			}
+
+			apiErr := DecodeJSONError(err)
+			if apiErr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", apiErr.Code)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("expected message %q, got %q", tt.wantMessage, apiErr.Message)
+			}
+			if !errors.Is(apiErr, err) {
+				t.Error("expected the decode error to be preserved as the cause")
+			}
+		})
+	}
+}
+
+func TestDecodeJSONError_TypeMismatchDetails(t *testing.T) {
+	var pet decodePet
+	err := json.Unmarshal([]byte(`{"age":"three"}`), &pet)
+
+	details, ok := DecodeJSONError(err).Details.(map[string]string)
+	if !ok {
+		t.Fatalf("expected map details, got %T", DecodeJSONError(err).Details)
+	}
+	want := map[string]string{"field": "age", "expected": "integer", "actual": "string"}
+	for k, v := range want {
+		if details[k] != v {
+			t.Errorf("expected details[%q] = %q, got %q", k, v, details[k])
+		}
+	}
+}
+
+func TestDecodeJSONError_UnknownField(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"nmae":"Rex"}`))
+	dec.DisallowUnknownFields()
+	var pet decodePet
+	err := dec.Decode(&pet)
+
+	if got := DecodeJSONError(err).Message; got != `unknown field "nmae"` {
+		t.Errorf("expected unknown field message, got %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package apikit
 
 import (
 	"fmt"
+	"net/http"
 )
 
 // Error represents an API error with an HTTP status code
@@ -23,6 +24,9 @@ type Error struct {
 
 	// Original error (not serialized)
 	cause error `json:"-"`
+
+	// Seconds sent in the Retry-After header (not serialized)
+	retryAfter int `json:"-"`
 }
 
 // Error implements the error interface
@@ -61,36 +65,55 @@ func (e *Error) WithCause(cause error) *Error {
 	return e
 }
 
+// WithErrorCode sets the semantic error code for client handling
+// Example: NotFound("user").WithErrorCode("USER_NOT_FOUND")
+func (e *Error) WithErrorCode(code string) *Error {
+	e.ErrorCode = code
+	return e
+}
+
+// WithRetryAfter sets the Retry-After header, in seconds, sent with the error response
+// Typically used with TooManyRequests and ServiceUnavailable
+func (e *Error) WithRetryAfter(seconds int) *Error {
+	e.retryAfter = seconds
+	return e
+}
+
 // NewError creates a new API error with the given status code and message
+// ErrorCode defaults to the status text (e.g., "Not Found"); use WithErrorCode to override it
 func NewError(code int, message string) *Error {
 	return &Error{
-		Code:    code,
-		Message: message,
+		Code:      code,
+		ErrorCode: http.StatusText(code),
+		Message:   message,
 	}
 }
 
 // NewErrorf creates a new API error with a formatted message
 func NewErrorf(code int, format string, args ...any) *Error {
 	return &Error{
-		Code:    code,
-		Message: fmt.Sprintf(format, args...),
+		Code:      code,
+		ErrorCode: http.StatusText(code),
+		Message:   fmt.Sprintf(format, args...),
 	}
 }
 
 // NewErrorWithDetails creates a new API error with additional details
 func NewErrorWithDetails(code int, message string, details any) *Error {
 	return &Error{
-		Code:    code,
-		Message: message,
-		Details: details,
+		Code:      code,
+		ErrorCode: http.StatusText(code),
+		Message:   message,
+		Details:   details,
 	}
 }
 
 // WrapError wraps an existing error with an API error
 func WrapError(code int, message string, cause error) *Error {
 	return &Error{
-		Code:    code,
-		Message: message,
-		cause:   cause,
+		Code:      code,
+		ErrorCode: http.StatusText(code),
+		Message:   message,
+		cause:     cause,
 	}
 }
@@ -33,6 +33,23 @@ func TestNewError(t *testing.T) {
 	}
 }
 
+func TestNewError_DefaultErrorCode(t *testing.T) {
+	err := NewError(404, "user not found")
+	if err.ErrorCode != "Not Found" {
+		t.Errorf("expected error code 'Not Found', got %q", err.ErrorCode)
+	}
+
+	// Codes without a status text leave ErrorCode empty
+	if err := NewError(599, "custom"); err.ErrorCode != "" {
+		t.Errorf("expected empty error code for an unknown status, got %q", err.ErrorCode)
+	}
+
+	wrapped := WrapError(503, "database unavailable", errors.New("dial tcp: timeout"))
+	if wrapped.ErrorCode != "Service Unavailable" {
+		t.Errorf("expected error code 'Service Unavailable', got %q", wrapped.ErrorCode)
+	}
+}
+
 func TestNewErrorf(t *testing.T) {
 	err := NewErrorf(400, "invalid value: %d", 42)
 	expected := "invalid value: 42"
@@ -167,6 +184,32 @@ func TestError_WithCause(t *testing.T) {
 	}
 }
 
+func TestError_WithErrorCode(t *testing.T) {
+	err := NewError(404, "user not found")
+
+	result := err.WithErrorCode("USER_NOT_FOUND")
+
+	if result.ErrorCode != "USER_NOT_FOUND" {
+		t.Errorf("expected error code 'USER_NOT_FOUND', got %q", result.ErrorCode)
+	}
+	if result != err {
+		t.Error("expected WithErrorCode to return same error instance")
+	}
+}
+
+func TestError_WithRetryAfter(t *testing.T) {
+	err := TooManyRequests("slow down")
+
+	result := err.WithRetryAfter(30)
+
+	if result.retryAfter != 30 {
+		t.Errorf("expected retry after 30, got %d", result.retryAfter)
+	}
+	if result != err {
+		t.Error("expected WithRetryAfter to return same error instance")
+	}
+}
+
 func TestError_Chaining(t *testing.T) {
 	// Test method chaining
 	details := map[string]string{"key": "value"}
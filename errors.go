@@ -63,6 +63,24 @@ func NotAcceptable(message string) *Error {
 	}
 }
 
+// RequestEntityTooLarge creates a 413 error
+func RequestEntityTooLarge(message string) *Error {
+	return &Error{
+		Code:      http.StatusRequestEntityTooLarge,
+		ErrorCode: http.StatusText(http.StatusRequestEntityTooLarge),
+		Message:   message,
+	}
+}
+
+// UnsupportedMediaType creates a 415 error
+func UnsupportedMediaType(message string) *Error {
+	return &Error{
+		Code:      http.StatusUnsupportedMediaType,
+		ErrorCode: http.StatusText(http.StatusUnsupportedMediaType),
+		Message:   message,
+	}
+}
+
 // UnprocessableEntity creates a 422 error
 func UnprocessableEntity(message string) *Error {
 	return &Error{
@@ -72,6 +90,16 @@ func UnprocessableEntity(message string) *Error {
 	}
 }
 
+// TooManyRequests creates a 429 error
+// Use WithRetryAfter to tell clients when to retry
+func TooManyRequests(message string) *Error {
+	return &Error{
+		Code:      http.StatusTooManyRequests,
+		ErrorCode: http.StatusText(http.StatusTooManyRequests),
+		Message:   message,
+	}
+}
+
 // ============================================================================
 // 5xx Server Errors
 // ============================================================================
@@ -75,6 +75,17 @@ func TestNotAcceptable(t *testing.T) {
 	}
 }
 
+func TestRequestEntityTooLarge(t *testing.T) {
+	err := RequestEntityTooLarge("request body too large")
+
+	if err.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected code %d, got %d", http.StatusRequestEntityTooLarge, err.Code)
+	}
+	if err.Message != "request body too large" {
+		t.Errorf("expected message 'request body too large', got %q", err.Message)
+	}
+}
+
 func TestUnprocessableEntity(t *testing.T) {
 	err := UnprocessableEntity("validation failed")
 
@@ -108,6 +119,34 @@ func TestNotImplemented(t *testing.T) {
 	}
 }
 
+func TestUnsupportedMediaType(t *testing.T) {
+	err := UnsupportedMediaType("unsupported upload type")
+
+	if err.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected code %d, got %d", http.StatusUnsupportedMediaType, err.Code)
+	}
+	if err.Message != "unsupported upload type" {
+		t.Errorf("expected message 'unsupported upload type', got %q", err.Message)
+	}
+	if err.ErrorCode != http.StatusText(http.StatusUnsupportedMediaType) {
+		t.Errorf("expected error code %q, got %q", http.StatusText(http.StatusUnsupportedMediaType), err.ErrorCode)
+	}
+}
+
+func TestTooManyRequests(t *testing.T) {
+	err := TooManyRequests("rate limit exceeded")
+
+	if err.Code != http.StatusTooManyRequests {
+		t.Errorf("expected code %d, got %d", http.StatusTooManyRequests, err.Code)
+	}
+	if err.Message != "rate limit exceeded" {
+		t.Errorf("expected message 'rate limit exceeded', got %q", err.Message)
+	}
+	if err.ErrorCode != http.StatusText(http.StatusTooManyRequests) {
+		t.Errorf("expected error code %q, got %q", http.StatusText(http.StatusTooManyRequests), err.ErrorCode)
+	}
+}
+
 func TestServiceUnavailable(t *testing.T) {
 	err := ServiceUnavailable("service temporarily down")
 
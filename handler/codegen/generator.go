@@ -6,22 +6,33 @@ import (
 	_ "embed"
 	"fmt"
 	"go/format"
+	"path/filepath"
 	"reflect"
 	"slices"
 	"strings"
 	"text/template"
+	"time"
+	"unicode"
 
 	"github.com/reation-io/apikit/handler/extractors"
 	"github.com/reation-io/apikit/handler/parser"
+	"github.com/reation-io/apikit/handler/types"
 	"golang.org/x/tools/imports"
 )
 
 //go:embed templates/handler.tmpl
 var handlerTemplate string
 
+// defaultMaxMemory is the multipart memory budget without an apikit:maxmemory
+// directive, matching the one net/http's FormFile uses
+const defaultMaxMemory = 32 << 20
+
 // Generator generates wrapper code for handlers using the extractor system
 type Generator struct {
 	tmpl *template.Template
+
+	// warnings are the problems found by the last Generate call
+	warnings []string
 }
 
 // New creates a new code generator
@@ -41,12 +52,34 @@ type TemplateData struct {
 	PackageName string
 	Imports     []string
 	Handlers    []HandlerData
+
+	// RegisterFuncName names the generated route registration function and methods
+	// It is derived from the source file so that files of one package don't collide
+	RegisterFuncName string
+
+	// Routes are the handlers with an apikit:route directive,
+	// registered by the generated RegisterFuncName function
+	Routes []HandlerData
+
+	// ReceiverRoutes are the routed handler methods grouped by receiver type,
+	// registered by a generated RegisterFuncName method on each type
+	ReceiverRoutes []ReceiverRoutes
+}
+
+// ReceiverRoutes holds the routed handler methods of a receiver type
+type ReceiverRoutes struct {
+	Receiver     string
+	ReceiverName string
+	Routes       []HandlerData
 }
 
 // HandlerData holds data for a single handler
 type HandlerData struct {
 	Name              string
 	WrapperName       string
+	Receiver          string
+	ReceiverName      string
+	MethodName        string
 	ParseFuncName     string
 	ParamType         string
 	ReturnType        string
@@ -54,15 +87,52 @@ type HandlerData struct {
 	ExtractionCode    string
 	HasBody           bool
 	BodyFieldName     string
+	Consumes          string
+	ConsumesForm      bool
+	FormCode          string
 	HasRawBody        bool
 	RawBodyFieldName  string
 	HasValidation     bool
 	HasResponseWriter bool
 	HasRequest        bool
+	Compress          bool
+	Log               bool
+	SSE               bool
+	ErrorHandler      string
+	Middlewares       []string
+	Method            string
+	Path              string
+	MaxBodySize       int64
+	MaxMemory         int64
+	Timeout           string
+	OutHeaderCode     string
+	StrictBody        bool
+	NilNoContent      bool
+}
+
+// ConsumesXML reports whether the request body is decoded as XML
+// Example: "application/xml", "text/xml", "application/atom+xml"
+func (hd HandlerData) ConsumesXML() bool {
+	mediaType, _, _ := strings.Cut(hd.Consumes, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return strings.HasSuffix(mediaType, "/xml") || strings.HasSuffix(mediaType, "+xml")
+}
+
+// isFormMediaType reports whether the media type is a URL-encoded form
+func isFormMediaType(mediaType string) bool {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	return strings.EqualFold(strings.TrimSpace(mediaType), "application/x-www-form-urlencoded")
+}
+
+// Pattern returns the Go 1.22 ServeMux pattern for the handler (e.g., "GET /users/{id}")
+func (hd HandlerData) Pattern() string {
+	return hd.Method + " " + hd.Path
 }
 
 // Generate creates wrapper code for the given handlers
+// Problems that don't prevent generation are reported by Warnings
 func (g *Generator) Generate(result *parser.ParseResult) ([]byte, error) {
+	g.warnings = nil
 	if len(result.Handlers) == 0 {
 		return nil, fmt.Errorf("no handlers found")
 	}
@@ -91,11 +161,18 @@ func (g *Generator) Generate(result *parser.ParseResult) ([]byte, error) {
 	return formatted, nil
 }
 
+// Warnings returns the problems found by the last Generate call that didn't prevent
+// generation, such as fields that are not extracted
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
+
 func (g *Generator) prepareTemplateData(result *parser.ParseResult) *TemplateData {
 	data := &TemplateData{
-		PackageName: result.Source.Package,
-		Imports:     []string{},
-		Handlers:    []HandlerData{},
+		PackageName:      result.Source.Package,
+		Imports:          []string{},
+		Handlers:         []HandlerData{},
+		RegisterFuncName: registerFuncName(result.Source.Filename),
 	}
 
 	importsMap := make(map[string]bool)
@@ -106,6 +183,13 @@ func (g *Generator) prepareTemplateData(result *parser.ParseResult) *TemplateDat
 	for _, handler := range result.Handlers {
 		hd := g.prepareHandlerData(&handler, importsMap)
 		data.Handlers = append(data.Handlers, hd)
+
+		// Methods need a receiver instance, so they are registered by a method on their type
+		if hd.Path != "" && hd.Receiver == "" {
+			data.Routes = append(data.Routes, hd)
+		} else if hd.Path != "" {
+			data.addReceiverRoute(hd)
+		}
 	}
 
 	// Convert imports map to slice and sort alphabetically for deterministic output
@@ -117,6 +201,30 @@ func (g *Generator) prepareTemplateData(result *parser.ParseResult) *TemplateDat
 	return data
 }
 
+// addReceiverRoute adds a routed handler method to the group of its receiver type
+// The group uses a pointer receiver as soon as one of its methods does
+func (data *TemplateData) addReceiverRoute(hd HandlerData) {
+	baseType := strings.TrimPrefix(hd.Receiver, "*")
+	for i := range data.ReceiverRoutes {
+		group := &data.ReceiverRoutes[i]
+		if strings.TrimPrefix(group.Receiver, "*") == baseType {
+			if strings.HasPrefix(hd.Receiver, "*") {
+				group.Receiver = hd.Receiver
+			}
+			group.Routes = append(group.Routes, hd)
+			return
+		}
+	}
+
+	data.ReceiverRoutes = append(data.ReceiverRoutes, ReceiverRoutes{
+		Receiver:     hd.Receiver,
+		ReceiverName: hd.ReceiverName,
+		Routes:       []HandlerData{hd},
+	})
+}
+
+// prepareHandlerData builds the template data for a handler
+// Problems that don't prevent generation are added to the generator's warnings
 func (g *Generator) prepareHandlerData(handler *parser.Handler, importsMap map[string]bool) HandlerData {
 	hd := HandlerData{
 		Name:              handler.Name,
@@ -126,14 +234,85 @@ func (g *Generator) prepareHandlerData(handler *parser.Handler, importsMap map[s
 		ReturnType:        handler.ReturnType,
 		HasResponseWriter: handler.HasResponseWriter,
 		HasRequest:        handler.HasRequest,
+		Method:            handler.Method,
+		Path:              handler.Path,
+	}
+
+	// Handler methods are served by a generated method on the receiver type, which
+	// calls a wrapper named after the type so methods of different types don't collide
+	// Example: func (s *Service) CreateUser -> (s *Service).createUserAPIKit and serviceCreateUserAPIKit
+	if handler.Receiver != "" {
+		baseType := strings.TrimPrefix(handler.Receiver, "*")
+		hd.Receiver = handler.Receiver
+		hd.ReceiverName = receiverName(baseType)
+		hd.MethodName = hd.WrapperName
+		hd.WrapperName = toCamelCasePrivate(baseType+handler.Name) + "APIKit"
+		hd.ParseFuncName = "parse" + capitalize(baseType+handler.Name) + "Request"
+	}
+
+	// Opt-in gzip compression via "// apikit:compress"
+	_, hd.Compress = handler.Directives["compress"]
+
+	// Request and error logging via "// apikit:log"
+	_, hd.Log = handler.Directives["log"]
+
+	// Server-Sent Events via "// apikit:sse" or an apikit.SSEStream return type
+	_, hd.SSE = handler.Directives["sse"]
+	hd.SSE = hd.SSE || handler.ReturnType == "apikit.SSEStream"
+
+	// Pointer responses answer 204 No Content when the handler returns nil without an error
+	// Example: func DeletePet(ctx, req) (*Pet, error) returning nil, nil
+	hd.NilNoContent = strings.HasPrefix(handler.ReturnType, "*") &&
+		handler.ReturnType != "*apikit.HttpResponse" && !hd.SSE
+
+	// Handler deadline via "// apikit:timeout 5s", answered with 504 when exceeded
+	if handler.Timeout > 0 {
+		hd.Timeout = durationLiteral(handler.Timeout)
+		importsMap["errors"] = true
+		importsMap["time"] = true
+	}
+
+	// Custom error mapper via "// apikit:errorhandler MyMapper"
+	// The mapper has the signature func(context.Context, error) error
+	hd.ErrorHandler = handler.Directives["errorhandler"]
+
+	// Middleware chain via "// apikit:middleware Auth,Logging" (outermost first)
+	if middlewares, ok := handler.Directives["middleware"]; ok {
+		for _, name := range strings.Split(middlewares, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				hd.Middlewares = append(hd.Middlewares, name)
+			}
+		}
+	}
+
+	// Response fields annotated with "// out:header Location" are written as headers
+	if handler.ResponseStruct != nil && !hd.SSE {
+		hd.OutHeaderCode = g.generateOutHeaderCode(handler.ResponseStruct, handler.ReturnType)
 	}
 
 	if handler.Struct == nil {
 		return hd
 	}
 
+	// Multipart uploads keep up to 32MB in memory unless "// apikit:maxmemory 10MB"
+	// sets another budget
+	if g.hasFileFields(handler.Struct) {
+		hd.MaxMemory = defaultMaxMemory
+		if handler.MaxMemory > 0 {
+			hd.MaxMemory = handler.MaxMemory
+		}
+		importsMap["errors"] = true
+	} else if handler.MaxMemory > 0 {
+		warning := fmt.Sprintf("%s: handler %s: apikit:maxmemory has no effect, request struct %s has no file fields",
+			handler.Pos, handler.Name, handler.Struct.Name)
+		g.warnings = append(g.warnings, warning)
+	}
+
 	// Use extractors to generate code for each field
-	extractionCode := g.generateExtractionCode(handler.Struct, importsMap)
+	extractionCode := g.generateExtractionCode(handler.Struct, importsMap, func(problem string) {
+		warning := fmt.Sprintf("%s: handler %s: %s; the field is not extracted", handler.Pos, handler.Name, problem)
+		g.warnings = append(g.warnings, warning)
+	})
 
 	hd.HasExtractionCode = extractionCode != ""
 	hd.ExtractionCode = extractionCode
@@ -141,9 +320,58 @@ func (g *Generator) prepareHandlerData(handler *parser.Handler, importsMap map[s
 	// Check if we need body parsing and find the body field name
 	hd.HasBody = g.hasBodyFields(handler.Struct)
 	if hd.HasBody {
-		bodyField := g.findBodyField(handler.Struct)
-		if bodyField != "" {
-			hd.BodyFieldName = bodyField
+		bodyFields := g.findBodyFields(handler.Struct)
+		if len(bodyFields) > 0 {
+			hd.BodyFieldName = bodyFields[0]
+		}
+		if len(bodyFields) > 1 {
+			warning := fmt.Sprintf("%s: handler %s: request struct %s has multiple body fields (%s); only %s is decoded",
+				handler.Pos, handler.Name, handler.Struct.Name, strings.Join(bodyFields, ", "), bodyFields[0])
+			g.warnings = append(g.warnings, warning)
+		}
+
+		// Body media types: "// in:body xml" on the field, then
+		// "// apikit:consumes" on the request struct or the handler
+		mediaTypes := handler.Struct.Consumes
+		if consumes := g.findBodyConsumes(handler.Struct); consumes != "" {
+			mediaTypes = []string{consumes}
+		}
+		if len(mediaTypes) == 0 {
+			mediaTypes = handler.Consumes
+		}
+		for _, mediaType := range mediaTypes {
+			if isFormMediaType(mediaType) {
+				hd.ConsumesForm = true
+			} else if hd.Consumes == "" {
+				hd.Consumes = mediaType
+			}
+		}
+		if hd.ConsumesXML() {
+			importsMap["bytes"] = true
+			importsMap["encoding/xml"] = true
+		}
+
+		// Form bodies are decoded field by field, keyed by form or json tag
+		if hd.ConsumesForm {
+			importsMap["bytes"] = true
+			importsMap["mime"] = true
+			if body := g.findBodyStruct(handler.Struct); body != nil {
+				hd.FormCode = g.generateFormCode(body, hd.BodyFieldName+".", importsMap)
+			}
+		}
+
+		// Reject unknown JSON keys via "// apikit:strictbody"
+		_, hd.StrictBody = handler.Directives["strictbody"]
+		if hd.StrictBody && !hd.ConsumesXML() {
+			importsMap["bytes"] = true
+		}
+	}
+
+	// Body size limit via "// apikit:maxbody 1MB", answered with 413 when exceeded
+	if hd.HasBody || g.findRawBodyField(handler.Struct) != "" {
+		hd.MaxBodySize = handler.MaxBodySize
+		if hd.MaxBodySize > 0 {
+			importsMap["errors"] = true
 		}
 	}
 
@@ -164,7 +392,9 @@ func (g *Generator) prepareHandlerData(handler *parser.Handler, importsMap map[s
 	return hd
 }
 
-func (g *Generator) generateExtractionCode(s *parser.Struct, importsMap map[string]bool) string {
+// generateExtractionCode generates the extraction code of each field of s
+// Fields rejected by their extractor are skipped and reported to warn
+func (g *Generator) generateExtractionCode(s *parser.Struct, importsMap map[string]bool, warn func(string)) string {
 	var lines []string
 
 	// Get all registered extractors (already sorted by priority)
@@ -175,7 +405,7 @@ func (g *Generator) generateExtractionCode(s *parser.Struct, importsMap map[stri
 		// Handle embedded structs - expand their fields
 		if field.IsEmbedded {
 			if field.NestedStruct != nil {
-				nestedCode := g.generateExtractionCode(field.NestedStruct, importsMap)
+				nestedCode := g.generateExtractionCode(field.NestedStruct, importsMap, warn)
 				if nestedCode != "" {
 					lines = append(lines, nestedCode)
 				}
@@ -191,6 +421,13 @@ func (g *Generator) generateExtractionCode(s *parser.Struct, importsMap map[stri
 		// Find the appropriate extractor for this field
 		for _, ext := range allExtractors {
 			if ext.CanExtract(&field) {
+				if validator, ok := ext.(extractors.FieldValidator); ok {
+					if err := validator.ValidateField(&field); err != nil {
+						warn(err.Error())
+						break
+					}
+				}
+
 				code, imports := ext.GenerateCode(&field, s.Name)
 				if code != "" {
 					// Add imports
@@ -200,6 +437,14 @@ func (g *Generator) generateExtractionCode(s *parser.Struct, importsMap map[stri
 
 					// Add code (extractors are already sorted by priority)
 					lines = append(lines, code)
+
+					// Reject values outside the "// enum:" annotation
+					if enumCheck, imports := extractors.GenerateEnumCheck(&field, field.Name); enumCheck != "" {
+						for _, imp := range imports {
+							importsMap[imp] = true
+						}
+						lines = append(lines, enumCheck)
+					}
 				}
 				break // Only use the first matching extractor
 			}
@@ -209,6 +454,19 @@ func (g *Generator) generateExtractionCode(s *parser.Struct, importsMap map[stri
 	return strings.Join(lines, "\n\t")
 }
 
+// hasFileFields checks if the struct or its embedded structs have multipart file fields
+func (g *Generator) hasFileFields(s *parser.Struct) bool {
+	for _, field := range s.Fields {
+		if field.IsFile {
+			return true
+		}
+		if field.IsEmbedded && field.NestedStruct != nil && g.hasFileFields(field.NestedStruct) {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *Generator) hasBodyFields(s *parser.Struct) bool {
 	for _, field := range s.Fields {
 		// Check embedded structs recursively
@@ -238,30 +496,198 @@ func (g *Generator) hasBodyFields(s *parser.Struct) bool {
 // findBodyField searches for a body field in the struct
 // Returns the field name if found, empty string otherwise
 func (g *Generator) findBodyField(s *parser.Struct) string {
+	if fields := g.findBodyFields(s); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// findBodyFields returns the names of all body fields in the struct, in declaration order
+// A field is a body field if it has an "in: body" comment or a json:"body" tag
+func (g *Generator) findBodyFields(s *parser.Struct) []string {
+	var fields []string
 	for _, field := range s.Fields {
 		// Check embedded structs recursively
 		if field.IsEmbedded && field.NestedStruct != nil {
-			if bodyField := g.findBodyField(field.NestedStruct); bodyField != "" {
-				return bodyField
-			}
+			fields = append(fields, g.findBodyFields(field.NestedStruct)...)
 		}
 
 		// Check if this is a body field
 		if field.IsBody {
-			return field.Name
+			fields = append(fields, field.Name)
+			continue
 		}
 
 		// Check if field has json:"body" tag
 		if field.StructTag != "" {
 			tag := reflect.StructTag(field.StructTag)
 			if jsonTag, ok := tag.Lookup("json"); ok && jsonTag == "body" {
-				return field.Name
+				fields = append(fields, field.Name)
+			}
+		}
+	}
+	return fields
+}
+
+// findBodyConsumes returns the media type declared on the body field ("// in:body xml")
+// Returns empty string if the body field doesn't declare one
+func (g *Generator) findBodyConsumes(s *parser.Struct) string {
+	for _, field := range s.Fields {
+		// Check embedded structs recursively
+		if field.IsEmbedded && field.NestedStruct != nil {
+			if consumes := g.findBodyConsumes(field.NestedStruct); consumes != "" {
+				return consumes
 			}
 		}
+
+		if field.IsBody && strings.EqualFold(field.InCommentName, "xml") {
+			return "application/xml"
+		}
 	}
 	return ""
 }
 
+// findBodyStruct returns the struct definition of the body field
+// Returns nil if the body type couldn't be resolved
+func (g *Generator) findBodyStruct(s *parser.Struct) *parser.Struct {
+	bodyField := g.findBodyField(s)
+	for _, field := range s.Fields {
+		if field.IsEmbedded && field.NestedStruct != nil {
+			if body := g.findBodyStruct(field.NestedStruct); body != nil {
+				return body
+			}
+		}
+
+		if field.Name == bodyField && !field.IsSlice {
+			return field.NestedStruct
+		}
+	}
+	return nil
+}
+
+// generateOutHeaderCode generates code copying "// out:header" fields of the response
+// into response headers and declaring a body value that leaves them out of the JSON
+// The fields are hidden by shadowing their JSON names with always-empty fields
+// Returns empty string when the response struct has no out:header fields
+// Example: Location string // out:header Location -> w.Header().Set("Location", response.Location)
+func (g *Generator) generateOutHeaderCode(s *parser.Struct, returnType string) string {
+	var headers, shadows []string
+	for _, field := range s.Fields {
+		if field.OutHeader == "" || field.IsEmbedded {
+			continue
+		}
+
+		value := "response." + field.Name
+		switch {
+		case field.IsPointer:
+			headers = append(headers, fmt.Sprintf("if %s != nil {\n\t\tw.Header().Set(%q, fmt.Sprint(*%s))\n\t}", value, field.OutHeader, value))
+		case field.Type == "string":
+			headers = append(headers, fmt.Sprintf("if %s != \"\" {\n\t\tw.Header().Set(%q, %s)\n\t}", value, field.OutHeader, value))
+		default:
+			headers = append(headers, fmt.Sprintf("w.Header().Set(%q, fmt.Sprint(%s))", field.OutHeader, value))
+		}
+
+		if name := jsonFieldName(field); name != "" {
+			shadows = append(shadows, fmt.Sprintf("%s *struct{} `json:\"%s,omitempty\"`", field.Name, name))
+		}
+	}
+	if len(headers) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	if strings.HasPrefix(returnType, "*") {
+		b.WriteString("if response == nil {\n\tapikit.HandleResponse(w, response, nil)\n\treturn\n}\n")
+	}
+	b.WriteString(strings.Join(headers, "\n"))
+	embedded := strings.TrimPrefix(returnType[strings.LastIndex(returnType, ".")+1:], "*")
+	fmt.Fprintf(&b, "\nbody := struct {\n\t%s\n\t%s\n}{%s: response}", returnType, strings.Join(shadows, "\n\t"), embedded)
+	return b.String()
+}
+
+// jsonFieldName returns the JSON key of a field
+// Returns empty string for fields excluded with "-"
+func jsonFieldName(field parser.Field) string {
+	value, _ := reflect.StructTag(field.StructTag).Lookup("json")
+	name, _, _ := strings.Cut(value, ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// generateFormCode generates code assigning form values from r.PostForm to the body fields
+// Fields are looked up by their form tag, then json tag, then field name
+// Only scalar fields, slices of scalars and registered types are supported;
+// other fields are left to their zero value
+// Example: Text string `json:"text"` -> payload.Body.Text = r.PostForm.Get("text")
+func (g *Generator) generateFormCode(s *parser.Struct, prefix string, importsMap map[string]bool) string {
+	var lines []string
+	for _, field := range s.Fields {
+		if field.IsEmbedded {
+			if field.NestedStruct != nil {
+				if code := g.generateFormCode(field.NestedStruct, prefix, importsMap); code != "" {
+					lines = append(lines, code)
+				}
+			}
+			continue
+		}
+
+		name := formFieldName(field)
+		typeName := extractors.GetBaseType(&field)
+		if name == "" || field.IsPointer || !isFormType(typeName) {
+			continue
+		}
+
+		var code string
+		var imports []string
+		if field.IsSlice {
+			code, imports = extractors.GenerateSliceCodeByType(fmt.Sprintf("r.PostForm[%q]", name), prefix+field.Name, typeName, &field)
+		} else {
+			code, imports = extractors.GenerateCodeByType(fmt.Sprintf("r.PostForm.Get(%q)", name), prefix+field.Name, typeName, &field)
+		}
+		for _, imp := range imports {
+			importsMap[imp] = true
+		}
+		if code != "" {
+			lines = append(lines, code)
+		}
+	}
+
+	return strings.Join(lines, "\n\t")
+}
+
+// formFieldName returns the form key of a body field
+// Returns empty string for fields excluded with "-"
+func formFieldName(field parser.Field) string {
+	tag := reflect.StructTag(field.StructTag)
+	for _, key := range []string{"form", "json"} {
+		if value, ok := tag.Lookup(key); ok {
+			name, _, _ := strings.Cut(value, ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+	}
+	return field.Name
+}
+
+// isFormType reports whether a form value can be parsed into the type
+func isFormType(typeName string) bool {
+	if _, ok := types.Get(typeName); ok {
+		return true
+	}
+	return extractors.IsStringType(typeName) || extractors.IsIntType(typeName) ||
+		extractors.IsUintType(typeName) || extractors.IsFloatType(typeName) ||
+		extractors.IsBoolType(typeName)
+}
+
 // findRawBodyField searches for a RawBody field ([]byte) in the struct
 // Returns the field name if found, empty string otherwise
 func (g *Generator) findRawBodyField(s *parser.Struct) string {
@@ -312,6 +738,62 @@ func templateFuncs() template.FuncMap {
 	}
 }
 
+// durationLiteral renders a duration as a Go expression using the largest exact unit
+// Example: 5s -> "5 * time.Second", 1m30s -> "90 * time.Second", 1h -> "time.Hour"
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, unit := range units {
+		if d%unit.size == 0 {
+			if d == unit.size {
+				return unit.name
+			}
+			return fmt.Sprintf("%d * %s", d/unit.size, unit.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
+// receiverName returns the receiver variable name used in generated methods
+// It is the lowercased first letter of the type, avoiding the w and r parameters
+// Example: "Service" -> "s", "Repo" -> "rcv"
+func receiverName(typeName string) string {
+	name := strings.ToLower(typeName[:1])
+	if name == "w" || name == "r" {
+		return "rcv"
+	}
+	return name
+}
+
+// registerFuncName returns the name of the route registration function for a source file
+// Without a file name it falls back to RegisterRoutes
+// Example: "users.go" -> "RegisterUsersRoutes", "pet_store.go" -> "RegisterPetStoreRoutes"
+func registerFuncName(filename string) string {
+	base := strings.TrimSuffix(filepath.Base(filename), ".go")
+	words := strings.FieldsFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "RegisterRoutes"
+	}
+
+	var name strings.Builder
+	name.WriteString("Register")
+	for _, word := range words {
+		name.WriteString(capitalize(word))
+	}
+	name.WriteString("Routes")
+	return name.String()
+}
+
 // toCamelCasePrivate converts a string to camelCase with first letter lowercase
 // Example: "GetUser" -> "getUser", "SearchUsers" -> "searchUsers"
 func toCamelCasePrivate(s string) string {
@@ -1,6 +1,8 @@
 package codegen
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -196,9 +198,10 @@ func TestGenerate_UsesHandleResponse(t *testing.T) {
 
 	codeStr := string(code)
 
-	// Should use HandleResponse instead of separate HandleError and WriteJSON
-	if !strings.Contains(codeStr, "apikit.HandleResponse(w, response, err)") {
-		t.Error("expected generated code to use apikit.HandleResponse")
+	// Should use HandleResponseCtx instead of separate HandleError and WriteJSON,
+	// so error bodies carry the request ID
+	if !strings.Contains(codeStr, "apikit.HandleResponseCtx(r.Context(), w, response, err)") {
+		t.Error("expected generated code to use apikit.HandleResponseCtx")
 	}
 
 	// Should NOT contain the old pattern
@@ -212,3 +215,906 @@ func TestGenerate_UsesHandleResponse(t *testing.T) {
 		t.Error("expected generated code to NOT use old error handling pattern")
 	}
 }
+
+func TestGenerate_CompressDirective(t *testing.T) {
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	reqStruct := &parser.Struct{
+		Name: "ListUsersRequest",
+		Fields: []parser.Field{
+			{
+				Name:      "Page",
+				Type:      "int",
+				StructTag: `query:"page"`,
+			},
+		},
+	}
+
+	handler := parser.Handler{
+		Name:       "ListUsers",
+		Package:    "test",
+		ParamType:  "ListUsersRequest",
+		ReturnType: "[]User",
+		Struct:     reqStruct,
+		Directives: map[string]string{"compress": ""},
+	}
+
+	result := &parser.ParseResult{
+		Handlers: []parser.Handler{handler},
+		Structs: map[string]*parser.Struct{
+			"ListUsersRequest": reqStruct,
+		},
+		Source: parser.Source{
+			Package: "test",
+		},
+	}
+
+	code, err := gen.Generate(result)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	codeStr := string(code)
+
+	if !strings.Contains(codeStr, "gw := apikit.NewGzipResponseWriter(w, r)") {
+		t.Errorf("expected generated code to wrap the writer with apikit.NewGzipResponseWriter, got:\n%s", codeStr)
+	}
+	if !strings.Contains(codeStr, "apikit.HandleResponseCtx(r.Context(), gw, response, err)") {
+		t.Error("expected generated code to handle the response through the gzip writer")
+	}
+	if strings.Contains(codeStr, "apikit.HandleResponseCtx(r.Context(), w, response, err)") {
+		t.Error("expected generated code to NOT write the response uncompressed")
+	}
+}
+
+func TestGenerate_LogDirective(t *testing.T) {
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	reqStruct := &parser.Struct{
+		Name: "ListUsersRequest",
+		Fields: []parser.Field{
+			{
+				Name:      "Page",
+				Type:      "int",
+				StructTag: `query:"page"`,
+			},
+		},
+	}
+
+	handler := parser.Handler{
+		Name:       "ListUsers",
+		Package:    "test",
+		ParamType:  "ListUsersRequest",
+		ReturnType: "[]User",
+		Struct:     reqStruct,
+		Directives: map[string]string{"log": ""},
+	}
+
+	result := &parser.ParseResult{
+		Handlers: []parser.Handler{handler},
+		Structs: map[string]*parser.Struct{
+			"ListUsersRequest": reqStruct,
+		},
+		Source: parser.Source{
+			Package: "test",
+		},
+	}
+
+	code, err := gen.Generate(result)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	codeStr := string(code)
+
+	for _, want := range []string{
+		"w, logDone := apikit.StartRequestLog(w, r)",
+		"defer logDone()",
+		"apikit.LogError(r, err)",
+	} {
+		if !strings.Contains(codeStr, want) {
+			t.Errorf("expected generated code to contain %q, got:\n%s", want, codeStr)
+		}
+	}
+}
+
+func TestGenerate_SSE(t *testing.T) {
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		returnType string
+		directives map[string]string
+	}{
+		{name: "SSEStream return type", returnType: "apikit.SSEStream"},
+		{name: "sse directive", returnType: "<-chan apikit.SSEEvent", directives: map[string]string{"sse": ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &parser.ParseResult{
+				Handlers: []parser.Handler{
+					{
+						Name:       "WatchEvents",
+						Package:    "test",
+						ParamType:  "WatchRequest",
+						ReturnType: tt.returnType,
+						Directives: tt.directives,
+					},
+				},
+				Source: parser.Source{
+					Package: "test",
+				},
+			}
+
+			code, err := gen.Generate(result)
+			if err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+
+			codeStr := string(code)
+
+			expected := []string{
+				"sse := apikit.NewSSEWriter(w)",
+				"case event, ok := <-response:",
+				"sse.Send(event.Event, event.Data)",
+				"case <-r.Context().Done():",
+			}
+			for _, exp := range expected {
+				if !strings.Contains(codeStr, exp) {
+					t.Errorf("expected generated code to contain %q, got:\n%s", exp, codeStr)
+				}
+			}
+			if strings.Contains(codeStr, "apikit.HandleResponseCtx(r.Context(), w, response, err)") {
+				t.Error("expected streaming handlers to NOT use apikit.HandleResponseCtx")
+			}
+		})
+	}
+}
+
+func TestGenerate_ErrorHandlerDirective(t *testing.T) {
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	newResult := func(directives map[string]string) *parser.ParseResult {
+		reqStruct := &parser.Struct{
+			Name: "GetUserRequest",
+			Fields: []parser.Field{
+				{
+					Name:      "ID",
+					Type:      "string",
+					StructTag: `path:"id"`,
+				},
+			},
+		}
+		return &parser.ParseResult{
+			Handlers: []parser.Handler{
+				{
+					Name:       "GetUser",
+					Package:    "test",
+					ParamType:  "GetUserRequest",
+					ReturnType: "User",
+					Struct:     reqStruct,
+					Directives: directives,
+				},
+			},
+			Structs: map[string]*parser.Struct{
+				"GetUserRequest": reqStruct,
+			},
+			Source: parser.Source{
+				Package: "test",
+			},
+		}
+	}
+
+	t.Run("with directive", func(t *testing.T) {
+		code, err := gen.Generate(newResult(map[string]string{"errorhandler": "mapDomainError"}))
+		if err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		codeStr := string(code)
+		mapperCall := "err = mapDomainError(r.Context(), err)"
+		if !strings.Contains(codeStr, mapperCall) {
+			t.Fatalf("expected generated code to call the error mapper, got:\n%s", codeStr)
+		}
+
+		// The mapper must run before the response is handled
+		handleIdx := strings.Index(codeStr, "apikit.HandleResponseCtx(r.Context(), w, response, err)")
+		if handleIdx == -1 || strings.Index(codeStr, mapperCall) > handleIdx {
+			t.Error("expected error mapper to be called before apikit.HandleResponseCtx")
+		}
+	})
+
+	t.Run("without directive", func(t *testing.T) {
+		code, err := gen.Generate(newResult(nil))
+		if err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		if strings.Contains(string(code), "(r.Context(), err)") {
+			t.Error("expected no error mapper call without directive")
+		}
+	})
+}
+
+func TestGenerate_MiddlewareDirective(t *testing.T) {
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	reqStruct := &parser.Struct{
+		Name: "GetUserRequest",
+		Fields: []parser.Field{
+			{
+				Name:      "ID",
+				Type:      "string",
+				StructTag: `path:"id"`,
+			},
+		},
+	}
+
+	result := &parser.ParseResult{
+		Handlers: []parser.Handler{
+			{
+				Name:       "GetUser",
+				Package:    "test",
+				ParamType:  "GetUserRequest",
+				ReturnType: "User",
+				Struct:     reqStruct,
+				Directives: map[string]string{"middleware": "Auth, Logging,Trace"},
+			},
+		},
+		Structs: map[string]*parser.Struct{
+			"GetUserRequest": reqStruct,
+		},
+		Source: parser.Source{
+			Package: "test",
+		},
+	}
+
+	code, err := gen.Generate(result)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	codeStr := string(code)
+
+	if !strings.Contains(codeStr, "base := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {") {
+		t.Errorf("expected base handler to be declared, got:\n%s", codeStr)
+	}
+
+	// Outermost middleware first, as written in the directive
+	if !strings.Contains(codeStr, "return Auth(Logging(Trace(base))).ServeHTTP") {
+		t.Errorf("expected middleware chain Auth(Logging(Trace(base))), got:\n%s", codeStr)
+	}
+}
+
+func TestGenerate_RouteDirective(t *testing.T) {
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	reqStruct := &parser.Struct{
+		Name: "GetUserRequest",
+		Fields: []parser.Field{
+			{
+				Name:      "ID",
+				Type:      "string",
+				StructTag: `path:"id"`,
+			},
+		},
+	}
+
+	result := &parser.ParseResult{
+		Handlers: []parser.Handler{
+			{
+				Name:       "GetUser",
+				Package:    "test",
+				ParamType:  "GetUserRequest",
+				ReturnType: "User",
+				Struct:     reqStruct,
+				Method:     "GET",
+				Path:       "/users/{id}",
+			},
+			{
+				Name:       "DeleteUser",
+				Package:    "test",
+				ParamType:  "GetUserRequest",
+				ReturnType: "User",
+				Struct:     reqStruct,
+				Method:     "DELETE",
+				Path:       "/users/{id}",
+			},
+			{
+				Name:       "ListUsers",
+				Package:    "test",
+				ParamType:  "GetUserRequest",
+				ReturnType: "User",
+				Struct:     reqStruct,
+			},
+		},
+		Structs: map[string]*parser.Struct{
+			"GetUserRequest": reqStruct,
+		},
+		Source: parser.Source{
+			Package: "test",
+		},
+	}
+
+	code, err := gen.Generate(result)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	codeStr := string(code)
+
+	if !strings.Contains(codeStr, "func RegisterRoutes(mux *http.ServeMux) {") {
+		t.Errorf("expected RegisterRoutes function, got:\n%s", codeStr)
+	}
+
+	expectedRoutes := []string{
+		`mux.HandleFunc("GET /users/{id}", getUserAPIKit(GetUser))`,
+		`mux.HandleFunc("DELETE /users/{id}", deleteUserAPIKit(DeleteUser))`,
+	}
+	for _, expected := range expectedRoutes {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("expected route registration %q, got:\n%s", expected, codeStr)
+		}
+	}
+
+	// Handlers without a route directive are not registered
+	if strings.Contains(codeStr, "listUsersAPIKit(ListUsers)") {
+		t.Errorf("expected ListUsers to be skipped, got:\n%s", codeStr)
+	}
+}
+
+func TestRegisterFuncName(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{filename: "users.go", want: "RegisterUsersRoutes"},
+		{filename: "/src/api/pet_store.go", want: "RegisterPetStoreRoutes"},
+		{filename: "v2-orders.go", want: "RegisterV2OrdersRoutes"},
+		{filename: "", want: "RegisterRoutes"},
+	}
+
+	for _, tt := range tests {
+		if got := registerFuncName(tt.filename); got != tt.want {
+			t.Errorf("registerFuncName(%q) = %q, want %q", tt.filename, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate_NoRoutes(t *testing.T) {
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	result := &parser.ParseResult{
+		Handlers: []parser.Handler{
+			{
+				Name:       "Ping",
+				Package:    "test",
+				ParamType:  "PingRequest",
+				ReturnType: "string",
+			},
+		},
+		Source: parser.Source{
+			Package: "test",
+		},
+	}
+
+	code, err := gen.Generate(result)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	if strings.Contains(string(code), "RegisterRoutes") {
+		t.Errorf("expected no RegisterRoutes without route directives, got:\n%s", code)
+	}
+}
+
+func TestGenerate_XMLBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantXML bool
+	}{
+		{
+			name: "in:body xml field",
+			source: `package test
+
+import "context"
+
+type Pet struct {
+	Name string ` + "`xml:\"name\"`" + `
+}
+
+type CreatePetRequest struct {
+	// in:body xml
+	Body Pet
+}
+
+// apikit:handler
+func CreatePet(ctx context.Context, req CreatePetRequest) (Pet, error) {
+	return req.Body, nil
+}
+`,
+			wantXML: true,
+		},
+		{
+			name: "apikit:consumes on request struct",
+			source: `package test
+
+import "context"
+
+type Pet struct {
+	Name string ` + "`xml:\"name\"`" + `
+}
+
+// CreatePetRequest is decoded from XML
+// apikit:consumes application/xml
+type CreatePetRequest struct {
+	// in:body
+	Body Pet
+}
+
+// apikit:handler
+func CreatePet(ctx context.Context, req CreatePetRequest) (Pet, error) {
+	return req.Body, nil
+}
+`,
+			wantXML: true,
+		},
+		{
+			name: "JSON by default",
+			source: `package test
+
+import "context"
+
+type Pet struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type CreatePetRequest struct {
+	// in:body
+	Body Pet
+}
+
+// apikit:handler
+func CreatePet(ctx context.Context, req CreatePetRequest) (Pet, error) {
+	return req.Body, nil
+}
+`,
+			wantXML: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "handlers.go")
+			if err := os.WriteFile(testFile, []byte(tt.source), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := parser.New().ParseFile(testFile)
+			if err != nil {
+				t.Fatalf("ParseFile failed: %v", err)
+			}
+
+			gen, err := New()
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+
+			code, err := gen.Generate(result)
+			if err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+
+			codeStr := string(code)
+			hasXML := strings.Contains(codeStr, "xml.NewDecoder(bytes.NewReader(body)).Decode(&payload.Body)")
+			hasJSON := strings.Contains(codeStr, "json.Unmarshal(body, &payload.Body)")
+
+			if hasXML != tt.wantXML {
+				t.Errorf("expected XML decoder = %v, got:\n%s", tt.wantXML, codeStr)
+			}
+			if hasJSON == tt.wantXML {
+				t.Errorf("expected JSON decoder = %v, got:\n%s", !tt.wantXML, codeStr)
+			}
+			if tt.wantXML && !strings.Contains(codeStr, `"encoding/xml"`) {
+				t.Errorf("expected encoding/xml import, got:\n%s", codeStr)
+			}
+		})
+	}
+}
+
+func TestGenerate_MaxBodyDirective(t *testing.T) {
+	tests := []struct {
+		name      string
+		directive string
+		want      string
+	}{
+		{name: "megabytes", directive: "\n// apikit:maxbody 1MB", want: "http.MaxBytesReader(w, r.Body, 1048576)"},
+		{name: "fractional kilobytes", directive: "\n// apikit:maxbody 1.5kb", want: "http.MaxBytesReader(w, r.Body, 1536)"},
+		{name: "no directive", directive: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := `package test
+
+import "context"
+
+type CreatePetRequest struct {
+	// in:body
+	Body map[string]string
+}
+
+// apikit:handler` + tt.directive + `
+func CreatePet(ctx context.Context, req CreatePetRequest) (string, error) {
+	return "", nil
+}
+`
+			testFile := filepath.Join(t.TempDir(), "handlers.go")
+			if err := os.WriteFile(testFile, []byte(source), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := parser.New().ParseFile(testFile)
+			if err != nil {
+				t.Fatalf("ParseFile failed: %v", err)
+			}
+
+			gen, err := New()
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+
+			code, err := gen.Generate(result)
+			if err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+
+			codeStr := string(code)
+			if tt.want == "" {
+				if strings.Contains(codeStr, "MaxBytesReader") {
+					t.Errorf("expected no MaxBytesReader without apikit:maxbody, got:\n%s", codeStr)
+				}
+				return
+			}
+
+			if !strings.Contains(codeStr, tt.want) {
+				t.Errorf("expected %q in generated code, got:\n%s", tt.want, codeStr)
+			}
+			if !strings.Contains(codeStr, "apikit.RequestEntityTooLarge(") {
+				t.Errorf("expected 413 response on overflow, got:\n%s", codeStr)
+			}
+		})
+	}
+}
+
+func TestGenerate_MaxMemoryDirective(t *testing.T) {
+	tests := []struct {
+		name      string
+		directive string
+		field     string
+		want      string
+	}{
+		{name: "custom budget", directive: "\n// apikit:maxmemory 10MB", field: "Avatar multipart.File", want: "r.ParseMultipartForm(10485760)"},
+		{name: "default budget", directive: "", field: "Avatar multipart.File", want: "r.ParseMultipartForm(33554432)"},
+		{name: "no file fields", directive: "\n// apikit:maxmemory 10MB", field: "Name string `query:\"name\"`", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := `package test
+
+import (
+	"context"
+	"mime/multipart"
+)
+
+var _ multipart.File
+
+type UploadRequest struct {
+	` + tt.field + `
+}
+
+// apikit:handler` + tt.directive + `
+func Upload(ctx context.Context, req UploadRequest) (string, error) {
+	return "", nil
+}
+`
+			testFile := filepath.Join(t.TempDir(), "handlers.go")
+			if err := os.WriteFile(testFile, []byte(source), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := parser.New().ParseFile(testFile)
+			if err != nil {
+				t.Fatalf("ParseFile failed: %v", err)
+			}
+
+			gen, err := New()
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+
+			code, err := gen.Generate(result)
+			if err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+
+			codeStr := string(code)
+			if tt.want == "" {
+				if strings.Contains(codeStr, "ParseMultipartForm") {
+					t.Errorf("expected no ParseMultipartForm without file fields, got:\n%s", codeStr)
+				}
+				// The ignored directive is reported
+				warnings := gen.Warnings()
+				if len(warnings) != 1 || !strings.Contains(warnings[0], "apikit:maxmemory has no effect") {
+					t.Errorf("expected a maxmemory warning, got %v", warnings)
+				}
+				return
+			}
+			if len(gen.Warnings()) != 0 {
+				t.Errorf("expected no warnings, got %v", gen.Warnings())
+			}
+
+			if !strings.Contains(codeStr, tt.want) {
+				t.Errorf("expected %q in generated code, got:\n%s", tt.want, codeStr)
+			}
+			// The budget must be set before r.FormFile parses the form with its own default
+			if strings.Index(codeStr, "r.ParseMultipartForm(") > strings.Index(codeStr, "r.FormFile(") {
+				t.Errorf("expected ParseMultipartForm before FormFile, got:\n%s", codeStr)
+			}
+		})
+	}
+}
+
+func TestGenerate_InvalidMaxMemoryWarning(t *testing.T) {
+	source := `package test
+
+import (
+	"context"
+	"mime/multipart"
+)
+
+type UploadRequest struct {
+	Avatar multipart.File
+}
+
+// apikit:handler
+// apikit:maxmemory plenty
+func Upload(ctx context.Context, req UploadRequest) (string, error) {
+	return "", nil
+}
+`
+	testFile := filepath.Join(t.TempDir(), "handlers.go")
+	if err := os.WriteFile(testFile, []byte(source), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := parser.New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], `invalid apikit:maxmemory directive "plenty"`) {
+		t.Errorf("expected invalid maxmemory warning, got %v", result.Warnings)
+	}
+}
+
+func TestGenerate_TimeoutDirective(t *testing.T) {
+	tests := []struct {
+		name      string
+		directive string
+		want      string
+	}{
+		{name: "seconds", directive: "\n// apikit:timeout 5s", want: "context.WithTimeout(r.Context(), 5*time.Second)"},
+		{name: "mixed units", directive: "\n// apikit:timeout 1m30s", want: "context.WithTimeout(r.Context(), 90*time.Second)"},
+		{name: "single unit", directive: "\n// apikit:timeout 1h", want: "context.WithTimeout(r.Context(), time.Hour)"},
+		{name: "milliseconds", directive: "\n// apikit:timeout 250ms", want: "context.WithTimeout(r.Context(), 250*time.Millisecond)"},
+		{name: "no directive", directive: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := `package test
+
+import "context"
+
+type GetReportRequest struct {
+	ID string ` + "`path:\"id\"`" + `
+}
+
+// apikit:handler` + tt.directive + `
+func GetReport(ctx context.Context, req GetReportRequest) (string, error) {
+	return "", nil
+}
+`
+			testFile := filepath.Join(t.TempDir(), "handlers.go")
+			if err := os.WriteFile(testFile, []byte(source), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			result, err := parser.New().ParseFile(testFile)
+			if err != nil {
+				t.Fatalf("ParseFile failed: %v", err)
+			}
+
+			gen, err := New()
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+
+			code, err := gen.Generate(result)
+			if err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+
+			codeStr := string(code)
+			if tt.want == "" {
+				if strings.Contains(codeStr, "context.WithTimeout") {
+					t.Errorf("expected no timeout without apikit:timeout, got:\n%s", codeStr)
+				}
+				return
+			}
+
+			if !strings.Contains(codeStr, tt.want) {
+				t.Errorf("expected %q in generated code, got:\n%s", tt.want, codeStr)
+			}
+			if !strings.Contains(codeStr, "apikit.GatewayTimeout(") {
+				t.Errorf("expected 504 response on timeout, got:\n%s", codeStr)
+			}
+		})
+	}
+}
+
+func TestGenerate_MultipleBodyFieldsWarning(t *testing.T) {
+	source := `package test
+
+import "context"
+
+type Pet struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type CreatePetRequest struct {
+	// in:body
+	Pet Pet
+
+	Payload Pet ` + "`json:\"body\"`" + `
+}
+
+// apikit:handler
+func CreatePet(ctx context.Context, req CreatePetRequest) (Pet, error) {
+	return req.Pet, nil
+}
+`
+
+	testFile := filepath.Join(t.TempDir(), "handlers.go")
+	if err := os.WriteFile(testFile, []byte(source), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := parser.New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Fatalf("expected no parse warnings, got %v", result.Warnings)
+	}
+
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	code, err := gen.Generate(result)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	warnings := gen.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+	warning := warnings[0]
+	for _, want := range []string{"CreatePet", "CreatePetRequest", "multiple body fields (Pet, Payload)", "only Pet is decoded"} {
+		if !strings.Contains(warning, want) {
+			t.Errorf("expected warning to contain %q, got %q", want, warning)
+		}
+	}
+
+	// Generating again reports the same warnings without touching the parse result
+	if _, err := gen.Generate(result); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if len(gen.Warnings()) != 1 {
+		t.Errorf("expected one warning after generating twice, got %v", gen.Warnings())
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("expected the parse result warnings to be left alone, got %v", result.Warnings)
+	}
+
+	// The first body field is still decoded
+	if !strings.Contains(string(code), "json.Unmarshal(body, &payload.Pet)") {
+		t.Errorf("expected the Pet field to be decoded, got:\n%s", code)
+	}
+}
+
+func TestGenerate_InvalidHeaderNameWarning(t *testing.T) {
+	source := `package test
+
+import "context"
+
+type GetPetRequest struct {
+	// in:header 'x-request-id'
+	RequestID string
+
+	// in:header 'X-Custom Header'
+	Custom string
+}
+
+// apikit:handler
+func GetPet(ctx context.Context, req GetPetRequest) (string, error) {
+	return req.RequestID, nil
+}
+`
+
+	testFile := filepath.Join(t.TempDir(), "handlers.go")
+	if err := os.WriteFile(testFile, []byte(source), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := parser.New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	code, err := gen.Generate(result)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	warnings := gen.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+	for _, want := range []string{"GetPet", `"X-Custom Header"`, "Custom", "whitespace"} {
+		if !strings.Contains(warnings[0], want) {
+			t.Errorf("expected warning to contain %q, got %q", want, warnings[0])
+		}
+	}
+
+	// Valid names are canonicalized; the invalid one is not extracted
+	if !strings.Contains(string(code), `r.Header.Get("X-Request-Id")`) {
+		t.Errorf("expected canonical header lookup, got:\n%s", code)
+	}
+	if strings.Contains(string(code), "X-Custom Header") {
+		t.Errorf("expected the invalid header to be skipped, got:\n%s", code)
+	}
+}
@@ -0,0 +1,1336 @@
+package codegen
+
+import (
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/handler/parser"
+)
+
+// runGenerated generates wrappers for the handler source, builds it together
+// with the main program in a temporary module and returns the program output
+func runGenerated(t *testing.T, source, program string) string {
+	t.Helper()
+	return runGeneratedFiles(t, map[string]string{"handlers.go": source}, program)
+}
+
+// runGeneratedFiles is runGenerated for several handler source files of one package,
+// each generating its own <file>_apikit.go
+func runGeneratedFiles(t *testing.T, sources map[string]string, program string) string {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	_, thisFile, _, _ := runtime.Caller(0)
+	repoRoot, err := filepath.Abs(filepath.Join(filepath.Dir(thisFile), "..", ".."))
+	if err != nil {
+		t.Fatalf("resolving repository root: %v", err)
+	}
+
+	dir := t.TempDir()
+	goMod := "module example.com/itest\n\ngo 1.25\n\n" +
+		"require github.com/reation-io/apikit v0.0.0\n\n" +
+		"replace github.com/reation-io/apikit => " + repoRoot + "\n"
+	goSum, err := os.ReadFile(filepath.Join(repoRoot, "go.sum"))
+	if err != nil {
+		t.Fatalf("reading go.sum: %v", err)
+	}
+
+	files := map[string]string{
+		"go.mod":  goMod,
+		"go.sum":  string(goSum),
+		"main.go": program,
+	}
+	maps.Copy(files, sources)
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+
+	gen, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var generated strings.Builder
+	for name := range sources {
+		result, err := parser.New().ParseFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", name, err)
+		}
+
+		code, err := gen.Generate(result)
+		if err != nil {
+			t.Fatalf("Generate(%s) failed: %v", name, err)
+		}
+		outName := strings.TrimSuffix(name, ".go") + "_apikit.go"
+		if err := os.WriteFile(filepath.Join(dir, outName), code, 0644); err != nil {
+			t.Fatalf("writing generated code: %v", err)
+		}
+		generated.Write(code)
+	}
+
+	cmd := exec.Command("go", "run", "-mod=mod", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOPROXY=off", "GOWORK=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running generated code failed: %v\n%s\ngenerated:\n%s", err, out, generated.String())
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+func TestIntegration_ValidatesQueryParameters(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type ListItemsRequest struct {
+	// in:query
+	Page int ` + "`json:\"page\" validate:\"min=1\"`" + `
+}
+
+type ListItemsResponse struct {
+	Page int ` + "`json:\"page\"`" + `
+}
+
+// apikit:handler
+func ListItems(ctx context.Context, req ListItemsRequest) (ListItemsResponse, error) {
+	return ListItemsResponse{Page: req.Page}, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+)
+
+func main() {
+	for _, query := range []string{"page=0", "page=2"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/items?"+query, nil)
+		listItemsAPIKit(ListItems)(w, r)
+		fmt.Println(query, w.Code)
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	if !strings.Contains(out, "page=0 422") {
+		t.Errorf("expected 422 for page=0, got:\n%s", out)
+	}
+	if !strings.Contains(out, "page=2 200") {
+		t.Errorf("expected 200 for page=2, got:\n%s", out)
+	}
+}
+
+func TestIntegration_MaxBodyLimit(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type CreateNoteRequest struct {
+	// in:body
+	Body map[string]string
+}
+
+// apikit:handler
+// apikit:maxbody 1KB
+func CreateNote(ctx context.Context, req CreateNoteRequest) (int, error) {
+	return len(req.Body["text"]), nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	for _, size := range []int{100, 2000} {
+		body := ` + "`" + `{"text":"` + "`" + ` + strings.Repeat("a", size) + ` + "`" + `"}` + "`" + `
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/notes", strings.NewReader(body))
+		createNoteAPIKit(CreateNote)(w, r)
+		fmt.Println(size, w.Code)
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	if !strings.Contains(out, "100 200") {
+		t.Errorf("expected 200 for a small body, got:\n%s", out)
+	}
+	if !strings.Contains(out, "2000 413") {
+		t.Errorf("expected 413 for a body over the limit, got:\n%s", out)
+	}
+}
+
+func TestIntegration_StrictBody(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type Note struct {
+	Text string ` + "`json:\"text\"`" + `
+}
+
+type CreateNoteRequest struct {
+	// in:body
+	Body Note
+}
+
+// apikit:handler
+// apikit:strictbody
+func CreateNote(ctx context.Context, req CreateNoteRequest) (Note, error) {
+	return req.Body, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"strings"
+)
+
+func main() {
+	generated, _ := os.ReadFile("handlers_apikit.go")
+	fmt.Println("strict", strings.Contains(string(generated), "dec.DisallowUnknownFields()"))
+
+	for _, body := range []string{` + "`" + `{"text":"hi"}` + "`" + `, ` + "`" + `{"txet":"hi"}` + "`" + `} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/notes", strings.NewReader(body))
+		createNoteAPIKit(CreateNote)(w, r)
+		fmt.Println(body, w.Code)
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	if !strings.Contains(out, "strict true") {
+		t.Errorf("expected DisallowUnknownFields() in generated code, got:\n%s", out)
+	}
+	if !strings.Contains(out, `{"text":"hi"} 200`) {
+		t.Errorf("expected 200 for known fields, got:\n%s", out)
+	}
+	if !strings.Contains(out, `{"txet":"hi"} 400`) {
+		t.Errorf("expected 400 for an unknown field, got:\n%s", out)
+	}
+}
+
+func TestIntegration_JSONDecodeErrors(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type Pet struct {
+	Name string ` + "`json:\"name\"`" + `
+	Age  int    ` + "`json:\"age\"`" + `
+}
+
+type CreatePetRequest struct {
+	// in:body
+	Body Pet
+}
+
+// apikit:handler
+func CreatePet(ctx context.Context, req CreatePetRequest) (Pet, error) {
+	return req.Body, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	for _, body := range []string{` + "`" + `{"age":"two"}` + "`" + `, ` + "`" + `{"name": }` + "`" + `} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/pets", strings.NewReader(body))
+		createPetAPIKit(CreatePet)(w, r)
+		fmt.Println(w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	if !strings.Contains(out, `400 {"code":400,"errorCode":"Bad Request","message":"field \"age\" must be an integer, got string"`) {
+		t.Errorf("expected a field-level 400 for a type mismatch, got:\n%s", out)
+	}
+	if !strings.Contains(out, `"message":"malformed JSON at offset 10: invalid character '}' looking for beginning of value"`) {
+		t.Errorf("expected a descriptive 400 for a syntax error, got:\n%s", out)
+	}
+}
+
+func TestIntegration_ConsumesForm(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type Note struct {
+	Text     string   ` + "`json:\"text\"`" + `
+	Priority int      ` + "`json:\"priority\"`" + `
+	Tags     []string ` + "`form:\"tag\" json:\"tags\"`" + `
+}
+
+type CreateNoteRequest struct {
+	// in:body
+	Body Note
+}
+
+// apikit:handler
+// apikit:consumes application/json,application/x-www-form-urlencoded
+func CreateNote(ctx context.Context, req CreateNoteRequest) (Note, error) {
+	return req.Body, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	requests := []struct{ contentType, body string }{
+		{"application/json", ` + "`" + `{"text":"hi","priority":2,"tags":["a","b"]}` + "`" + `},
+		{"application/x-www-form-urlencoded; charset=utf-8", "text=hi&priority=2&tag=a&tag=b"},
+		{"application/x-www-form-urlencoded", "priority=high"},
+	}
+	for _, req := range requests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/notes", strings.NewReader(req.body))
+		r.Header.Set("Content-Type", req.contentType)
+		createNoteAPIKit(CreateNote)(w, r)
+		fmt.Println(req.body, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	want := `{"text":"hi","priority":2,"tags":["a","b"]}`
+	if !strings.Contains(out, `{"text":"hi","priority":2,"tags":["a","b"]} 200 `+want) {
+		t.Errorf("expected JSON body to be decoded, got:\n%s", out)
+	}
+	if !strings.Contains(out, "text=hi&priority=2&tag=a&tag=b 200 "+want) {
+		t.Errorf("expected form body to be decoded by json and form tags, got:\n%s", out)
+	}
+	if !strings.Contains(out, "priority=high 400") {
+		t.Errorf("expected 400 for an invalid form value, got:\n%s", out)
+	}
+}
+
+func TestIntegration_QueryDelimiter(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type ListRequest struct {
+	IDs  []int    ` + "`query:\"ids\" delimiter:\",\"`" + `
+	Tags []string // in:query tags,style=pipeDelimited
+}
+
+// apikit:handler
+func List(ctx context.Context, req ListRequest) (ListRequest, error) {
+	return req, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+)
+
+func main() {
+	for _, url := range []string{"/?ids=1,2,3&tags=a|b", "/?ids=1,x"} {
+		w := httptest.NewRecorder()
+		listAPIKit(List)(w, httptest.NewRequest("GET", url, nil))
+		fmt.Println(url, w.Code, w.Body.String())
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	if !strings.Contains(out, `/?ids=1,2,3&tags=a|b 200 {"IDs":[1,2,3],"Tags":["a","b"]}`) {
+		t.Errorf("expected delimited values to be split, got:\n%s", out)
+	}
+	if !strings.Contains(out, "/?ids=1,x 400") {
+		t.Errorf("expected 400 for an invalid element, got:\n%s", out)
+	}
+}
+
+func TestIntegration_EnumCheck(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type ListPetsRequest struct {
+	// enum: available,pending,sold
+	Status string ` + "`query:\"status\"`" + `
+
+	Tags []string ` + "`query:\"tags\"`" + ` // enum: cat,dog
+}
+
+// apikit:handler
+func ListPets(ctx context.Context, req ListPetsRequest) (ListPetsRequest, error) {
+	return req, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	for _, url := range []string{"/?status=sold&tags=cat", "/", "/?status=lost", "/?tags=cat&tags=fish"} {
+		w := httptest.NewRecorder()
+		listPetsAPIKit(ListPets)(w, httptest.NewRequest("GET", url, nil))
+		fmt.Println(url, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		"/?status=sold&tags=cat 200",
+		"/ 200",
+		"/?status=lost 400",
+		"/?tags=cat&tags=fish 400",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_SliceBody(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type Item struct {
+	Name     string ` + "`json:\"name\" validate:\"required\"`" + `
+	Quantity int    ` + "`json:\"quantity\"`" + `
+}
+
+type CreateItemsRequest struct {
+	// in:body
+	Body []Item ` + "`validate:\"dive\"`" + `
+}
+
+type CreateItemsResponse struct {
+	Count int    ` + "`json:\"count\"`" + `
+	Last  string ` + "`json:\"last\"`" + `
+}
+
+// apikit:handler
+func CreateItems(ctx context.Context, req CreateItemsRequest) (CreateItemsResponse, error) {
+	resp := CreateItemsResponse{Count: len(req.Body)}
+	if len(req.Body) > 0 {
+		resp.Last = req.Body[len(req.Body)-1].Name
+	}
+	return resp, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	for _, body := range []string{
+		` + "`" + `[{"name":"apple","quantity":2},{"name":"pear","quantity":1}]` + "`" + `,
+		` + "`" + `[]` + "`" + `,
+		` + "`" + `{"name":"apple"}` + "`" + `,
+		` + "`" + `[{"quantity":1}]` + "`" + `,
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/items", strings.NewReader(body))
+		createItemsAPIKit(CreateItems)(w, r)
+		fmt.Println(body, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		`[{"name":"apple","quantity":2},{"name":"pear","quantity":1}] 200 {"count":2,"last":"pear"}`,
+		`[] 200 {"count":0,"last":""}`,
+		`{"name":"apple"} 400`,
+		`[{"quantity":1}] 422`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_ContextValues(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type ctxKey string
+
+const (
+	userIDKey   ctxKey = "userID"
+	tenantIDKey ctxKey = "tenantID"
+)
+
+type WhoAmIRequest struct {
+	// in:context userIDKey
+	UserID string
+
+	TenantID int ` + "`context:\"tenantIDKey\"`" + `
+}
+
+// apikit:handler
+func WhoAmI(ctx context.Context, req WhoAmIRequest) (WhoAmIRequest, error) {
+	return req, nil
+}
+`
+
+	program := `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	for _, tc := range []struct {
+		name   string
+		tenant any
+	}{
+		{"int", 42},
+		{"missing", nil},
+		{"mismatch", "42"},
+	} {
+		ctx := context.WithValue(context.Background(), userIDKey, "u-1")
+		if tc.tenant != nil {
+			ctx = context.WithValue(ctx, tenantIDKey, tc.tenant)
+		}
+		w := httptest.NewRecorder()
+		r := httptest.NewRequestWithContext(ctx, "GET", "/me", nil)
+		whoAmIAPIKit(WhoAmI)(w, r)
+		fmt.Println(tc.name, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		`int 200 {"UserID":"u-1","TenantID":42}`,
+		`missing 200 {"UserID":"u-1","TenantID":0}`,
+		`mismatch 400`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_Logging(t *testing.T) {
+	source := `package main
+
+import (
+	"context"
+	"errors"
+)
+
+type GetItemRequest struct {
+	ID int ` + "`path:\"id\"`" + `
+}
+
+// apikit:handler
+// apikit:log
+func GetItem(ctx context.Context, req GetItemRequest) (GetItemRequest, error) {
+	if req.ID == 0 {
+		return req, errors.New("missing item")
+	}
+	return req, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/reation-io/apikit"
+)
+
+type fakeLogger struct{}
+
+func (fakeLogger) LogRequest(r *http.Request, status int, d time.Duration) {
+	fmt.Println("request", r.URL.Path, status)
+}
+
+func (fakeLogger) LogError(r *http.Request, err error) {
+	fmt.Println("error", r.URL.Path, err)
+}
+
+func main() {
+	apikit.SetLogger(fakeLogger{})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items/{id}", getItemAPIKit(GetItem))
+	for _, path := range []string{"/items/7", "/items/0", "/items/x"} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	want := "request /items/7 200\nerror /items/0 missing item\nrequest /items/0 500\nrequest /items/x 400"
+	if strings.TrimSpace(out) != want {
+		t.Errorf("unexpected log output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestIntegration_Timeout(t *testing.T) {
+	source := `package main
+
+import (
+	"context"
+	"time"
+)
+
+type WaitRequest struct {
+	Delay  time.Duration ` + "`query:\"delay\"`" + `
+	Ignore bool          ` + "`query:\"ignore\"`" + `
+}
+
+// apikit:handler
+// apikit:timeout 50ms
+func Wait(ctx context.Context, req WaitRequest) (string, error) {
+	if req.Ignore {
+		// Succeeds after the deadline without looking at ctx
+		time.Sleep(req.Delay)
+		return "late", nil
+	}
+	select {
+	case <-time.After(req.Delay):
+		return "done", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	for _, query := range []string{"delay=1ms", "delay=5s", "delay=100ms&ignore=true"} {
+		w := httptest.NewRecorder()
+		waitAPIKit(Wait)(w, httptest.NewRequest("GET", "/wait?"+query, nil))
+		fmt.Println(query, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		`delay=1ms 200 "done"`,
+		`delay=5s 504`,
+		`handler timed out`,
+		`delay=100ms&ignore=true 200 "late"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_OutHeaders(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type CreateItemRequest struct {
+	// in:body
+	Body struct {
+		Name string ` + "`json:\"name\"`" + `
+	}
+}
+
+type CreatedItem struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+
+	// out:header Location
+	Location string ` + "`json:\"location\"`" + `
+
+	// out:header X-Item-Version
+	Version *int
+}
+
+// apikit:handler
+func CreateItem(ctx context.Context, req CreateItemRequest) (*CreatedItem, error) {
+	if req.Body.Name == "" {
+		return nil, nil
+	}
+	version := 3
+	return &CreatedItem{ID: 7, Name: req.Body.Name, Location: "/items/7", Version: &version}, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	for _, body := range []string{` + "`{\"name\":\"lamp\"}`, `{}`" + `} {
+		w := httptest.NewRecorder()
+		createItemAPIKit(CreateItem)(w, httptest.NewRequest("POST", "/items", strings.NewReader(body)))
+		fmt.Printf("%d location=%q version=%q body=%s\n", w.Code, w.Header().Get("Location"),
+			w.Header().Get("X-Item-Version"), strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		`200 location="/items/7" version="3" body={"id":7,"name":"lamp"}`,
+		`204 location="" version="" body=`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_IntOverflow(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type ResizeRequest struct {
+	Level int8    ` + "`query:\"level\"`" + `
+	Steps []int16 ` + "`query:\"steps\"`" + `
+	Max   uint8   ` + "`query:\"max\"`" + `
+}
+
+// apikit:handler
+func Resize(ctx context.Context, req ResizeRequest) (ResizeRequest, error) {
+	return req, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+)
+
+func main() {
+	for _, query := range []string{"level=127", "level=300", "steps=1&steps=40000", "max=256"} {
+		w := httptest.NewRecorder()
+		resizeAPIKit(Resize)(w, httptest.NewRequest("GET", "/resize?"+query, nil))
+		fmt.Println(query, w.Code)
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		"level=127 200",
+		"level=300 400",
+		"steps=1&steps=40000 400",
+		"max=256 400",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_PointerQueryPresence(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type Status string
+
+type PatchItemRequest struct {
+	Name   *string ` + "`query:\"name\"`" + `
+	Limit  *int    ` + "`query:\"limit\"`" + `
+	Status *Status ` + "`query:\"status\"`" + `
+	Note   string  ` + "`query:\"note\"`" + `
+}
+
+// apikit:handler
+func PatchItem(ctx context.Context, req PatchItemRequest) (PatchItemRequest, error) {
+	return req, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	for _, query := range []string{"", "name=&note=", "name=lamp&limit=3&status=sold", "limit=x"} {
+		w := httptest.NewRecorder()
+		patchItemAPIKit(PatchItem)(w, httptest.NewRequest("PATCH", "/items?"+query, nil))
+		fmt.Printf("%q %d %s\n", query, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		`"" 200 {"Name":null,"Limit":null,"Status":null,"Note":""}`,
+		`"name=&note=" 200 {"Name":"","Limit":null,"Status":null,"Note":""}`,
+		`"name=lamp&limit=3&status=sold" 200 {"Name":"lamp","Limit":3,"Status":"sold","Note":""}`,
+		`"limit=x" 400`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_MethodHandlers(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type GreetRequest struct {
+	Name string ` + "`path:\"name\"`" + `
+}
+
+type Greeter struct {
+	Greeting string
+}
+
+// apikit:handler
+// apikit:route GET /greet/{name}
+func (g *Greeter) Greet(ctx context.Context, req GreetRequest) (string, error) {
+	return g.Greeting + ", " + req.Name, nil
+}
+
+type Shouter struct{}
+
+// apikit:handler
+// apikit:route GET /shout/{name}
+func (Shouter) Greet(ctx context.Context, req GreetRequest) (string, error) {
+	return "HEY " + req.Name, nil
+}
+
+// apikit:handler
+// apikit:route GET /hello/{name}
+func Hello(ctx context.Context, req GreetRequest) (string, error) {
+	return "hello " + req.Name, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	mux := http.NewServeMux()
+	RegisterHandlersRoutes(mux)
+	(&Greeter{Greeting: "Hi"}).RegisterHandlersRoutes(mux)
+	Shouter{}.RegisterHandlersRoutes(mux)
+
+	for _, path := range []string{"/greet/ann", "/shout/bob", "/hello/cy"} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		fmt.Println(path, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+
+	// The generated method can also be mounted directly
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.SetPathValue("name", "dee")
+	(&Greeter{Greeting: "Yo"}).greetAPIKit(w, r)
+	fmt.Println("direct", w.Code, strings.TrimSpace(w.Body.String()))
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		`/greet/ann 200 "Hi, ann"`,
+		`/shout/bob 200 "HEY bob"`,
+		`/hello/cy 200 "hello cy"`,
+		`direct 200 "Yo, dee"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_RoutesAcrossFiles(t *testing.T) {
+	users := `package main
+
+import "context"
+
+type Store struct {
+	Name string
+}
+
+type UserRequest struct {
+	ID string ` + "`path:\"id\"`" + `
+}
+
+// apikit:handler
+// apikit:route GET /users
+func ListUsers(ctx context.Context, req UserRequest) (string, error) {
+	return "users", nil
+}
+
+// apikit:handler
+// apikit:route GET /users/{id}
+func (s *Store) GetUser(ctx context.Context, req UserRequest) (string, error) {
+	return s.Name + " user " + req.ID, nil
+}
+`
+
+	pets := `package main
+
+import "context"
+
+type PetRequest struct {
+	ID string ` + "`path:\"id\"`" + `
+}
+
+// apikit:handler
+// apikit:route GET /pets
+func ListPets(ctx context.Context, req PetRequest) (string, error) {
+	return "pets", nil
+}
+
+// apikit:handler
+// apikit:route GET /pets/{id}
+func (s *Store) GetPet(ctx context.Context, req PetRequest) (string, error) {
+	return s.Name + " pet " + req.ID, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	store := &Store{Name: "shop"}
+	mux := http.NewServeMux()
+	RegisterUsersRoutes(mux)
+	RegisterPetsRoutes(mux)
+	store.RegisterUsersRoutes(mux)
+	store.RegisterPetsRoutes(mux)
+
+	for _, path := range []string{"/users", "/users/1", "/pets", "/pets/2"} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		fmt.Println(path, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGeneratedFiles(t, map[string]string{"users.go": users, "pets.go": pets}, program)
+
+	for _, want := range []string{
+		`/users 200 "users"`,
+		`/users/1 200 "shop user 1"`,
+		`/pets 200 "pets"`,
+		`/pets/2 200 "shop pet 2"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_NilPointerResponse(t *testing.T) {
+	source := `package main
+
+import "context"
+
+type Pet struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type GetPetRequest struct {
+	Name string ` + "`query:\"name\"`" + `
+}
+
+// apikit:handler
+func GetPet(ctx context.Context, req GetPetRequest) (*Pet, error) {
+	if req.Name == "" {
+		return nil, nil
+	}
+	return &Pet{Name: req.Name}, nil
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	for _, name := range []string{"", "Rex"} {
+		w := httptest.NewRecorder()
+		getPetAPIKit(GetPet)(w, httptest.NewRequest("GET", "/pets?name="+name, nil))
+		fmt.Printf("%q %d %q\n", name, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		`"" 204 ""`,
+		`"Rex" 200 "{\"name\":\"Rex\"}"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_MultipartFile(t *testing.T) {
+	source := `package main
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+)
+
+type UploadRequest struct {
+	Avatar multipart.File
+	Meta *multipart.FileHeader ` + "`form:\"document\"`" + `
+}
+
+// apikit:handler
+func Upload(ctx context.Context, req UploadRequest) (string, error) {
+	if req.Avatar == nil {
+		return "no avatar", nil
+	}
+	defer req.Avatar.Close()
+	data, err := io.ReadAll(req.Avatar)
+	if err != nil {
+		return "", err
+	}
+	return string(data) + " " + req.Meta.Filename, nil
+}
+`
+
+	program := `package main
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"strings"
+)
+
+func main() {
+	generated, _ := os.ReadFile("handlers_apikit.go")
+	fmt.Println("formfile", strings.Contains(string(generated), ` + "`" + `if file, _, err := r.FormFile("avatar"); err == nil {
+		payload.Avatar = file` + "`" + `))
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	avatar, _ := mw.CreateFormFile("avatar", "avatar.png")
+	avatar.Write([]byte("png bytes"))
+	document, _ := mw.CreateFormFile("document", "cv.pdf")
+	document.Write([]byte("pdf bytes"))
+	mw.Close()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/upload", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	uploadAPIKit(Upload)(w, r)
+	fmt.Println(w.Code, strings.TrimSpace(w.Body.String()))
+
+	// Missing files leave the fields nil
+	body.Reset()
+	mw = multipart.NewWriter(&body)
+	mw.WriteField("note", "no files")
+	mw.Close()
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("POST", "/upload", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	uploadAPIKit(Upload)(w, r)
+	fmt.Println(w.Code, strings.TrimSpace(w.Body.String()))
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		"formfile true",
+		`200 "png bytes cv.pdf"`,
+		`200 "no avatar"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_UploadLimits(t *testing.T) {
+	source := `package main
+
+import (
+	"context"
+	"mime/multipart"
+)
+
+type UploadRequest struct {
+	Avatar multipart.File ` + "`form:\"avatar\" maxfilesize:\"16B\" accept:\"image/png,image/jpeg\"`" + `
+}
+
+// apikit:handler
+func Upload(ctx context.Context, req UploadRequest) (string, error) {
+	if req.Avatar != nil {
+		req.Avatar.Close()
+	}
+	return "uploaded", nil
+}
+`
+
+	program := `package main
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+)
+
+func main() {
+	for _, upload := range []struct{ contentType, data string }{
+		{"image/png", "small png"},
+		{"image/jpeg", "a jpeg well over sixteen bytes"},
+		{"text/plain", "small text"},
+	} {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", ` + "`" + `form-data; name="avatar"; filename="avatar"` + "`" + `)
+		header.Set("Content-Type", upload.contentType)
+		part, _ := mw.CreatePart(header)
+		part.Write([]byte(upload.data))
+		mw.Close()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/upload", &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		uploadAPIKit(Upload)(w, r)
+		fmt.Println(upload.contentType, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	for _, want := range []string{
+		`image/png 200 "uploaded"`,
+		`image/jpeg 413 {"code":413,"errorCode":"Request Entity Too Large","message":"file avatar exceeds 16 bytes"}`,
+		`text/plain 415 {"code":415,"errorCode":"Unsupported Media Type","message":"file avatar has content type \"text/plain\", expected image/png, image/jpeg"}`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestIntegration_CompressHttpResponse(t *testing.T) {
+	source := `package main
+
+import (
+	"context"
+
+	"github.com/reation-io/apikit"
+)
+
+type CreateItemRequest struct {
+	Name string ` + "`query:\"name\"`" + `
+}
+
+// apikit:handler
+// apikit:compress
+func CreateItem(ctx context.Context, req CreateItemRequest) (*apikit.HttpResponse, error) {
+	return apikit.NewHttpResponse(201, map[string]string{"name": req.Name}).WithHeader("Location", "/items/1"), nil
+}
+`
+
+	program := `package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+)
+
+func main() {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/items?name=lamp", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	createItemAPIKit(CreateItem)(w, r)
+
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		fmt.Println("not gzip:", err)
+		return
+	}
+	body, _ := io.ReadAll(gz)
+	fmt.Println(w.Code, w.Header().Get("Location"), w.Header().Get("Content-Encoding"), strings.TrimSpace(string(body)))
+}
+`
+
+	out := runGenerated(t, source, program)
+
+	if !strings.Contains(out, `201 /items/1 gzip {"name":"lamp"}`) {
+		t.Errorf("expected compressed HttpResponse with its status and headers, got:\n%s", out)
+	}
+}
+
+func TestIntegration_ErrorRequestID(t *testing.T) {
+	source := `package main
+
+import (
+	"context"
+
+	"github.com/reation-io/apikit"
+)
+
+type GetItemRequest struct {
+	ID int ` + "`path:\"id\" validate:\"min=1\"`" + `
+}
+
+// apikit:handler
+func GetItem(ctx context.Context, req GetItemRequest) (map[string]int, error) {
+	return nil, apikit.NotFound("item")
+}
+`
+
+	program := `package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/reation-io/apikit"
+)
+
+func main() {
+	mux := http.NewServeMux()
+	mux.Handle("GET /items/{id}", apikit.RequestID(getItemAPIKit(GetItem)))
+
+	for _, path := range []string{"/items/7", "/items/0"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Set("X-Request-ID", "req-42")
+		mux.ServeHTTP(w, r)
+		fmt.Println(w.Code, strings.TrimSpace(w.Body.String()))
+	}
+}
+`
+
+	out := runGenerated(t, source, program)
+	for _, want := range []string{`404 {"code":404,`, `422 {"code":422,`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %s, got:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, `"requestId":"req-42"`) != 2 {
+		t.Errorf("expected handler and validation errors to carry the request ID, got:\n%s", out)
+	}
+}
@@ -23,7 +23,7 @@ func (e *BodyExtractor) Priority() int {
 
 func (e *BodyExtractor) CanExtract(field *parser.Field) bool {
 	// Skip special fields - they have their own extractors
-	if field.IsRequest || field.IsResponseWriter || field.IsRawBody {
+	if field.IsRequest || field.IsResponseWriter || field.IsRawBody || field.IsFile {
 		return false
 	}
 
@@ -0,0 +1,52 @@
+package extractors
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/reation-io/apikit/handler/parser"
+)
+
+func init() {
+	Register(&ContextExtractor{})
+}
+
+// ContextExtractor assigns request-scoped values stored in the request context,
+// such as the authenticated user set by a middleware
+// The key is a Go expression in the handler's package (e.g., a context key constant)
+type ContextExtractor struct{}
+
+func (e *ContextExtractor) Name() string {
+	return "context"
+}
+
+func (e *ContextExtractor) Priority() int {
+	return 35 // Extract context values after headers, before body
+}
+
+func (e *ContextExtractor) CanExtract(field *parser.Field) bool {
+	// Check if field has context tag
+	if field.StructTag != "" {
+		tag := reflect.StructTag(field.StructTag)
+		if _, ok := tag.Lookup("context"); ok {
+			return true
+		}
+	}
+	// Check if field is marked with // in:context comment
+	return field.InComment == "context"
+}
+
+func (e *ContextExtractor) GenerateCode(field *parser.Field, structName string) (string, []string) {
+	key := GetParameterName(field, "context")
+
+	// A missing value is not an error here: required values are enforced
+	// by the validator (validate:"required"), a value of another type is
+	// reported since it means the middleware and the handler disagree
+	return fmt.Sprintf(`switch v := r.Context().Value(%s).(type) {
+	case nil:
+	case %s:
+		payload.%s = v
+	default:
+		return fmt.Errorf("context value %s: expected %s, got %%T", v)
+	}`, key, field.Type, field.Name, key, field.Type), []string{"fmt"}
+}
@@ -0,0 +1,110 @@
+package extractors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/handler/parser"
+)
+
+func TestContextExtractor_CanExtract(t *testing.T) {
+	e := &ContextExtractor{}
+
+	tests := []struct {
+		name     string
+		field    *parser.Field
+		expected bool
+	}{
+		{
+			name:     "with context tag",
+			field:    &parser.Field{StructTag: `context:"userIDKey"`},
+			expected: true,
+		},
+		{
+			name:     "with in:context comment",
+			field:    &parser.Field{InComment: "context"},
+			expected: true,
+		},
+		{
+			name:     "without context tag or comment",
+			field:    &parser.Field{StructTag: `header:"X-User-ID"`},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := e.CanExtract(tt.field)
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestContextExtractor_GenerateCode(t *testing.T) {
+	e := &ContextExtractor{}
+
+	tests := []struct {
+		name           string
+		field          *parser.Field
+		expectedInCode []string
+	}{
+		{
+			name: "string field from comment",
+			field: &parser.Field{
+				Name:          "UserID",
+				Type:          "string",
+				InComment:     "context",
+				InCommentName: "userIDKey",
+			},
+			expectedInCode: []string{
+				"switch v := r.Context().Value(userIDKey).(type) {",
+				"case string:",
+				"payload.UserID = v",
+				`return fmt.Errorf("context value userIDKey: expected string, got %T", v)`,
+			},
+		},
+		{
+			name: "int field from tag",
+			field: &parser.Field{
+				Name:      "TenantID",
+				Type:      "int",
+				StructTag: `context:"auth.TenantKey"`,
+			},
+			expectedInCode: []string{
+				"r.Context().Value(auth.TenantKey)",
+				"case int:",
+				"payload.TenantID = v",
+			},
+		},
+		{
+			name: "pointer field",
+			field: &parser.Field{
+				Name:      "User",
+				Type:      "*User",
+				IsPointer: true,
+				InComment: "context",
+			},
+			expectedInCode: []string{
+				"r.Context().Value(user)",
+				"case *User:",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, imports := e.GenerateCode(tt.field, "Request")
+
+			for _, expected := range tt.expectedInCode {
+				if !strings.Contains(code, expected) {
+					t.Errorf("expected code to contain %q, got:\n%s", expected, code)
+				}
+			}
+			if len(imports) != 1 || imports[0] != "fmt" {
+				t.Errorf("expected fmt import, got %v", imports)
+			}
+		})
+	}
+}
@@ -19,7 +19,7 @@ func (e *CookieExtractor) Name() string {
 }
 
 func (e *CookieExtractor) Priority() int {
-	return 35 // Extract cookies after headers but before body
+	return 15 // Extract cookies after path params but before query params
 }
 
 func (e *CookieExtractor) CanExtract(field *parser.Field) bool {
@@ -39,8 +39,24 @@ func (e *CookieExtractor) GenerateCode(field *parser.Field, structName string) (
 	fieldName := field.Name
 	typeName := GetBaseType(field)
 
-	varName := fmt.Sprintf(`apikit.GetCookie(r, "%s")`, cookieName)
-
 	// Use the public helper to generate code based on type
-	return GenerateCodeByType(varName, fieldName, typeName, field)
+	code, imports := GenerateCodeByType("c.Value", fieldName, typeName, field)
+	if code == "" {
+		return "", imports
+	}
+
+	// A missing cookie is not an error here: required cookies are enforced
+	// by the validator (validate:"required"), optional ones keep their zero
+	// value or fall back to the default tag
+	if defaultTag := GetDefaultTag(field); defaultTag != "" {
+		return fmt.Sprintf(`if c, err := r.Cookie("%s"); err == nil {
+		%s
+	} else {
+		%s
+	}`, cookieName, code, GenerateDefaultValue(fieldName, defaultTag, typeName)), imports
+	}
+
+	return fmt.Sprintf(`if c, err := r.Cookie("%s"); err == nil {
+		%s
+	}`, cookieName, code), imports
 }
@@ -0,0 +1,154 @@
+package extractors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/handler/parser"
+)
+
+func TestCookieExtractor_Name(t *testing.T) {
+	e := &CookieExtractor{}
+	if e.Name() != "cookie" {
+		t.Errorf("expected name 'cookie', got %q", e.Name())
+	}
+}
+
+func TestCookieExtractor_Priority(t *testing.T) {
+	e := &CookieExtractor{}
+	path := &PathExtractor{}
+	query := &QueryExtractor{}
+	if e.Priority() <= path.Priority() || e.Priority() >= query.Priority() {
+		t.Errorf("expected priority between path (%d) and query (%d), got %d",
+			path.Priority(), query.Priority(), e.Priority())
+	}
+}
+
+func TestCookieExtractor_CanExtract(t *testing.T) {
+	e := &CookieExtractor{}
+
+	tests := []struct {
+		name     string
+		field    *parser.Field
+		expected bool
+	}{
+		{
+			name:     "with cookie tag",
+			field:    &parser.Field{StructTag: `cookie:"session-id"`},
+			expected: true,
+		},
+		{
+			name:     "with in:cookie comment",
+			field:    &parser.Field{InComment: "cookie"},
+			expected: true,
+		},
+		{
+			name:     "without cookie tag or comment",
+			field:    &parser.Field{StructTag: `json:"session"`},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := e.CanExtract(tt.field)
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCookieExtractor_GenerateCode(t *testing.T) {
+	e := &CookieExtractor{}
+
+	tests := []struct {
+		name              string
+		field             *parser.Field
+		expectedInCode    []string
+		notExpectedInCode []string
+	}{
+		{
+			name: "string field",
+			field: &parser.Field{
+				Name:      "SessionID",
+				Type:      "string",
+				StructTag: `cookie:"session-id"`,
+			},
+			expectedInCode: []string{
+				`if c, err := r.Cookie("session-id"); err == nil {`,
+				"payload.SessionID = val",
+				"c.Value",
+			},
+			notExpectedInCode: []string{
+				"return",
+			},
+		},
+		{
+			name: "int field",
+			field: &parser.Field{
+				Name:      "Visits",
+				Type:      "int",
+				StructTag: `cookie:"visits"`,
+			},
+			expectedInCode: []string{
+				`r.Cookie("visits")`,
+				"strconv.ParseInt",
+				"payload.Visits",
+			},
+		},
+		{
+			name: "field with comment name",
+			field: &parser.Field{
+				Name:          "SessionID",
+				Type:          "string",
+				InComment:     "cookie",
+				InCommentName: "session-id",
+			},
+			expectedInCode: []string{
+				`r.Cookie("session-id")`,
+			},
+		},
+		{
+			name: "field without name falls back to camelCase",
+			field: &parser.Field{
+				Name:      "Theme",
+				Type:      "string",
+				InComment: "cookie",
+			},
+			expectedInCode: []string{
+				`r.Cookie("theme")`,
+			},
+		},
+		{
+			name: "field with default tag",
+			field: &parser.Field{
+				Name:      "Theme",
+				Type:      "string",
+				StructTag: `cookie:"theme" default:"light"`,
+			},
+			expectedInCode: []string{
+				`r.Cookie("theme")`,
+				"} else {",
+				`payload.Theme = "light"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := e.GenerateCode(tt.field, "Request")
+
+			for _, expected := range tt.expectedInCode {
+				if !strings.Contains(code, expected) {
+					t.Errorf("expected code to contain %q, got:\n%s", expected, code)
+				}
+			}
+			for _, notExpected := range tt.notExpectedInCode {
+				if strings.Contains(code, notExpected) {
+					t.Errorf("expected code not to contain %q, got:\n%s", notExpected, code)
+				}
+			}
+		})
+	}
+}
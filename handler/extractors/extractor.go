@@ -28,6 +28,13 @@ type Extractor interface {
 	Priority() int
 }
 
+// FieldValidator is implemented by extractors that reject some of the fields they match
+// The generator skips a rejected field and reports the error as a warning
+type FieldValidator interface {
+	// ValidateField returns an error describing why the field cannot be extracted
+	ValidateField(field *parser.Field) error
+}
+
 // Registry holds all registered extractors
 type Registry struct {
 	extractors []Extractor
@@ -110,20 +117,20 @@ func GenerateExtractionCode(varName, fieldName, typeName string, field *parser.F
 
 // GenerateIntParsing generates code to parse an integer from a string
 func GenerateIntParsing(varName, fieldName, typeName string) string {
-	return fmt.Sprintf(`if i, err := strconv.ParseInt(%s, 10, 64); err == nil {
+	return fmt.Sprintf(`if i, err := strconv.ParseInt(%s, 10, %d); err == nil {
 		payload.%s = %s(i)
 	} else {
 		return fmt.Errorf("invalid %s: %%w", err)
-	}`, varName, fieldName, typeName, fieldName)
+	}`, varName, types.IntBitSize(typeName), fieldName, typeName, fieldName)
 }
 
 // GenerateUintParsing generates code to parse an unsigned integer from a string
 func GenerateUintParsing(varName, fieldName, typeName string) string {
-	return fmt.Sprintf(`if i, err := strconv.ParseUint(%s, 10, 64); err == nil {
+	return fmt.Sprintf(`if i, err := strconv.ParseUint(%s, 10, %d); err == nil {
 		payload.%s = %s(i)
 	} else {
 		return fmt.Errorf("invalid %s: %%w", err)
-	}`, varName, fieldName, typeName, fieldName)
+	}`, varName, types.IntBitSize(typeName), fieldName, typeName, fieldName)
 }
 
 // GenerateFloatParsing generates code to parse a float from a string
@@ -170,6 +177,9 @@ func GetParameterName(field *parser.Field, tagName string) string {
 	if field.StructTag != "" {
 		tag := reflect.StructTag(field.StructTag)
 		if val, ok := tag.Lookup(tagName); ok {
+			// Options like "style=pipeDelimited" follow the name
+			val, _, _ = strings.Cut(val, ",")
+
 			// If tag exists but is empty, fall through to use field name
 			if val != "" {
 				return val
@@ -193,6 +203,14 @@ func GenerateCodeByType(varName, fieldName, typeName string, field *parser.Field
 	var imports []string
 	var code string
 
+	// Pointer fields are assigned a new value; defaults do not apply to them
+	if field.IsPointer && !field.IsEmbedded {
+		parsingCode, imports := GeneratePointerParsing("val", fieldName, typeName)
+		return fmt.Sprintf(`if val := %s; val != "" {
+		%s
+	}`, varName, parsingCode), imports
+	}
+
 	switch {
 	case IsStringType(typeName):
 		code, imports = GenerateExtractionCode(varName, fieldName, typeName, field, nil, imports)
@@ -251,6 +269,28 @@ func GenerateCodeByType(varName, fieldName, typeName string, field *parser.Field
 	return code, imports
 }
 
+// GeneratePointerParsing generates code parsing a string into a new value assigned to a pointer field
+// Builtin and registered types use their types.Extractor; other types are converted from the string
+// Example: *int -> val := int(i); payload.Limit = &val
+// Returns: (code, imports)
+func GeneratePointerParsing(varName, fieldName, typeName string) (string, []string) {
+	typeExtractor, ok := types.Get(typeName)
+	if !ok {
+		// Unknown custom types (e.g., model.Status) are string-based
+		return fmt.Sprintf(`val := %s(%s)
+		payload.%s = &val`, typeName, varName, fieldName), nil
+	}
+
+	var imports []string
+	if IsIntType(typeName) || IsUintType(typeName) || IsFloatType(typeName) || IsBoolType(typeName) {
+		imports = append(imports, "strconv")
+	}
+	if typeExtractor.Import != "" {
+		imports = append(imports, typeExtractor.Import)
+	}
+	return typeExtractor.ParseFunc(varName, fieldName, true), imports
+}
+
 // GenerateSliceCodeByType generates code to parse a slice of values
 // This handles the standard HTTP pattern: ?tags=go&tags=api&tags=http
 // Returns: (code, imports)
@@ -271,13 +311,13 @@ func GenerateSliceCodeByType(varName, fieldName, elementType string, field *pars
 		code = fmt.Sprintf(`if vals := %s; len(vals) > 0 {
 		payload.%s = make([]%s, 0, len(vals))
 		for i, val := range vals {
-			if parsed, err := strconv.ParseInt(val, 10, 64); err == nil {
+			if parsed, err := strconv.ParseInt(val, 10, %d); err == nil {
 				payload.%s = append(payload.%s, %s(parsed))
 			} else {
 				return fmt.Errorf("invalid %s[%%d]: %%w", i, err)
 			}
 		}
-	}`, varName, fieldName, elementType, fieldName, fieldName, elementType, fieldName)
+	}`, varName, fieldName, elementType, types.IntBitSize(elementType), fieldName, fieldName, elementType, fieldName)
 
 	case IsUintType(elementType):
 		// For []uint, []uint64, etc. - parse each element
@@ -285,13 +325,13 @@ func GenerateSliceCodeByType(varName, fieldName, elementType string, field *pars
 		code = fmt.Sprintf(`if vals := %s; len(vals) > 0 {
 		payload.%s = make([]%s, 0, len(vals))
 		for i, val := range vals {
-			if parsed, err := strconv.ParseUint(val, 10, 64); err == nil {
+			if parsed, err := strconv.ParseUint(val, 10, %d); err == nil {
 				payload.%s = append(payload.%s, %s(parsed))
 			} else {
 				return fmt.Errorf("invalid %s[%%d]: %%w", i, err)
 			}
 		}
-	}`, varName, fieldName, elementType, fieldName, fieldName, elementType, fieldName)
+	}`, varName, fieldName, elementType, types.IntBitSize(elementType), fieldName, fieldName, elementType, fieldName)
 
 	case IsFloatType(elementType):
 		// For []float32, []float64 - parse each element
@@ -359,6 +399,56 @@ func GenerateSliceCodeByType(varName, fieldName, elementType string, field *pars
 	return code, imports
 }
 
+// GenerateEnumCheck generates code rejecting values outside the field's enum annotation
+// Only string-based fields are checked; empty values are left to validation
+// Returns: (code, imports), or empty code when there's nothing to check
+func GenerateEnumCheck(field *parser.Field, fieldName string) (string, []string) {
+	typeName := GetBaseType(field)
+	if len(field.Enum) == 0 || !isStringBased(typeName) {
+		return "", nil
+	}
+
+	quoted := make([]string, len(field.Enum))
+	for i, v := range field.Enum {
+		quoted[i] = strconv.Quote(v)
+	}
+	allowed := fmt.Sprintf("[]string{%s}", strings.Join(quoted, ", "))
+	message := strconv.Quote(fmt.Sprintf("invalid %s: must be one of %s", fieldName, strings.Join(field.Enum, ", ")))
+	imports := []string{"slices"}
+
+	if field.IsSlice {
+		return fmt.Sprintf(`for _, val := range payload.%s {
+		if !slices.Contains(%s, string(val)) {
+			return fmt.Errorf(%s)
+		}
+	}`, fieldName, allowed, message), imports
+	}
+
+	value := "payload." + fieldName
+	if field.IsPointer {
+		return fmt.Sprintf(`if %s != nil && !slices.Contains(%s, string(*%s)) {
+		return fmt.Errorf(%s)
+	}`, value, allowed, value, message), imports
+	}
+
+	return fmt.Sprintf(`if %s != "" && !slices.Contains(%s, string(%s)) {
+		return fmt.Errorf(%s)
+	}`, value, allowed, value, message), imports
+}
+
+// isStringBased reports whether values of the type are assigned from the raw string:
+// string itself or a custom type without a registered parser (e.g., model.Status)
+func isStringBased(typeName string) bool {
+	if IsStringType(typeName) {
+		return true
+	}
+	if IsIntType(typeName) || IsUintType(typeName) || IsFloatType(typeName) || IsBoolType(typeName) {
+		return false
+	}
+	_, registered := types.Get(typeName)
+	return !registered
+}
+
 // GenerateDefaultValue generates code to set a default value
 func GenerateDefaultValue(fieldName, defaultValue, typeName string) string {
 	switch {
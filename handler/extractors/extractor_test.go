@@ -1,6 +1,7 @@
 package extractors
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -219,6 +220,28 @@ func TestGenerateIntParsing(t *testing.T) {
 	}
 }
 
+func TestGenerateIntParsing_BitSize(t *testing.T) {
+	tests := []struct {
+		typeName string
+		want     string
+	}{
+		{typeName: "int8", want: "strconv.ParseInt(value, 10, 8)"},
+		{typeName: "int16", want: "strconv.ParseInt(value, 10, 16)"},
+		{typeName: "int32", want: "strconv.ParseInt(value, 10, 32)"},
+		{typeName: "int", want: "strconv.ParseInt(value, 10, 0)"},
+	}
+
+	for _, tt := range tests {
+		if code := GenerateIntParsing("value", "N", tt.typeName); !strings.Contains(code, tt.want) {
+			t.Errorf("expected %q for %s, got:\n%s", tt.want, tt.typeName, code)
+		}
+	}
+
+	if code := GenerateUintParsing("value", "N", "uint16"); !strings.Contains(code, "strconv.ParseUint(value, 10, 16)") {
+		t.Errorf("expected 16-bit ParseUint for uint16, got:\n%s", code)
+	}
+}
+
 func TestGenerateUintParsing(t *testing.T) {
 	code := GenerateUintParsing("value", "Count", "uint32")
 
@@ -345,3 +368,62 @@ func (m *mockExtractor) CanExtract(field *parser.Field) bool { return m.canExtra
 func (m *mockExtractor) GenerateCode(field *parser.Field, structName string) (string, []string) {
 	return "mock code", []string{}
 }
+
+func TestGenerateEnumCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		field *parser.Field
+		want  string
+	}{
+		{
+			name:  "string",
+			field: &parser.Field{Name: "Status", Type: "string", Enum: []string{"available", "sold"}},
+			want:  `if payload.Status != "" && !slices.Contains([]string{"available", "sold"}, string(payload.Status)) {`,
+		},
+		{
+			name:  "pointer",
+			field: &parser.Field{Name: "Status", Type: "*string", IsPointer: true, Enum: []string{"available", "sold"}},
+			want:  `if payload.Status != nil && !slices.Contains([]string{"available", "sold"}, string(*payload.Status)) {`,
+		},
+		{
+			name:  "slice",
+			field: &parser.Field{Name: "Tags", Type: "[]string", IsSlice: true, SliceType: "string", Enum: []string{"cat"}},
+			want:  `for _, val := range payload.Tags {`,
+		},
+		{
+			name:  "custom string type",
+			field: &parser.Field{Name: "Status", Type: "model.Status", Enum: []string{"available"}},
+			want:  `string(payload.Status)`,
+		},
+		{
+			name:  "int is not checked",
+			field: &parser.Field{Name: "Limit", Type: "int", Enum: []string{"10", "20"}},
+		},
+		{
+			name:  "no enum",
+			field: &parser.Field{Name: "Status", Type: "string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, imports := GenerateEnumCheck(tt.field, tt.field.Name)
+			if tt.want == "" {
+				if code != "" {
+					t.Errorf("expected no check, got:\n%s", code)
+				}
+				return
+			}
+
+			if !strings.Contains(code, tt.want) {
+				t.Errorf("expected code to contain %q, got:\n%s", tt.want, code)
+			}
+			if !strings.Contains(code, `return fmt.Errorf("invalid `+tt.field.Name+`: must be one of `) {
+				t.Errorf("expected error listing the allowed values, got:\n%s", code)
+			}
+			if !slices.Contains(imports, "slices") {
+				t.Errorf("expected slices import, got %v", imports)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package extractors
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/reation-io/apikit/handler/parser"
+)
+
+func init() {
+	Register(&FileExtractor{})
+}
+
+// FileExtractor extracts uploaded files from multipart/form-data requests
+// Fields typed multipart.File receive the opened upload for streaming,
+// fields typed *multipart.FileHeader receive its metadata
+type FileExtractor struct{}
+
+func (e *FileExtractor) Name() string {
+	return "file"
+}
+
+func (e *FileExtractor) Priority() int {
+	return 38 // Extract files before body fields, which would otherwise claim json-tagged fields
+}
+
+func (e *FileExtractor) CanExtract(field *parser.Field) bool {
+	return field.IsFile
+}
+
+// ValidateField rejects a maxfilesize tag the parser could not read as a size
+// Example: `maxfilesize:"5 apples"`
+func (e *FileExtractor) ValidateField(field *parser.Field) error {
+	if value, ok := reflect.StructTag(field.StructTag).Lookup("maxfilesize"); ok && field.MaxFileSize == 0 {
+		return fmt.Errorf("maxfilesize %q of field %s is not a valid size", value, field.Name)
+	}
+	return nil
+}
+
+func (e *FileExtractor) GenerateCode(field *parser.Field, structName string) (string, []string) {
+	formName := GetParameterName(field, "form")
+	imports := []string{"errors"}
+
+	// Uploads over maxfilesize are answered with 413, other media types than accept with 415
+	// Example: `maxfilesize:"5MB"` -> if header.Size > 5242880 { return apikit.RequestEntityTooLarge(...) }
+	var checks []string
+	closeFile := ""
+	if field.Type == "multipart.File" {
+		closeFile = "file.Close()\n"
+	}
+	if field.MaxFileSize > 0 {
+		checks = append(checks, fmt.Sprintf(`if header.Size > %d {
+		%sreturn apikit.RequestEntityTooLarge("file %s exceeds %d bytes")
+	}`, field.MaxFileSize, closeFile, formName, field.MaxFileSize))
+	}
+	if len(field.Accept) > 0 {
+		checks = append(checks, fmt.Sprintf(`if mediaType, _, _ := mime.ParseMediaType(header.Header.Get("Content-Type")); !slices.Contains(%#v, mediaType) {
+		%sreturn apikit.UnsupportedMediaType(fmt.Sprintf("file %s has content type %%q, expected %s", mediaType))
+	}`, field.Accept, closeFile, formName, strings.Join(field.Accept, ", ")))
+		imports = append(imports, "mime", "slices")
+	}
+	headerVar := "header"
+	if len(checks) == 0 && field.Type == "multipart.File" {
+		headerVar = "_"
+	}
+
+	// A missing file is not an error here: required uploads are enforced
+	// by the handler or the validator, like other optional parameters
+	if field.Type == "*multipart.FileHeader" {
+		body := append(append([]string{"file.Close()"}, checks...), fmt.Sprintf("payload.%s = header", field.Name))
+		return fmt.Sprintf(`if file, header, err := r.FormFile("%s"); err == nil {
+		%s
+	} else if !errors.Is(err, http.ErrMissingFile) {
+		return fmt.Errorf("reading file %s: %%w", err)
+	}`, formName, strings.Join(body, "\n"), formName), imports
+	}
+
+	body := append(checks, fmt.Sprintf("payload.%s = file", field.Name))
+	return fmt.Sprintf(`// The handler owns the opened upload and must close it
+	if file, %s, err := r.FormFile("%s"); err == nil {
+		%s
+	} else if !errors.Is(err, http.ErrMissingFile) {
+		return fmt.Errorf("reading file %s: %%w", err)
+	}`, headerVar, formName, strings.Join(body, "\n"), formName), imports
+}
@@ -0,0 +1,108 @@
+package extractors
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/handler/parser"
+)
+
+func TestFileExtractor_Priority(t *testing.T) {
+	e := &FileExtractor{}
+	body := &BodyExtractor{}
+	if e.Priority() >= body.Priority() {
+		t.Errorf("expected priority before body (%d), got %d", body.Priority(), e.Priority())
+	}
+}
+
+func TestFileExtractor_CanExtract(t *testing.T) {
+	e := &FileExtractor{}
+
+	if !e.CanExtract(&parser.Field{Type: "multipart.File", IsFile: true, StructTag: `json:"avatar"`}) {
+		t.Error("expected file field to be extracted")
+	}
+	if e.CanExtract(&parser.Field{Type: "string", StructTag: `form:"avatar"`}) {
+		t.Error("expected non-file field to be skipped")
+	}
+	if (&BodyExtractor{}).CanExtract(&parser.Field{Type: "multipart.File", IsFile: true, StructTag: `json:"avatar"`}) {
+		t.Error("expected body extractor to skip file fields")
+	}
+}
+
+func TestFileExtractor_ValidateField(t *testing.T) {
+	e := &FileExtractor{}
+
+	valid := &parser.Field{Name: "Avatar", IsFile: true, StructTag: `maxfilesize:"5MB"`, MaxFileSize: 5 << 20}
+	if err := e.ValidateField(valid); err != nil {
+		t.Errorf("expected valid size to pass, got %v", err)
+	}
+
+	invalid := &parser.Field{Name: "Avatar", IsFile: true, StructTag: `maxfilesize:"lots"`}
+	if err := e.ValidateField(invalid); err == nil || !strings.Contains(err.Error(), `maxfilesize "lots" of field Avatar`) {
+		t.Errorf("expected invalid size error, got %v", err)
+	}
+}
+
+func TestFileExtractor_GenerateCode(t *testing.T) {
+	e := &FileExtractor{}
+
+	tests := []struct {
+		name     string
+		field    *parser.Field
+		contains []string
+		excludes []string
+	}{
+		{
+			name:  "streamed file",
+			field: &parser.Field{Name: "Avatar", Type: "multipart.File", IsFile: true},
+			contains: []string{
+				"The handler owns the opened upload and must close it",
+				`r.FormFile("avatar")`,
+				"payload.Avatar = file",
+				"http.ErrMissingFile",
+			},
+			excludes: []string{"file.Close()"},
+		},
+		{
+			name: "streamed file with limits",
+			field: &parser.Field{Name: "Avatar", Type: "multipart.File", IsFile: true,
+				MaxFileSize: 1024, Accept: []string{"image/png"}},
+			contains: []string{
+				"if header.Size > 1024",
+				`apikit.RequestEntityTooLarge("file avatar exceeds 1024 bytes")`,
+				`!slices.Contains([]string{"image/png"}, mediaType)`,
+				"apikit.UnsupportedMediaType",
+				"file.Close()",
+			},
+		},
+		{
+			name:  "file header with form tag",
+			field: &parser.Field{Name: "Document", Type: "*multipart.FileHeader", IsPointer: true, IsFile: true, StructTag: `form:"cv"`},
+			contains: []string{
+				`r.FormFile("cv")`,
+				"file.Close()",
+				"payload.Document = header",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, imports := e.GenerateCode(tt.field, "UploadRequest")
+			for _, want := range tt.contains {
+				if !strings.Contains(code, want) {
+					t.Errorf("expected %q in code:\n%s", want, code)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(code, unwanted) {
+					t.Errorf("expected no %q in code:\n%s", unwanted, code)
+				}
+			}
+			if !slices.Contains(imports, "errors") {
+				t.Errorf("expected errors import, got %v", imports)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package extractors
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
 
 	"github.com/reation-io/apikit/handler/parser"
 )
@@ -34,8 +36,20 @@ func (e *HeaderExtractor) CanExtract(field *parser.Field) bool {
 	return field.InComment == "header"
 }
 
-func (e *HeaderExtractor) GenerateCode(field *parser.Field, structName string) (string, []string) {
+// ValidateField rejects header names that are not valid HTTP header names
+// Example: "// in:header 'X-Custom Header'" would never match a request header
+func (e *HeaderExtractor) ValidateField(field *parser.Field) error {
 	headerName := GetParameterName(field, "header")
+	if strings.ContainsAny(headerName, " \t") {
+		return fmt.Errorf("header name %q of field %s contains whitespace", headerName, field.Name)
+	}
+	return nil
+}
+
+func (e *HeaderExtractor) GenerateCode(field *parser.Field, structName string) (string, []string) {
+	// Headers are stored in canonical form, which r.Header[...] lookups require
+	// Example: x-request-id -> X-Request-Id
+	headerName := http.CanonicalHeaderKey(GetParameterName(field, "header"))
 	fieldName := field.Name
 	typeName := GetBaseType(field)
 
@@ -0,0 +1,59 @@
+package extractors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/handler/parser"
+)
+
+func TestHeaderExtractor_GenerateCode_Canonical(t *testing.T) {
+	e := &HeaderExtractor{}
+
+	tests := []struct {
+		name  string
+		field *parser.Field
+		want  string
+	}{
+		{
+			name:  "canonical name",
+			field: &parser.Field{Name: "RequestID", Type: "string", StructTag: `header:"X-Request-Id"`},
+			want:  `r.Header.Get("X-Request-Id")`,
+		},
+		{
+			name:  "lowercase comment name",
+			field: &parser.Field{Name: "RequestID", Type: "string", InComment: "header", InCommentName: "x-request-id"},
+			want:  `r.Header.Get("X-Request-Id")`,
+		},
+		{
+			name:  "slice",
+			field: &parser.Field{Name: "Tags", Type: "[]string", IsSlice: true, SliceType: "string", StructTag: `header:"x-tags"`},
+			want:  `r.Header["X-Tags"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := e.ValidateField(tt.field); err != nil {
+				t.Fatalf("ValidateField() error = %v", err)
+			}
+			code, _ := e.GenerateCode(tt.field, "Request")
+			if !strings.Contains(code, tt.want) {
+				t.Errorf("expected %s in code, got:\n%s", tt.want, code)
+			}
+		})
+	}
+}
+
+func TestHeaderExtractor_ValidateField_Whitespace(t *testing.T) {
+	e := &HeaderExtractor{}
+	field := &parser.Field{Name: "Custom", Type: "string", InComment: "header", InCommentName: "X-Custom Header"}
+
+	err := e.ValidateField(field)
+	if err == nil {
+		t.Fatal("expected an error for a header name with a space")
+	}
+	if !strings.Contains(err.Error(), `"X-Custom Header"`) {
+		t.Errorf("expected the header name in the error, got %q", err.Error())
+	}
+}
@@ -0,0 +1,73 @@
+package extractors
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/reation-io/apikit/handler/parser"
+)
+
+func init() {
+	Register(&MatrixExtractor{})
+}
+
+// MatrixExtractor extracts matrix parameters from URL path segments
+// Example: /users;role=admin;active=true → role="admin", active="true"
+type MatrixExtractor struct{}
+
+func (e *MatrixExtractor) Name() string {
+	return "matrix"
+}
+
+func (e *MatrixExtractor) Priority() int {
+	return 11 // Extract matrix params right after path params
+}
+
+func (e *MatrixExtractor) CanExtract(field *parser.Field) bool {
+	// Check if field has matrix tag
+	if field.StructTag != "" {
+		tag := reflect.StructTag(field.StructTag)
+		if _, ok := tag.Lookup("matrix"); ok {
+			return true
+		}
+	}
+	// Check if field is marked with // in:matrix comment
+	return field.InComment == "matrix"
+}
+
+func (e *MatrixExtractor) GenerateCode(field *parser.Field, structName string) (string, []string) {
+	paramName := GetParameterName(field, "matrix")
+	fieldName := field.Name
+	typeName := GetBaseType(field)
+
+	// For slices, collect every value (repeated params and comma-separated lists)
+	// Example: /cars;color=red,blue;color=green → []string{"red", "blue", "green"}
+	if field.IsSlice {
+		code, imports := GenerateSliceCodeByType("matrixVals", fieldName, field.SliceType, field)
+		return fmt.Sprintf(`{
+		var matrixVals []string
+		for _, segment := range strings.Split(r.URL.Path, "/") {
+			for _, param := range strings.Split(segment, ";")[1:] {
+				if key, value, ok := strings.Cut(param, "="); ok && key == "%s" {
+					matrixVals = append(matrixVals, strings.Split(value, ",")...)
+				}
+			}
+		}
+		%s
+	}`, paramName, code), append(imports, "strings")
+	}
+
+	// For single values, the last occurrence wins
+	code, imports := GenerateCodeByType("matrixVal", fieldName, typeName, field)
+	return fmt.Sprintf(`{
+		var matrixVal string
+		for _, segment := range strings.Split(r.URL.Path, "/") {
+			for _, param := range strings.Split(segment, ";")[1:] {
+				if key, value, ok := strings.Cut(param, "="); ok && key == "%s" {
+					matrixVal = value
+				}
+			}
+		}
+		%s
+	}`, paramName, code), append(imports, "strings")
+}
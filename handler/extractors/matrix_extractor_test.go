@@ -0,0 +1,136 @@
+package extractors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/handler/parser"
+)
+
+func TestMatrixExtractor_Name(t *testing.T) {
+	e := &MatrixExtractor{}
+	if e.Name() != "matrix" {
+		t.Errorf("expected name 'matrix', got %q", e.Name())
+	}
+}
+
+func TestMatrixExtractor_Priority(t *testing.T) {
+	e := &MatrixExtractor{}
+	path := &PathExtractor{}
+	if e.Priority() != path.Priority()+1 {
+		t.Errorf("expected priority just after path (%d), got %d", path.Priority(), e.Priority())
+	}
+}
+
+func TestMatrixExtractor_CanExtract(t *testing.T) {
+	e := &MatrixExtractor{}
+
+	tests := []struct {
+		name     string
+		field    *parser.Field
+		expected bool
+	}{
+		{
+			name:     "with matrix tag",
+			field:    &parser.Field{StructTag: `matrix:"role"`},
+			expected: true,
+		},
+		{
+			name:     "with in:matrix comment",
+			field:    &parser.Field{InComment: "matrix"},
+			expected: true,
+		},
+		{
+			name:     "without matrix tag or comment",
+			field:    &parser.Field{StructTag: `json:"role"`},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := e.CanExtract(tt.field)
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMatrixExtractor_GenerateCode(t *testing.T) {
+	e := &MatrixExtractor{}
+
+	tests := []struct {
+		name           string
+		field          *parser.Field
+		expectedInCode []string
+	}{
+		{
+			name: "string field",
+			field: &parser.Field{
+				Name:      "Role",
+				Type:      "string",
+				StructTag: `matrix:"role"`,
+			},
+			expectedInCode: []string{
+				`strings.Split(r.URL.Path, "/")`,
+				`strings.Split(segment, ";")[1:]`,
+				`strings.Cut(param, "=")`,
+				`key == "role"`,
+				"payload.Role = val",
+			},
+		},
+		{
+			name: "bool field with comment name",
+			field: &parser.Field{
+				Name:          "Active",
+				Type:          "bool",
+				InComment:     "matrix",
+				InCommentName: "active",
+			},
+			expectedInCode: []string{
+				`key == "active"`,
+				"strconv.ParseBool",
+				"payload.Active",
+			},
+		},
+		{
+			name: "slice field",
+			field: &parser.Field{
+				Name:      "Colors",
+				Type:      "[]string",
+				IsSlice:   true,
+				SliceType: "string",
+				StructTag: `matrix:"color"`,
+			},
+			expectedInCode: []string{
+				`key == "color"`,
+				`strings.Split(value, ",")`,
+				"payload.Colors = vals",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, imports := e.GenerateCode(tt.field, "Request")
+
+			for _, expected := range tt.expectedInCode {
+				if !strings.Contains(code, expected) {
+					t.Errorf("expected code to contain %q, got:\n%s", expected, code)
+				}
+			}
+
+			found := false
+			for _, imp := range imports {
+				if imp == "strings" {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("expected strings import, got %v", imports)
+			}
+		})
+	}
+}
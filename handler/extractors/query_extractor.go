@@ -43,12 +43,44 @@ func (e *QueryExtractor) GenerateCode(field *parser.Field, structName string) (s
 	// Example: ?tags=go&tags=api&tags=http → []string{"go", "api", "http"}
 	if field.IsSlice {
 		varName := fmt.Sprintf(`r.URL.Query()["%s"]`, paramName)
+
+		// Delimited values pack the slice into one parameter, split each value
+		// Example: delimiter:",", ?tags=go,api,http → []string{"go", "api", "http"}
+		if delimiter := sliceDelimiter(field); delimiter != "" {
+			varName = fmt.Sprintf(`apikit.SplitQueryValues(r.URL.Query()["%s"], %q)`, paramName, delimiter)
+		}
 		return GenerateSliceCodeByType(varName, fieldName, field.SliceType, field)
 	}
 
+	// Pointer fields are set whenever the parameter is present, even when empty,
+	// so handlers can tell "?name=" apart from an absent parameter (PATCH semantics)
+	if field.IsPointer && !field.IsEmbedded {
+		parsingCode, imports := GeneratePointerParsing("value", fieldName, typeName)
+		return fmt.Sprintf(`if query := r.URL.Query(); query.Has("%s") {
+		value := query.Get("%s")
+		%s
+	}`, paramName, paramName, parsingCode), imports
+	}
+
 	// For single values, use .Get()
 	varName := fmt.Sprintf(`r.URL.Query().Get("%s")`, paramName)
 
 	// Use the public helper to generate code based on type
 	return GenerateCodeByType(varName, fieldName, typeName, field)
 }
+
+// sliceDelimiter returns the separator from the field's delimiter tag or delimited query style
+// Returns empty string by default, where the parameter is repeated for each value
+func sliceDelimiter(field *parser.Field) string {
+	if delimiter := reflect.StructTag(field.StructTag).Get("delimiter"); delimiter != "" {
+		return delimiter
+	}
+
+	switch field.Style {
+	case "pipeDelimited":
+		return "|"
+	case "spaceDelimited":
+		return " "
+	}
+	return ""
+}
@@ -1,6 +1,12 @@
 package extractors
 
 import (
+	"go/ast"
+	"go/importer"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"slices"
 	"strings"
 	"testing"
 
@@ -79,6 +85,62 @@ func TestQueryExtractor_GenerateCode_SingleValue(t *testing.T) {
 	}
 }
 
+func TestQueryExtractor_GenerateCode_PointerPresence(t *testing.T) {
+	e := &QueryExtractor{}
+
+	tests := []struct {
+		name       string
+		field      *parser.Field
+		want       []string
+		wantNot    []string
+		wantImport string
+	}{
+		{
+			name:    "*string uses Has",
+			field:   &parser.Field{Name: "Name", Type: "*string", IsPointer: true, StructTag: `query:"name"`},
+			want:    []string{`query.Has("name")`, `value := query.Get("name")`, "payload.Name = &val"},
+			wantNot: []string{`!= ""`},
+		},
+		{
+			name:       "*int uses Has",
+			field:      &parser.Field{Name: "Limit", Type: "*int", IsPointer: true, StructTag: `query:"limit"`},
+			want:       []string{`query.Has("limit")`, "strconv.ParseInt(value, 10, 0)", "payload.Limit = &val"},
+			wantImport: "strconv",
+		},
+		{
+			name:  "*custom type uses Has",
+			field: &parser.Field{Name: "Status", Type: "*model.Status", IsPointer: true, StructTag: `query:"status"`},
+			want:  []string{`query.Has("status")`, "val := model.Status(value)", "payload.Status = &val"},
+		},
+		{
+			name:    "string uses emptiness",
+			field:   &parser.Field{Name: "Name", Type: "string", StructTag: `query:"name"`},
+			want:    []string{`if val := r.URL.Query().Get("name"); val != ""`},
+			wantNot: []string{"Has("},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, imports := e.GenerateCode(tt.field, "Request")
+
+			for _, want := range tt.want {
+				if !strings.Contains(code, want) {
+					t.Errorf("expected code to contain %q, got:\n%s", want, code)
+				}
+			}
+			for _, notWant := range tt.wantNot {
+				if strings.Contains(code, notWant) {
+					t.Errorf("expected code to NOT contain %q, got:\n%s", notWant, code)
+				}
+			}
+			if tt.wantImport != "" && !slices.Contains(imports, tt.wantImport) {
+				t.Errorf("expected import %q, got %v", tt.wantImport, imports)
+			}
+		})
+	}
+}
+
 func TestQueryExtractor_GenerateCode_Slice(t *testing.T) {
 	e := &QueryExtractor{}
 
@@ -134,3 +196,177 @@ func TestQueryExtractor_GenerateCode_IntSlice(t *testing.T) {
 		t.Error("expected strconv import for int slice")
 	}
 }
+
+func TestQueryExtractor_GenerateCode_DelimitedStyle(t *testing.T) {
+	e := &QueryExtractor{}
+
+	tests := []struct {
+		name    string
+		field   *parser.Field
+		want    string
+		wantNot string
+	}{
+		{
+			name:  "pipeDelimited",
+			field: &parser.Field{Name: "Tags", Type: "[]string", IsSlice: true, SliceType: "string", StructTag: `query:"tags,style=pipeDelimited"`, Style: "pipeDelimited"},
+			want:  `apikit.SplitQueryValues(r.URL.Query()["tags"], "|")`,
+		},
+		{
+			name:  "spaceDelimited int slice",
+			field: &parser.Field{Name: "IDs", Type: "[]int", IsSlice: true, SliceType: "int", InComment: "query", InCommentName: "ids", Style: "spaceDelimited"},
+			want:  `apikit.SplitQueryValues(r.URL.Query()["ids"], " ")`,
+		},
+		{
+			name:    "form style repeats the parameter",
+			field:   &parser.Field{Name: "Tags", Type: "[]string", IsSlice: true, SliceType: "string", StructTag: `query:"tags,style=form"`, Style: "form"},
+			want:    `r.URL.Query()["tags"]`,
+			wantNot: "SplitQueryValues",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := e.GenerateCode(tt.field, "Request")
+			if !strings.Contains(code, tt.want) {
+				t.Errorf("expected code to contain %q, got:\n%s", tt.want, code)
+			}
+			if tt.wantNot != "" && strings.Contains(code, tt.wantNot) {
+				t.Errorf("expected code not to contain %q, got:\n%s", tt.wantNot, code)
+			}
+		})
+	}
+}
+
+func TestQueryExtractor_GenerateCode_Delimiter(t *testing.T) {
+	e := &QueryExtractor{}
+
+	field := &parser.Field{
+		Name:      "IDs",
+		Type:      "[]int",
+		IsSlice:   true,
+		SliceType: "int",
+		StructTag: `query:"ids" delimiter:","`,
+	}
+
+	code, imports := e.GenerateCode(field, "Request")
+
+	expectedParts := []string{
+		`vals := apikit.SplitQueryValues(r.URL.Query()["ids"], ",")`,
+		"strconv.ParseInt(val, 10, 0)",
+		"payload.IDs = append(payload.IDs, int(parsed))",
+	}
+	for _, expected := range expectedParts {
+		if !strings.Contains(code, expected) {
+			t.Errorf("expected code to contain %q, got:\n%s", expected, code)
+		}
+	}
+	if !slices.Contains(imports, "strconv") {
+		t.Errorf("expected strconv import, got %v", imports)
+	}
+
+	// Without a delimiter the parameter is repeated
+	field.StructTag = `query:"ids"`
+	code, _ = e.GenerateCode(field, "Request")
+	if strings.Contains(code, "SplitQueryValues") {
+		t.Errorf("expected repeated keys without a delimiter, got:\n%s", code)
+	}
+}
+
+func TestQueryExtractor_GenerateCode_RawMessage(t *testing.T) {
+	e := &QueryExtractor{}
+
+	field := &parser.Field{
+		Name:      "Filter",
+		Type:      "json.RawMessage",
+		StructTag: `query:"filter"`,
+	}
+
+	code, imports := e.GenerateCode(field, "Request")
+
+	if !strings.Contains(code, "payload.Filter = json.RawMessage(val)") {
+		t.Errorf("expected raw message assignment, got:\n%s", code)
+	}
+	for _, imp := range imports {
+		if imp == "strconv" {
+			t.Error("expected no strconv import for json.RawMessage field")
+		}
+	}
+
+	// The generated assignment must type-check
+	src := `package test
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Request struct {
+	Filter json.RawMessage
+}
+
+func parse(r *http.Request, payload *Request) error {
+	` + code + `
+	return nil
+}
+`
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "test.go", src, 0)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, src)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("test", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("generated code does not compile: %v\n%s", err, src)
+	}
+}
+
+func TestQueryExtractor_GenerateCode_NetTypes(t *testing.T) {
+	e := &QueryExtractor{}
+
+	tests := []struct {
+		name       string
+		field      *parser.Field
+		wantCode   string
+		wantImport string
+	}{
+		{
+			name:       "net.IP",
+			field:      &parser.Field{Name: "ClientIP", Type: "net.IP", StructTag: `query:"ip"`},
+			wantCode:   "net.ParseIP(",
+			wantImport: "net",
+		},
+		{
+			name:       "url.URL",
+			field:      &parser.Field{Name: "Callback", Type: "url.URL", StructTag: `query:"callback"`},
+			wantCode:   "url.Parse(",
+			wantImport: "net/url",
+		},
+		{
+			name:       "*url.URL",
+			field:      &parser.Field{Name: "Callback", Type: "url.URL", IsPointer: true, StructTag: `query:"callback"`},
+			wantCode:   "url.Parse(",
+			wantImport: "net/url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, imports := e.GenerateCode(tt.field, "Request")
+
+			if !strings.Contains(code, tt.wantCode) {
+				t.Errorf("expected code to contain %q, got:\n%s", tt.wantCode, code)
+			}
+
+			found := false
+			for _, imp := range imports {
+				if imp == tt.wantImport {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("expected import %q, got %v", tt.wantImport, imports)
+			}
+		})
+	}
+}
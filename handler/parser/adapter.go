@@ -42,9 +42,10 @@ func ExtractFromGeneric(generic *coreast.ParseResult) (*ParseResult, error) {
 // convertStruct converts a generic struct to APIKit struct
 func convertStruct(generic *coreast.Struct) *Struct {
 	s := &Struct{
-		Name:   generic.Name,
-		Fields: []Field{},
-		IsDTO:  hasDirective(generic.Doc, "apikit:dto"),
+		Name:     generic.Name,
+		Fields:   []Field{},
+		IsDTO:    hasDirective(generic.Doc, "apikit:dto"),
+		Consumes: parseMediaTypes(extractDirectives(generic.Doc)["consumes"]),
 	}
 
 	for _, genericField := range generic.Fields {
@@ -68,15 +69,25 @@ func convertField(generic *coreast.Field) Field {
 	}
 
 	// Extract "// in:xxx" and "// default:xxx" comments
+	var inModifiers []string
+	outHeader := ""
+	hasOutHeader := false
 	if generic.Comment != nil {
 		for _, comment := range generic.Comment.List {
 			if source, name := extractInComment(comment.Text); source != "" {
 				f.InComment = source
 				f.InCommentName = name
+				inModifiers = extractInModifiers(comment.Text)
 				if source == "body" {
 					f.IsBody = true
 				}
 			}
+			if values := extractEnumComment(comment.Text); values != nil {
+				f.Enum = values
+			}
+			if name, ok := extractOutHeaderComment(comment.Text); ok {
+				outHeader, hasOutHeader = name, true
+			}
 		}
 	}
 	if generic.Doc != nil {
@@ -86,11 +97,35 @@ func convertField(generic *coreast.Field) Field {
 				if source, name := extractInComment(comment.Text); source != "" {
 					f.InComment = source
 					f.InCommentName = name
+					inModifiers = extractInModifiers(comment.Text)
 					if source == "body" {
 						f.IsBody = true
 					}
 				}
 			}
+			// Only extract if not found in Comment
+			if f.Enum == nil {
+				f.Enum = extractEnumComment(comment.Text)
+			}
+			// Only extract if not found in Comment
+			if !hasOutHeader {
+				if name, ok := extractOutHeaderComment(comment.Text); ok {
+					outHeader, hasOutHeader = name, true
+				}
+			}
+		}
+	}
+
+	// Comment modifiers take precedence over tag options
+	applyInTag(&f)
+	applyInModifiers(&f, tagModifiers(f.StructTag))
+	applyInModifiers(&f, inModifiers)
+
+	// "// out:header" without a name uses the field name
+	if hasOutHeader {
+		f.OutHeader = outHeader
+		if f.OutHeader == "" {
+			f.OutHeader = generic.Name
 		}
 	}
 
@@ -111,6 +146,10 @@ func convertField(generic *coreast.Field) Field {
 		generic.Name == "R") &&
 		generic.Type == "*http.Request"
 
+	// Uploaded files from multipart/form-data requests
+	f.IsFile = generic.Type == "multipart.File" || generic.Type == "*multipart.FileHeader"
+	applyFileTags(&f)
+
 	return f
 }
 
@@ -125,9 +164,35 @@ func extractHandler(fn *coreast.Function, result *ParseResult, generic *coreast.
 	}
 
 	h := &Handler{
-		Name:    fn.Name,
-		Package: generic.Package,
-		Pos:     fn.Pos,
+		Name:       fn.Name,
+		Package:    generic.Package,
+		Pos:        fn.Pos,
+		Directives: extractDirectives(fn.Doc),
+	}
+	h.Consumes = parseMediaTypes(h.Directives["consumes"])
+
+	if !parseRouteDirective(h) {
+		warning := fmt.Sprintf("%s: function %s has invalid apikit:route directive %q (expected \"METHOD /path\")",
+			fn.Pos, fn.Name, h.Directives["route"])
+		result.Warnings = append(result.Warnings, warning)
+	}
+
+	if !parseMaxBodyDirective(h) {
+		warning := fmt.Sprintf("%s: function %s has invalid apikit:maxbody directive %q (expected a size like \"1MB\")",
+			fn.Pos, fn.Name, h.Directives["maxbody"])
+		result.Warnings = append(result.Warnings, warning)
+	}
+
+	if !parseMaxMemoryDirective(h) {
+		warning := fmt.Sprintf("%s: function %s has invalid apikit:maxmemory directive %q (expected a size like \"10MB\")",
+			fn.Pos, fn.Name, h.Directives["maxmemory"])
+		result.Warnings = append(result.Warnings, warning)
+	}
+
+	if !parseTimeoutDirective(h) {
+		warning := fmt.Sprintf("%s: function %s has invalid apikit:timeout directive %q (expected a duration like \"5s\")",
+			fn.Pos, fn.Name, h.Directives["timeout"])
+		result.Warnings = append(result.Warnings, warning)
 	}
 
 	// Handle receiver for methods
@@ -168,6 +233,11 @@ func extractHandler(fn *coreast.Function, result *ParseResult, generic *coreast.
 	}
 	h.ReturnType = fn.Results[0].Type
 
+	// Look up response struct info (for "// out:header" fields)
+	if s, ok := result.Structs[getTypeName(fn.Results[0].Type)]; ok {
+		h.ResponseStruct = s
+	}
+
 	return h
 }
 
@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// externalPackage holds the structs parsed from an external package directory
+type externalPackage struct {
+	modTime time.Time                    // Newest modification time of the package's Go files
+	structs map[string]*Struct           // Structs by name
+	imports map[string]map[string]string // Imports of the file declaring each struct
+}
+
+// packageCache is shared by all Parser instances, so generating several files
+// that import the same package only parses that package once per process
+// Entries are invalidated when any Go file in the package changes
+var (
+	packageCache   = make(map[string]*externalPackage)
+	packageCacheMu sync.Mutex
+)
+
+// ClearCache drops all cached external packages and structs,
+// including the cache shared with other Parser instances
+func (p *Parser) ClearCache() {
+	packageCacheMu.Lock()
+	packageCache = make(map[string]*externalPackage)
+	packageCacheMu.Unlock()
+
+	p.externalStructs = make(map[string]*Struct)
+	p.structImportsCache = make(map[string]map[string]string)
+}
+
+// loadExternalPackage returns the parsed structs of the package in pkgDir,
+// reusing the shared cache while the package's files are unchanged
+func (p *Parser) loadExternalPackage(importPath, pkgDir string) *externalPackage {
+	files, modTime := packageFiles(pkgDir)
+	if len(files) == 0 {
+		return nil
+	}
+
+	packageCacheMu.Lock()
+	cached, ok := packageCache[importPath]
+	packageCacheMu.Unlock()
+	if ok && cached.modTime.Equal(modTime) {
+		return cached
+	}
+
+	pkg := &externalPackage{
+		modTime: modTime,
+		structs: make(map[string]*Struct),
+		imports: make(map[string]map[string]string),
+	}
+
+	for _, file := range files {
+		src, err := parser.ParseFile(p.fset, file, nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		// Collect imports from this file
+		fileImports := make(map[string]string)
+		for _, imp := range src.Imports {
+			path := strings.Trim(imp.Path.Value, `"`)
+			alias := filepath.Base(path)
+			if imp.Name != nil {
+				alias = imp.Name.Name
+			}
+			fileImports[alias] = path
+		}
+
+		// Parse ALL structs in this file
+		for _, decl := range src.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				pkg.structs[typeSpec.Name.Name] = p.parseStruct(typeSpec.Name.Name, structType, typeSpecDoc(typeSpec, genDecl))
+				pkg.imports[typeSpec.Name.Name] = fileImports
+			}
+		}
+	}
+
+	packageCacheMu.Lock()
+	packageCache[importPath] = pkg
+	packageCacheMu.Unlock()
+
+	return pkg
+}
+
+// packageFiles lists the non-test Go files in dir and their newest modification time
+// The directory's own modification time is included so added or removed files are noticed
+func packageFiles(dir string) ([]string, time.Time) {
+	dirInfo, err := os.Stat(dir)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, time.Time{}
+	}
+
+	var files []string
+	modTime := dirInfo.ModTime()
+	for _, file := range matches {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+		files = append(files, file)
+	}
+
+	return files, modTime
+}
@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeSharedImportModule creates a module with a models package and
+// n handler files that all embed models.Address in their request struct
+func writeSharedImportModule(tb testing.TB, n int) []string {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	modelsDir := filepath.Join(dir, "models")
+	if err := os.MkdirAll(modelsDir, 0755); err != nil {
+		tb.Fatalf("creating models dir: %v", err)
+	}
+
+	files := map[string]string{
+		filepath.Join(dir, "go.mod"): "module example.com/shared\n\ngo 1.25\n",
+		filepath.Join(modelsDir, "address.go"): `package models
+
+type Address struct {
+	Street string ` + "`json:\"street\"`" + `
+	Zip    string ` + "`json:\"zip\"`" + `
+}
+`,
+	}
+
+	var handlerFiles []string
+	for i := range n {
+		path := filepath.Join(dir, fmt.Sprintf("handler%d.go", i))
+		files[path] = fmt.Sprintf(`package api
+
+import (
+	"context"
+
+	"example.com/shared/models"
+)
+
+type Request%[1]d struct {
+	Address models.Address `+"`json:\"address\"`"+`
+}
+
+// apikit:handler
+func Handler%[1]d(ctx context.Context, req Request%[1]d) (string, error) {
+	return "", nil
+}
+`, i)
+		handlerFiles = append(handlerFiles, path)
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			tb.Fatalf("writing %s: %v", path, err)
+		}
+	}
+
+	return handlerFiles
+}
+
+// addressFields returns the number of fields resolved for the Address field
+func addressFields(t *testing.T, result *ParseResult) int {
+	t.Helper()
+
+	if len(result.Handlers) != 1 {
+		t.Fatalf("expected 1 handler, got %d", len(result.Handlers))
+	}
+	for _, field := range result.Handlers[0].Struct.Fields {
+		if field.Name == "Address" && field.NestedStruct != nil {
+			return len(field.NestedStruct.Fields)
+		}
+	}
+	return 0
+}
+
+func TestPackageCache_SharedAcrossParsers(t *testing.T) {
+	files := writeSharedImportModule(t, 2)
+
+	p := New()
+	p.ClearCache()
+
+	result, err := p.ParseFile(files[0])
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if got := addressFields(t, result); got != 2 {
+		t.Fatalf("expected Address with 2 fields, got %d", got)
+	}
+
+	packageCacheMu.Lock()
+	cached := packageCache["example.com/shared/models"]
+	packageCacheMu.Unlock()
+	if cached == nil {
+		t.Fatal("expected models package to be cached")
+	}
+
+	// A fresh parser for a sibling file reuses the cached package
+	result, err = New().ParseFile(files[1])
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if got := addressFields(t, result); got != 2 {
+		t.Fatalf("expected Address with 2 fields, got %d", got)
+	}
+
+	packageCacheMu.Lock()
+	reused := packageCache["example.com/shared/models"]
+	packageCacheMu.Unlock()
+	if reused != cached {
+		t.Error("expected cached package to be reused")
+	}
+}
+
+func TestPackageCache_InvalidatedOnChange(t *testing.T) {
+	files := writeSharedImportModule(t, 1)
+
+	p := New()
+	p.ClearCache()
+	if _, err := p.ParseFile(files[0]); err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	// Add a field to the external struct and bump its modification time
+	modelFile := filepath.Join(filepath.Dir(files[0]), "models", "address.go")
+	updated := `package models
+
+type Address struct {
+	Street  string ` + "`json:\"street\"`" + `
+	Zip     string ` + "`json:\"zip\"`" + `
+	Country string ` + "`json:\"country\"`" + `
+}
+`
+	if err := os.WriteFile(modelFile, []byte(updated), 0644); err != nil {
+		t.Fatalf("updating model file: %v", err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(modelFile, future, future); err != nil {
+		t.Fatalf("updating mtime: %v", err)
+	}
+
+	result, err := New().ParseFile(files[0])
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	if got := addressFields(t, result); got != 3 {
+		t.Errorf("expected reloaded Address with 3 fields, got %d", got)
+	}
+}
+
+func BenchmarkParseFile_SharedImports(b *testing.B) {
+	files := writeSharedImportModule(b, 20)
+
+	b.Run("uncached", func(b *testing.B) {
+		for b.Loop() {
+			for _, file := range files {
+				p := New()
+				p.ClearCache()
+				if _, err := p.ParseFile(file); err != nil {
+					b.Fatalf("ParseFile failed: %v", err)
+				}
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		New().ClearCache()
+		for b.Loop() {
+			for _, file := range files {
+				if _, err := New().ParseFile(file); err != nil {
+					b.Fatalf("ParseFile failed: %v", err)
+				}
+			}
+		}
+	})
+}
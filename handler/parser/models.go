@@ -3,7 +3,10 @@
 // their metadata for code generation.
 package parser
 
-import "go/token"
+import (
+	"go/token"
+	"time"
+)
 
 // Handler represents a function marked with apikit:handler comment
 type Handler struct {
@@ -25,12 +28,43 @@ type Handler struct {
 	// Struct contains the parsed request struct information
 	Struct *Struct
 
+	// ResponseStruct contains the parsed response struct information
+	// Nil when the return type is not a struct declared in the parsed source
+	ResponseStruct *Struct
+
 	// HasResponseWriter indicates if handler has http.ResponseWriter parameter
 	HasResponseWriter bool
 
 	// HasRequest indicates if handler has *http.Request parameter
 	HasRequest bool
 
+	// Directives holds the "// apikit:<name> [value]" comments found on the handler
+	// (excluding apikit:handler itself), keyed by name
+	// Example: "// apikit:compress" -> {"compress": ""}
+	Directives map[string]string
+
+	// Method and Path come from the "// apikit:route GET /users/{id}" directive
+	// Both are empty when the handler has no route
+	Method string
+	Path   string
+
+	// MaxBodySize is the request body limit in bytes from the "// apikit:maxbody 1MB" directive
+	// Zero when the handler has no limit
+	MaxBodySize int64
+
+	// MaxMemory is the multipart memory budget in bytes from the "// apikit:maxmemory 10MB" directive
+	// Zero when the handler keeps the default
+	MaxMemory int64
+
+	// Timeout bounds the handler context, from the "// apikit:timeout 5s" directive
+	// Zero when the handler has no timeout
+	Timeout time.Duration
+
+	// Consumes lists the body media types from the "// apikit:consumes" directive
+	// Example: "// apikit:consumes application/json,application/x-www-form-urlencoded"
+	// -> ["application/json", "application/x-www-form-urlencoded"]
+	Consumes []string
+
 	// Position in source file (for error reporting)
 	Pos token.Position
 }
@@ -45,6 +79,10 @@ type Struct struct {
 
 	// IsDTO indicates if this struct is marked with apikit:dto comment
 	IsDTO bool
+
+	// Consumes lists the body media types from an "apikit:consumes <media-type>,..." comment
+	// Example: "// apikit:consumes application/xml" -> ["application/xml"]
+	Consumes []string
 }
 
 // Field represents a struct field with its tags and metadata
@@ -63,6 +101,18 @@ type Field struct {
 	InComment     string // Source extracted from "// in:xxx" comment (e.g., "query", "path")
 	InCommentName string // Optional parameter name from "// in:xxx paramName" comment
 
+	// Serialization modifiers from "// in:query tags,style=pipeDelimited,explode"
+	// or the equivalent `query:"tags,style=pipeDelimited,explode"` tag
+	Style   string // OpenAPI style (e.g., "form", "spaceDelimited", "pipeDelimited")
+	Explode *bool  // Nil when not specified
+
+	// Enum lists the allowed values from an "// enum: available,pending,sold" comment
+	Enum []string
+
+	// OutHeader is the response header name from an "// out:header Location" comment
+	// Set on response struct fields, which are written as headers instead of the JSON body
+	OutHeader string
+
 	// Type information
 	IsPointer bool   // Is this a pointer type (*string)
 	IsSlice   bool   // Is this a slice type ([]string)
@@ -74,6 +124,11 @@ type Field struct {
 	IsRawBody        bool // Field named RawBody with type []byte
 	IsResponseWriter bool // Field is http.ResponseWriter
 	IsRequest        bool // Field is *http.Request
+	IsFile           bool // Field is multipart.File or *multipart.FileHeader
+
+	// Upload limits of file fields from `maxfilesize:"5MB"` and `accept:"image/png,image/jpeg"` tags
+	MaxFileSize int64    // Zero when the size is not limited
+	Accept      []string // Allowed media types, empty when any type is accepted
 
 	// Nested struct information
 	NestedStruct *Struct // If this field is a struct type, contains its definition
@@ -5,9 +5,14 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"math"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // Parser analyzes Go source files to find apikit handlers
@@ -16,8 +21,6 @@ type Parser struct {
 	structs            map[string]*Struct           // Cache of parsed structs
 	externalStructs    map[string]*Struct           // Cache of external structs by "importPath.StructName"
 	structImportsCache map[string]map[string]string // Cache of imports for each external struct
-	parsedFiles        map[string]bool              // Cache of already parsed files
-	fileImportsCache   map[string]map[string]string // Cache of file imports by file path
 	importPathCache    map[string]string            // Cache of resolved import paths
 	goModPath          string                       // Cached path to go.mod
 	moduleName         string                       // Cached module name from go.mod
@@ -31,8 +34,6 @@ func New() *Parser {
 		structs:            make(map[string]*Struct),
 		externalStructs:    make(map[string]*Struct),
 		structImportsCache: make(map[string]map[string]string),
-		parsedFiles:        make(map[string]bool),
-		fileImportsCache:   make(map[string]map[string]string),
 		importPathCache:    make(map[string]string),
 	}
 }
@@ -74,10 +75,14 @@ func (p *Parser) ParseFile(filename string) (*ParseResult, error) {
 	}
 
 	// First pass: collect all struct definitions
+	var genDecl *ast.GenDecl
 	ast.Inspect(file, func(n ast.Node) bool {
+		if gd, ok := n.(*ast.GenDecl); ok {
+			genDecl = gd
+		}
 		if typeSpec, ok := n.(*ast.TypeSpec); ok {
 			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
-				s := p.parseStruct(typeSpec.Name.Name, structType, typeSpec)
+				s := p.parseStruct(typeSpec.Name.Name, structType, typeSpecDoc(typeSpec, genDecl))
 				result.Structs[s.Name] = s
 				p.structs[s.Name] = s // Cache for nested resolution
 			}
@@ -121,9 +126,39 @@ func (p *Parser) parseHandler(fn *ast.FuncDecl, pkgName string, result *ParseRes
 	}
 
 	h := &Handler{
-		Name:    fn.Name.Name,
-		Package: pkgName,
-		Pos:     p.fset.Position(fn.Pos()),
+		Name:       fn.Name.Name,
+		Package:    pkgName,
+		Pos:        p.fset.Position(fn.Pos()),
+		Directives: extractDirectives(fn.Doc),
+	}
+	h.Consumes = parseMediaTypes(h.Directives["consumes"])
+
+	if !parseRouteDirective(h) {
+		pos := p.fset.Position(fn.Pos())
+		warning := fmt.Sprintf("%s: function %s has invalid apikit:route directive %q (expected \"METHOD /path\")",
+			pos, fn.Name.Name, h.Directives["route"])
+		result.Warnings = append(result.Warnings, warning)
+	}
+
+	if !parseMaxBodyDirective(h) {
+		pos := p.fset.Position(fn.Pos())
+		warning := fmt.Sprintf("%s: function %s has invalid apikit:maxbody directive %q (expected a size like \"1MB\")",
+			pos, fn.Name.Name, h.Directives["maxbody"])
+		result.Warnings = append(result.Warnings, warning)
+	}
+
+	if !parseMaxMemoryDirective(h) {
+		pos := p.fset.Position(fn.Pos())
+		warning := fmt.Sprintf("%s: function %s has invalid apikit:maxmemory directive %q (expected a size like \"10MB\")",
+			pos, fn.Name.Name, h.Directives["maxmemory"])
+		result.Warnings = append(result.Warnings, warning)
+	}
+
+	if !parseTimeoutDirective(h) {
+		pos := p.fset.Position(fn.Pos())
+		warning := fmt.Sprintf("%s: function %s has invalid apikit:timeout directive %q (expected a duration like \"5s\")",
+			pos, fn.Name.Name, h.Directives["timeout"])
+		result.Warnings = append(result.Warnings, warning)
 	}
 
 	// Handle receiver for methods
@@ -177,24 +212,30 @@ func (p *Parser) parseHandler(fn *ast.FuncDecl, pkgName string, result *ParseRes
 	}
 	h.ReturnType = p.typeToString(results[0].Type)
 
+	// Look up response struct info (for "// out:header" fields)
+	if s, ok := result.Structs[p.getTypeName(results[0].Type)]; ok {
+		h.ResponseStruct = s
+	}
+
 	return h
 }
 
 // parseStruct extracts struct field information
-func (p *Parser) parseStruct(name string, st *ast.StructType, typeSpec *ast.TypeSpec) *Struct {
+func (p *Parser) parseStruct(name string, st *ast.StructType, doc *ast.CommentGroup) *Struct {
 	s := &Struct{
 		Name:   name,
 		Fields: []Field{},
 	}
 
 	// Check for apikit:dto comment
-	if typeSpec != nil && typeSpec.Doc != nil {
-		for _, comment := range typeSpec.Doc.List {
+	if doc != nil {
+		for _, comment := range doc.List {
 			if strings.Contains(comment.Text, "apikit:dto") {
 				s.IsDTO = true
 				break
 			}
 		}
+		s.Consumes = parseMediaTypes(extractDirectives(doc)["consumes"])
 	}
 
 	// Parse fields
@@ -206,6 +247,18 @@ func (p *Parser) parseStruct(name string, st *ast.StructType, typeSpec *ast.Type
 	return s
 }
 
+// typeSpecDoc returns the doc comment of a type spec
+// Ungrouped declarations ("// Doc\ntype X struct{}") attach the comment to the GenDecl
+func typeSpecDoc(typeSpec *ast.TypeSpec, genDecl *ast.GenDecl) *ast.CommentGroup {
+	if typeSpec.Doc != nil {
+		return typeSpec.Doc
+	}
+	if genDecl != nil && len(genDecl.Specs) == 1 {
+		return genDecl.Doc
+	}
+	return nil
+}
+
 // parseField extracts field information including tags
 func (p *Parser) parseField(field *ast.Field) []Field {
 	var fields []Field
@@ -229,14 +282,19 @@ func (p *Parser) parseField(field *ast.Field) []Field {
 	// Extract "// in:xxx" and "// default:xxx" comments
 	inComment := ""
 	inCommentName := ""
+	var inModifiers []string
+	var enum []string
 	defaultFromComment := ""
 	isBody := false
+	outHeader := ""
+	hasOutHeader := false
 	if field.Comment != nil {
 		for _, comment := range field.Comment.List {
 			// Extract "// in:xxx"
 			if source, name := extractInComment(comment.Text); source != "" {
 				inComment = source
 				inCommentName = name
+				inModifiers = extractInModifiers(comment.Text)
 				if source == "body" {
 					isBody = true
 				}
@@ -245,6 +303,14 @@ func (p *Parser) parseField(field *ast.Field) []Field {
 			if defaultVal := extractDefaultComment(comment.Text); defaultVal != "" {
 				defaultFromComment = defaultVal
 			}
+			// Extract "// enum:a,b,c"
+			if values := extractEnumComment(comment.Text); values != nil {
+				enum = values
+			}
+			// Extract "// out:header Name"
+			if name, ok := extractOutHeaderComment(comment.Text); ok {
+				outHeader, hasOutHeader = name, true
+			}
 		}
 	}
 	if field.Doc != nil {
@@ -254,6 +320,7 @@ func (p *Parser) parseField(field *ast.Field) []Field {
 				if source, name := extractInComment(comment.Text); source != "" {
 					inComment = source
 					inCommentName = name
+					inModifiers = extractInModifiers(comment.Text)
 					if source == "body" {
 						isBody = true
 					}
@@ -265,6 +332,16 @@ func (p *Parser) parseField(field *ast.Field) []Field {
 					defaultFromComment = defaultVal
 				}
 			}
+			// Extract "// enum:a,b,c" (only if not found in Comment)
+			if enum == nil {
+				enum = extractEnumComment(comment.Text)
+			}
+			// Extract "// out:header Name" (only if not found in Comment)
+			if !hasOutHeader {
+				if name, ok := extractOutHeaderComment(comment.Text); ok {
+					outHeader, hasOutHeader = name, true
+				}
+			}
 		}
 	}
 
@@ -280,6 +357,15 @@ func (p *Parser) parseField(field *ast.Field) []Field {
 				IsBody:        isBody,
 				InComment:     inComment,
 				InCommentName: inCommentName,
+				Enum:          enum,
+			}
+
+			// "// out:header" without a name uses the field name
+			if hasOutHeader {
+				f.OutHeader = outHeader
+				if f.OutHeader == "" {
+					f.OutHeader = name.Name
+				}
 			}
 
 			// Check for special field types
@@ -301,11 +387,21 @@ func (p *Parser) parseField(field *ast.Field) []Field {
 				name.Name == "R") &&
 				fieldType == "*http.Request"
 
+			// Uploaded files from multipart/form-data requests
+			f.IsFile = fieldType == "multipart.File" || fieldType == "*multipart.FileHeader"
+
 			// Store the complete struct tag
 			if field.Tag != nil {
 				f.StructTag = strings.Trim(field.Tag.Value, "`")
 			}
 
+			applyFileTags(&f)
+
+			// Comment modifiers take precedence over tag options
+			applyInTag(&f)
+			applyInModifiers(&f, tagModifiers(f.StructTag))
+			applyInModifiers(&f, inModifiers)
+
 			fields = append(fields, f)
 		}
 	} else {
@@ -447,6 +543,15 @@ func (p *Parser) typeToString(expr ast.Expr) string {
 		return "map[" + p.typeToString(e.Key) + "]" + p.typeToString(e.Value)
 	case *ast.InterfaceType:
 		return "any"
+	case *ast.ChanType:
+		switch e.Dir {
+		case ast.RECV:
+			return "<-chan " + p.typeToString(e.Value)
+		case ast.SEND:
+			return "chan<- " + p.typeToString(e.Value)
+		default:
+			return "chan " + p.typeToString(e.Value)
+		}
 	default:
 		return ""
 	}
@@ -487,7 +592,7 @@ func (p *Parser) resolveNestedStructsRecursive(s *Struct, visited map[string]boo
 		field := &s.Fields[i]
 
 		// Skip special fields
-		if field.IsRawBody || field.IsResponseWriter || field.IsRequest {
+		if field.IsRawBody || field.IsResponseWriter || field.IsRequest || field.IsFile {
 			continue
 		}
 
@@ -597,93 +702,20 @@ func (p *Parser) loadExternalStruct(importPath, structName string) (*Struct, map
 		return nil, nil
 	}
 
-	// Find all Go files in the package directory
-	files, err := filepath.Glob(filepath.Join(pkgDir, "*.go"))
-	if err != nil {
+	// Parse the whole package once (or reuse the shared cache) and cache all its structs
+	pkg := p.loadExternalPackage(importPath, pkgDir)
+	if pkg == nil {
 		return nil, nil
 	}
-
-	// Parse each file looking for the struct
-	for _, file := range files {
-		// Skip test files
-		if strings.HasSuffix(file, "_test.go") {
-			continue
-		}
-
-		// Check if file was already parsed
-		if cachedImports, ok := p.fileImportsCache[file]; ok {
-			// File already parsed, check if struct is in cache
-			structCacheKey := importPath + "." + structName
-			if cachedStruct, ok := p.externalStructs[structCacheKey]; ok {
-				return cachedStruct, cachedImports
-			}
-			// Struct not in this file, skip it
-			continue
-		}
-
-		// Parse the file
-		src, err := parser.ParseFile(p.fset, file, nil, parser.ParseComments)
-		if err != nil {
-			continue
-		}
-
-		// Collect imports from this file
-		fileImports := make(map[string]string)
-		for _, imp := range src.Imports {
-			path := strings.Trim(imp.Path.Value, `"`)
-			alias := filepath.Base(path)
-			if imp.Name != nil {
-				alias = imp.Name.Name
-			}
-			fileImports[alias] = path
-		}
-
-		// Cache the imports
-		p.fileImportsCache[file] = fileImports
-
-		// Parse ALL structs in this file and cache them
-		// This way we only parse each file once
-		var foundStruct *Struct
-		for _, decl := range src.Decls {
-			genDecl, ok := decl.(*ast.GenDecl)
-			if !ok {
-				continue
-			}
-
-			for _, spec := range genDecl.Specs {
-				typeSpec, ok := spec.(*ast.TypeSpec)
-				if !ok {
-					continue
-				}
-
-				structType, ok := typeSpec.Type.(*ast.StructType)
-				if !ok {
-					continue
-				}
-
-				// Parse and cache this struct
-				s := p.parseStruct(typeSpec.Name.Name, structType, typeSpec)
-				structCacheKey := importPath + "." + typeSpec.Name.Name
-				p.externalStructs[structCacheKey] = s
-				// Cache the imports for this struct
-				p.structImportsCache[structCacheKey] = fileImports
-
-				// Check if this is the struct we're looking for
-				if typeSpec.Name.Name == structName {
-					foundStruct = s
-				}
-			}
-		}
-
-		// Mark as parsed AFTER we've cached all structs
-		p.parsedFiles[file] = true
-
-		// If we found the struct we were looking for, return it
-		if foundStruct != nil {
-			return foundStruct, fileImports
-		}
+	for name, s := range pkg.structs {
+		structCacheKey := importPath + "." + name
+		p.externalStructs[structCacheKey] = s
+		p.structImportsCache[structCacheKey] = pkg.imports[name]
 	}
 
+	if s, ok := pkg.structs[structName]; ok {
+		return s, pkg.imports[structName]
+	}
 	return nil, nil
 }
 
@@ -779,6 +811,175 @@ func hasApikitComment(fn *ast.FuncDecl) bool {
 	return false
 }
 
+// extractDirectives collects "// apikit:<name> [value]" comments from a handler's doc
+// The apikit:handler marker itself is skipped
+// Examples:
+//   - "// apikit:compress" -> {"compress": ""}
+//   - "// apikit:timeout 5s" -> {"timeout": "5s"}
+func extractDirectives(doc *ast.CommentGroup) map[string]string {
+	directives := make(map[string]string)
+	if doc == nil {
+		return directives
+	}
+
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(text, "apikit:") {
+			continue
+		}
+
+		name, value, _ := strings.Cut(strings.TrimPrefix(text, "apikit:"), " ")
+		if name == "" || name == "handler" {
+			continue
+		}
+		directives[name] = strings.TrimSpace(value)
+	}
+
+	return directives
+}
+
+// parseRouteDirective fills Method and Path from the "apikit:route" directive
+// Returns false if the directive is present but malformed
+// Example: "// apikit:route get /users/{id}" -> Method "GET", Path "/users/{id}"
+func parseRouteDirective(h *Handler) bool {
+	route, ok := h.Directives["route"]
+	if !ok {
+		return true
+	}
+
+	method, path, _ := strings.Cut(route, " ")
+	path = strings.TrimSpace(path)
+	if method == "" || !strings.HasPrefix(path, "/") {
+		return false
+	}
+
+	h.Method = strings.ToUpper(method)
+	h.Path = path
+	return true
+}
+
+// parseMediaTypes splits a comma-separated list of media types
+// Example: "application/json, application/x-www-form-urlencoded" -> ["application/json", "application/x-www-form-urlencoded"]
+func parseMediaTypes(value string) []string {
+	var mediaTypes []string
+	for mediaType := range strings.SplitSeq(value, ",") {
+		if mediaType = strings.TrimSpace(mediaType); mediaType != "" {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	return mediaTypes
+}
+
+// parseMaxBodyDirective fills MaxBodySize from the "apikit:maxbody" directive
+// Returns false if the directive is present but not a valid size
+// Example: "// apikit:maxbody 1MB" -> MaxBodySize 1048576
+func parseMaxBodyDirective(h *Handler) bool {
+	value, ok := h.Directives["maxbody"]
+	if !ok {
+		return true
+	}
+
+	size, err := parseByteSize(value)
+	if err != nil {
+		return false
+	}
+
+	h.MaxBodySize = size
+	return true
+}
+
+// applyFileTags fills the upload limits of a file field from its struct tags
+// An invalid maxfilesize is left unset and reported by the file extractor
+// Example: `maxfilesize:"5MB" accept:"image/png,image/jpeg"` -> MaxFileSize 5242880, Accept [image/png image/jpeg]
+func applyFileTags(f *Field) {
+	if !f.IsFile {
+		return
+	}
+
+	tag := reflect.StructTag(f.StructTag)
+	if value, ok := tag.Lookup("maxfilesize"); ok {
+		if size, err := parseByteSize(value); err == nil {
+			f.MaxFileSize = size
+		}
+	}
+	if value, ok := tag.Lookup("accept"); ok {
+		f.Accept = parseMediaTypes(value)
+	}
+}
+
+// parseMaxMemoryDirective fills MaxMemory from the "apikit:maxmemory" directive
+// Returns false if the directive is present but not a valid size
+// Example: "// apikit:maxmemory 10MB" -> MaxMemory 10485760
+func parseMaxMemoryDirective(h *Handler) bool {
+	value, ok := h.Directives["maxmemory"]
+	if !ok {
+		return true
+	}
+
+	size, err := parseByteSize(value)
+	if err != nil {
+		return false
+	}
+
+	h.MaxMemory = size
+	return true
+}
+
+// parseTimeoutDirective fills Timeout from the "apikit:timeout" directive
+// Returns false if the directive is present but not a positive duration
+// Example: "// apikit:timeout 5s" -> Timeout 5 * time.Second
+func parseTimeoutDirective(h *Handler) bool {
+	value, ok := h.Directives["timeout"]
+	if !ok {
+		return true
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return false
+	}
+
+	h.Timeout = timeout
+	return true
+}
+
+// byteUnits maps size suffixes to their multiplier, using binary units
+var byteUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+}
+
+// parseByteSize parses a human-readable size into bytes
+// Units are case-insensitive and binary (1KB = 1024 bytes)
+// Examples: "512" -> 512, "64KB" -> 65536, "1.5mb" -> 1572864
+func parseByteSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	number := strings.TrimRightFunc(s, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsSpace(r)
+	})
+
+	multiplier, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[len(number):]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit", s)
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value <= 0 || value*float64(multiplier) >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	return int64(value * float64(multiplier)), nil
+}
+
 // extractInComment extracts the source and optional name from "// in:xxx" comment
 // Returns: (source, name)
 // Examples:
@@ -828,17 +1029,186 @@ func extractInComment(comment string) (string, string) {
 		if len(parts) == 0 {
 			return "", ""
 		}
-		if len(parts) == 1 {
-			return parts[0], ""
-		}
 		// parts[0] = source (query, path, header, etc.)
-		// parts[1] = parameter name
-		return parts[0], parts[1]
+		// parts[1:] = parameter name and modifiers
+		name, _ := splitInModifiers(strings.Join(parts[1:], " "))
+		return parts[0], name
 	}
 
 	return "", ""
 }
 
+// extractInTag extracts the source, name and modifiers of an `apikit:"in=..."` struct tag,
+// the tag form of an "// in:xxx" comment
+// Returns an empty source if the tag has no "in" option
+// Examples:
+//   - `apikit:"in=query"` -> ("query", "", nil)
+//   - `apikit:"in=query,name=filter"` -> ("query", "filter", nil)
+//   - `apikit:"in=query,name=tags,style=pipeDelimited"` -> ("query", "tags", ["style=pipeDelimited"])
+func extractInTag(structTag string) (string, string, []string) {
+	value, ok := reflect.StructTag(structTag).Lookup("apikit")
+	if !ok {
+		return "", "", nil
+	}
+
+	var source, name string
+	var modifiers []string
+	for option := range strings.SplitSeq(value, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "in":
+			source = val
+		case "name":
+			name = val
+		case "style", "explode":
+			modifiers = append(modifiers, strings.TrimSpace(option))
+		}
+	}
+	return source, name, modifiers
+}
+
+// applyInTag sets the source of a field from its `apikit:"in=..."` tag
+// An "// in:" comment takes precedence over the tag
+func applyInTag(f *Field) {
+	if f.InComment != "" {
+		return
+	}
+	source, name, modifiers := extractInTag(f.StructTag)
+	if source == "" {
+		return
+	}
+
+	f.InComment = source
+	f.InCommentName = name
+	f.IsBody = f.IsBody || source == "body"
+	applyInModifiers(f, modifiers)
+}
+
+// extractOutHeaderComment extracts the header name from an "// out:header" comment
+// Returns ok=false if the comment is not an out:header annotation
+// Examples:
+//   - "// out:header Location" -> ("Location", true)
+//   - "// out:header 'X-Total Count'" -> ("X-Total Count", true)
+//   - "// out:header" -> ("", true)
+func extractOutHeaderComment(comment string) (string, bool) {
+	comment = strings.TrimPrefix(comment, "//")
+	comment = strings.TrimPrefix(comment, "/*")
+	comment = strings.TrimSuffix(comment, "*/")
+	comment = strings.TrimSpace(comment)
+
+	value, ok := strings.CutPrefix(comment, "out:")
+	if !ok {
+		return "", false
+	}
+
+	source, name, _ := strings.Cut(strings.TrimSpace(value), " ")
+	if source != "header" {
+		return "", false
+	}
+	return strings.Trim(strings.TrimSpace(name), "'"), true
+}
+
+// extractInModifiers extracts the serialization modifiers of a "// in:xxx" comment
+// Examples:
+//   - "// in:query tags,style=pipeDelimited" -> ["style=pipeDelimited"]
+//   - "// in:query explode=false" -> ["explode=false"]
+func extractInModifiers(comment string) []string {
+	comment = strings.TrimPrefix(comment, "//")
+	comment = strings.TrimPrefix(comment, "/*")
+	comment = strings.TrimSuffix(comment, "*/")
+	comment = strings.TrimSpace(comment)
+
+	value, ok := strings.CutPrefix(comment, "in:")
+	if !ok || strings.Contains(value, "'") {
+		return nil
+	}
+
+	parts := strings.Fields(value)
+	if len(parts) < 2 {
+		return nil
+	}
+	_, modifiers := splitInModifiers(strings.Join(parts[1:], " "))
+	return modifiers
+}
+
+// splitInModifiers splits the text following an "in:" source into the parameter name and modifiers
+// Tokens are separated by commas or spaces; "style=..." and "explode[=bool]" tokens are modifiers
+// Example: "tags,style=pipeDelimited" -> ("tags", ["style=pipeDelimited"])
+func splitInModifiers(value string) (string, []string) {
+	var name string
+	var modifiers []string
+	for token := range strings.FieldsFuncSeq(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	}) {
+		key, _, _ := strings.Cut(token, "=")
+		if key == "style" || key == "explode" {
+			modifiers = append(modifiers, token)
+		} else if name == "" {
+			name = token
+		}
+	}
+	return name, modifiers
+}
+
+// tagModifiers returns the modifiers following the name in a query, header, path or cookie tag
+// Example: `query:"tags,style=pipeDelimited"` -> ["style=pipeDelimited"]
+func tagModifiers(structTag string) []string {
+	tag := reflect.StructTag(structTag)
+	for _, key := range []string{"query", "header", "path", "cookie"} {
+		if value, ok := tag.Lookup(key); ok {
+			_, options, _ := strings.Cut(value, ",")
+			_, modifiers := splitInModifiers(options)
+			return modifiers
+		}
+	}
+	return nil
+}
+
+// applyInModifiers sets the Style and Explode of a field from its modifiers
+// Example: ["style=pipeDelimited", "explode=false"] -> Style "pipeDelimited", Explode false
+func applyInModifiers(f *Field, modifiers []string) {
+	for _, modifier := range modifiers {
+		key, value, hasValue := strings.Cut(modifier, "=")
+		switch key {
+		case "style":
+			f.Style = value
+		case "explode":
+			explode := true
+			if hasValue {
+				if b, err := strconv.ParseBool(value); err == nil {
+					explode = b
+				}
+			}
+			f.Explode = &explode
+		}
+	}
+}
+
+// extractEnumComment extracts the allowed values from an "// enum:a,b,c" comment
+// Returns nil if the comment isn't an enum annotation
+// Examples:
+//   - "// enum: available,pending,sold" -> ["available", "pending", "sold"]
+//   - "// Enum: asc, desc" -> ["asc", "desc"]
+func extractEnumComment(comment string) []string {
+	comment = strings.TrimPrefix(comment, "//")
+	comment = strings.TrimPrefix(comment, "/*")
+	comment = strings.TrimSuffix(comment, "*/")
+	comment = strings.TrimSpace(comment)
+
+	key, value, ok := strings.Cut(comment, ":")
+	if !ok || !strings.EqualFold(strings.TrimSpace(key), "enum") {
+		return nil
+	}
+
+	var values []string
+	for v := range strings.SplitSeq(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 // extractDefaultComment extracts the default value from "// default:xxx" comment
 // Returns: default value (empty string if not found)
 // Examples:
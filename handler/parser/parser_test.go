@@ -3,7 +3,12 @@ package parser
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	coreast "github.com/reation-io/apikit/core/ast"
 )
 
 func TestNew(t *testing.T) {
@@ -377,6 +382,12 @@ func TestExtractInComment(t *testing.T) {
 			expectedSource: "path",
 			expectedName:   "userId",
 		},
+		{
+			name:           "context with key",
+			comment:        "// in:context userIDKey",
+			expectedSource: "context",
+			expectedName:   "userIDKey",
+		},
 		{
 			name:           "header without quotes",
 			comment:        "// in:header X-API-Key",
@@ -455,6 +466,18 @@ func TestExtractInComment(t *testing.T) {
 			expectedSource: "header",
 			expectedName:   "Content-Type",
 		},
+		{
+			name:           "name with modifiers",
+			comment:        "// in:query tags,style=pipeDelimited,explode",
+			expectedSource: "query",
+			expectedName:   "tags",
+		},
+		{
+			name:           "modifiers without name",
+			comment:        "// in:query explode=false",
+			expectedSource: "query",
+			expectedName:   "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -471,3 +494,570 @@ func TestExtractInComment(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFile_HandlerDirectives(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "handler.go")
+
+	content := `package test
+
+import "context"
+
+type ListRequest struct {
+	Page int ` + "`query:\"page\"`" + `
+}
+
+// ListItems lists items
+// apikit:handler
+// apikit:compress
+// apikit:timeout 5s
+func ListItems(ctx context.Context, req ListRequest) ([]string, error) {
+	return nil, nil
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	if len(result.Handlers) != 1 {
+		t.Fatalf("expected 1 handler, got %d", len(result.Handlers))
+	}
+
+	directives := result.Handlers[0].Directives
+	if len(directives) != 2 {
+		t.Errorf("expected 2 directives, got %v", directives)
+	}
+	if value, ok := directives["compress"]; !ok || value != "" {
+		t.Errorf("expected compress directive without value, got %q (present=%v)", value, ok)
+	}
+	if value := directives["timeout"]; value != "5s" {
+		t.Errorf("expected timeout directive '5s', got %q", value)
+	}
+	if _, ok := directives["handler"]; ok {
+		t.Error("expected apikit:handler marker not to be a directive")
+	}
+}
+
+func TestParseFile_RouteDirective(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "handler.go")
+
+	content := `package test
+
+import "context"
+
+type GetUserRequest struct {
+	ID string ` + "`path:\"id\"`" + `
+}
+
+// GetUser returns a user
+// apikit:handler
+// apikit:route get /users/{id}
+func GetUser(ctx context.Context, req GetUserRequest) (string, error) {
+	return "", nil
+}
+
+// BrokenRoute has a route without a path
+// apikit:handler
+// apikit:route GET
+func BrokenRoute(ctx context.Context, req GetUserRequest) (string, error) {
+	return "", nil
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	if len(result.Handlers) != 2 {
+		t.Fatalf("expected 2 handlers, got %d", len(result.Handlers))
+	}
+
+	h := result.Handlers[0]
+	if h.Method != "GET" {
+		t.Errorf("expected method GET, got %q", h.Method)
+	}
+	if h.Path != "/users/{id}" {
+		t.Errorf("expected path /users/{id}, got %q", h.Path)
+	}
+
+	broken := result.Handlers[1]
+	if broken.Method != "" || broken.Path != "" {
+		t.Errorf("expected malformed route to be ignored, got %q %q", broken.Method, broken.Path)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("expected 1 warning for malformed route, got %v", result.Warnings)
+	}
+}
+
+func TestParseFile_MaxBodyDirective(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "handler.go")
+
+	content := `package test
+
+import "context"
+
+type CreateUserRequest struct {
+	// in:body
+	Body map[string]string
+}
+
+// CreateUser creates a user
+// apikit:handler
+// apikit:maxbody 2MB
+func CreateUser(ctx context.Context, req CreateUserRequest) (string, error) {
+	return "", nil
+}
+
+// BrokenLimit has an unparseable size
+// apikit:handler
+// apikit:maxbody lots
+func BrokenLimit(ctx context.Context, req CreateUserRequest) (string, error) {
+	return "", nil
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	if len(result.Handlers) != 2 {
+		t.Fatalf("expected 2 handlers, got %d", len(result.Handlers))
+	}
+	if got := result.Handlers[0].MaxBodySize; got != 2<<20 {
+		t.Errorf("expected MaxBodySize %d, got %d", 2<<20, got)
+	}
+	if got := result.Handlers[1].MaxBodySize; got != 0 {
+		t.Errorf("expected invalid limit to be ignored, got %d", got)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "apikit:maxbody") {
+		t.Errorf("expected 1 warning for invalid maxbody, got %v", result.Warnings)
+	}
+}
+
+func TestParseFile_TimeoutDirective(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "handler.go")
+
+	content := `package test
+
+import "context"
+
+type ReportRequest struct {
+	ID string ` + "`path:\"id\"`" + `
+}
+
+// BuildReport builds a report
+// apikit:handler
+// apikit:timeout 1m30s
+func BuildReport(ctx context.Context, req ReportRequest) (string, error) {
+	return "", nil
+}
+
+// BrokenTimeout has an unparseable duration
+// apikit:handler
+// apikit:timeout soon
+func BrokenTimeout(ctx context.Context, req ReportRequest) (string, error) {
+	return "", nil
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	if len(result.Handlers) != 2 {
+		t.Fatalf("expected 2 handlers, got %d", len(result.Handlers))
+	}
+	if got := result.Handlers[0].Timeout; got != 90*time.Second {
+		t.Errorf("expected Timeout %v, got %v", 90*time.Second, got)
+	}
+	if got := result.Handlers[1].Timeout; got != 0 {
+		t.Errorf("expected invalid timeout to be ignored, got %v", got)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "apikit:timeout") {
+		t.Errorf("expected 1 warning for invalid timeout, got %v", result.Warnings)
+	}
+}
+
+func TestParseFile_QueryStyleModifiers(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "handler.go")
+
+	content := `package test
+
+import "context"
+
+type ListRequest struct {
+	Tags []string // in:query tags,style=pipeDelimited,explode=false
+	IDs  []int    ` + "`query:\"ids,style=spaceDelimited\"`" + `
+	Sort []string // in:query explode
+}
+
+// apikit:handler
+func List(ctx context.Context, req ListRequest) (string, error) {
+	return "", nil
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	fields := result.Structs["ListRequest"].Fields
+	if len(fields) != 3 {
+		t.Fatalf("expected 3 fields, got %d", len(fields))
+	}
+
+	tags := fields[0]
+	if tags.InCommentName != "tags" || tags.Style != "pipeDelimited" || tags.Explode == nil || *tags.Explode {
+		t.Errorf("unexpected Tags modifiers: name=%q style=%q explode=%v", tags.InCommentName, tags.Style, tags.Explode)
+	}
+
+	ids := fields[1]
+	if ids.Style != "spaceDelimited" || ids.Explode != nil {
+		t.Errorf("unexpected IDs modifiers: style=%q explode=%v", ids.Style, ids.Explode)
+	}
+
+	sort := fields[2]
+	if sort.InCommentName != "" || sort.Style != "" || sort.Explode == nil || !*sort.Explode {
+		t.Errorf("unexpected Sort modifiers: name=%q style=%q explode=%v", sort.InCommentName, sort.Style, sort.Explode)
+	}
+}
+
+func TestParseFile_InTag(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "handler.go")
+
+	content := `package test
+
+import "context"
+
+type Pet struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type UpdatePetRequest struct {
+	ID      int      ` + "`apikit:\"in=path,name=petId\"`" + `
+	Filter  string   ` + "`apikit:\"in=query,name=filter\"`" + `
+	Tags    []string ` + "`apikit:\"in=query,style=pipeDelimited,explode=false\"`" + `
+	TraceID string   ` + "`apikit:\"in=header,name=X-Trace-ID\"`" + `
+	Pet     Pet      ` + "`apikit:\"in=body\"`" + `
+
+	// in:query q
+	Search string ` + "`apikit:\"in=header,name=X-Search\"`" + `
+}
+
+// apikit:handler
+func UpdatePet(ctx context.Context, req UpdatePetRequest) (Pet, error) {
+	return req.Pet, nil
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	genericResult, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+	adapterResult, err := ExtractFromGeneric(genericResult)
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	tests := []struct {
+		field    string
+		wantIn   string
+		wantName string
+		wantBody bool
+	}{
+		{field: "ID", wantIn: "path", wantName: "petId"},
+		{field: "Filter", wantIn: "query", wantName: "filter"},
+		{field: "Tags", wantIn: "query"},
+		{field: "TraceID", wantIn: "header", wantName: "X-Trace-ID"},
+		{field: "Pet", wantIn: "body", wantBody: true},
+		// The comment takes precedence over the tag
+		{field: "Search", wantIn: "query", wantName: "q"},
+	}
+
+	for name, parsed := range map[string]*ParseResult{"parser": result, "adapter": adapterResult} {
+		fields := make(map[string]Field)
+		for _, f := range parsed.Structs["UpdatePetRequest"].Fields {
+			fields[f.Name] = f
+		}
+
+		for _, tt := range tests {
+			f := fields[tt.field]
+			if f.InComment != tt.wantIn || f.InCommentName != tt.wantName || f.IsBody != tt.wantBody {
+				t.Errorf("%s: %s: got in=%q name=%q body=%v, want in=%q name=%q body=%v",
+					name, tt.field, f.InComment, f.InCommentName, f.IsBody, tt.wantIn, tt.wantName, tt.wantBody)
+			}
+		}
+
+		tags := fields["Tags"]
+		if tags.Style != "pipeDelimited" || tags.Explode == nil || *tags.Explode {
+			t.Errorf("%s: unexpected Tags modifiers: style=%q explode=%v", name, tags.Style, tags.Explode)
+		}
+	}
+}
+
+func TestParseFile_FileFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "handler.go")
+
+	content := `package test
+
+import (
+	"context"
+	"mime/multipart"
+)
+
+type UploadRequest struct {
+	Avatar   multipart.File        ` + "`form:\"avatar\" maxfilesize:\"5MB\" accept:\"image/png, image/jpeg\"`" + `
+	Document *multipart.FileHeader ` + "`maxfilesize:\"lots\"`" + `
+	Name     string                ` + "`maxfilesize:\"1KB\"`" + `
+}
+
+// apikit:handler
+func Upload(ctx context.Context, req UploadRequest) (string, error) {
+	return "", nil
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	genericResult, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+	adapterResult, err := ExtractFromGeneric(genericResult)
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	for name, parsed := range map[string]*ParseResult{"parser": result, "adapter": adapterResult} {
+		fields := make(map[string]Field)
+		for _, f := range parsed.Structs["UploadRequest"].Fields {
+			fields[f.Name] = f
+		}
+
+		avatar := fields["Avatar"]
+		if !avatar.IsFile || avatar.MaxFileSize != 5<<20 || !slices.Equal(avatar.Accept, []string{"image/png", "image/jpeg"}) {
+			t.Errorf("%s: unexpected Avatar limits: file=%v size=%d accept=%v", name, avatar.IsFile, avatar.MaxFileSize, avatar.Accept)
+		}
+
+		// An invalid size is left for the file extractor to report
+		document := fields["Document"]
+		if !document.IsFile || document.MaxFileSize != 0 {
+			t.Errorf("%s: unexpected Document limits: file=%v size=%d", name, document.IsFile, document.MaxFileSize)
+		}
+
+		// Limits only apply to file fields
+		if nonFile := fields["Name"]; nonFile.IsFile || nonFile.MaxFileSize != 0 {
+			t.Errorf("%s: unexpected Name limits: file=%v size=%d", name, nonFile.IsFile, nonFile.MaxFileSize)
+		}
+	}
+}
+
+func TestExtractEnumComment(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    []string
+	}{
+		{comment: "// enum: available,pending,sold", want: []string{"available", "pending", "sold"}},
+		{comment: "// Enum: asc, desc", want: []string{"asc", "desc"}},
+		{comment: "/* enum:a */", want: []string{"a"}},
+		{comment: "// in:query", want: nil},
+		{comment: "// enumerates the values", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.comment, func(t *testing.T) {
+			if got := extractEnumComment(tt.comment); !slices.Equal(got, tt.want) {
+				t.Errorf("extractEnumComment(%q) = %q, want %q", tt.comment, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractOutHeaderComment(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    string
+		wantOK  bool
+	}{
+		{comment: "// out:header Location", want: "Location", wantOK: true},
+		{comment: "// out:header 'X-Total Count'", want: "X-Total Count", wantOK: true},
+		{comment: "/* out:header ETag */", want: "ETag", wantOK: true},
+		{comment: "// out:header", want: "", wantOK: true},
+		{comment: "// out:cookie session", want: "", wantOK: false},
+		{comment: "// in:header Location", want: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.comment, func(t *testing.T) {
+			got, ok := extractOutHeaderComment(tt.comment)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("extractOutHeaderComment(%q) = (%q, %v), want (%q, %v)", tt.comment, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseFile_ConsumesDirective(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "handler.go")
+
+	content := `package test
+
+import "context"
+
+// CreateUserRequest is sent as XML
+// apikit:consumes application/xml
+type CreateUserRequest struct {
+	// in:body
+	Body map[string]string
+}
+
+// CreateUser creates a user
+// apikit:handler
+// apikit:consumes application/json, application/x-www-form-urlencoded
+func CreateUser(ctx context.Context, req CreateUserRequest) (string, error) {
+	return "", nil
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	if len(result.Handlers) != 1 {
+		t.Fatalf("expected 1 handler, got %d", len(result.Handlers))
+	}
+	want := []string{"application/json", "application/x-www-form-urlencoded"}
+	if got := result.Handlers[0].Consumes; !slices.Equal(got, want) {
+		t.Errorf("expected handler Consumes %v, got %v", want, got)
+	}
+	if got := result.Structs["CreateUserRequest"].Consumes; !slices.Equal(got, []string{"application/xml"}) {
+		t.Errorf("expected struct Consumes [application/xml], got %v", got)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{input: "512", want: 512},
+		{input: "512B", want: 512},
+		{input: "64KB", want: 64 << 10},
+		{input: "64 kib", want: 64 << 10},
+		{input: "1MB", want: 1 << 20},
+		{input: "1.5mb", want: 3 << 19},
+		{input: "2G", want: 2 << 30},
+		{input: "", wantErr: true},
+		{input: "MB", wantErr: true},
+		{input: "0", wantErr: true},
+		{input: "-1KB", wantErr: true},
+		{input: "10TB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseByteSize(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseByteSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseByteSize(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseFile_ChannelReturnType(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "handler.go")
+
+	content := `package test
+
+import (
+	"context"
+
+	"github.com/reation-io/apikit"
+)
+
+type WatchRequest struct {
+	Topic string ` + "`query:\"topic\"`" + `
+}
+
+// WatchEvents streams events
+// apikit:handler
+// apikit:sse
+func WatchEvents(ctx context.Context, req WatchRequest) (<-chan apikit.SSEEvent, error) {
+	return nil, nil
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, err := New().ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	if len(result.Handlers) != 1 {
+		t.Fatalf("expected 1 handler, got %d", len(result.Handlers))
+	}
+	if got := result.Handlers[0].ReturnType; got != "<-chan apikit.SSEEvent" {
+		t.Errorf("expected return type '<-chan apikit.SSEEvent', got %q", got)
+	}
+}
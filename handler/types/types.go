@@ -7,6 +7,8 @@ package types
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -155,6 +157,125 @@ func (r *Registry) registerBuiltins() {
 	payload.%s = t
 } else {
 	return fmt.Errorf("invalid %s: %%w", err)
+}`, varName, fieldName, fieldName)
+		},
+		RequiresError: true,
+	})
+
+	// time.Duration - parsed with time.ParseDuration (e.g., "1h30m", "250ms")
+	r.Register(&Extractor{
+		TypeName: "time.Duration",
+		Import:   "time",
+		ParseFunc: func(varName, fieldName string, isPointer bool) string {
+			if isPointer {
+				return fmt.Sprintf(`if d, err := time.ParseDuration(%s); err == nil {
+	payload.%s = &d
+} else {
+	return fmt.Errorf("invalid %s: %%w", err)
+}`, varName, fieldName, fieldName)
+			}
+			return fmt.Sprintf(`if d, err := time.ParseDuration(%s); err == nil {
+	payload.%s = d
+} else {
+	return fmt.Errorf("invalid %s: %%w", err)
+}`, varName, fieldName, fieldName)
+		},
+		RequiresError: true,
+	})
+
+	// json.RawMessage - raw JSON passed through untouched
+	// Body fields get the raw bytes via json.Unmarshal; string sources are wrapped as-is
+	r.Register(&Extractor{
+		TypeName: "json.RawMessage",
+		Import:   "encoding/json",
+		ParseFunc: func(varName, fieldName string, isPointer bool) string {
+			if isPointer {
+				return fmt.Sprintf("raw := json.RawMessage(%s)\npayload.%s = &raw", varName, fieldName)
+			}
+			return fmt.Sprintf("payload.%s = json.RawMessage(%s)", fieldName, varName)
+		},
+		RequiresError: false,
+	})
+
+	// uuid.UUID - parsed with github.com/google/uuid
+	r.Register(&Extractor{
+		TypeName: "uuid.UUID",
+		Import:   "github.com/google/uuid",
+		ParseFunc: func(varName, fieldName string, isPointer bool) string {
+			if isPointer {
+				return fmt.Sprintf(`if u, err := uuid.Parse(%s); err == nil {
+	payload.%s = &u
+} else {
+	return fmt.Errorf("invalid %s: %%w", err)
+}`, varName, fieldName, fieldName)
+			}
+			return fmt.Sprintf(`if u, err := uuid.Parse(%s); err == nil {
+	payload.%s = u
+} else {
+	return fmt.Errorf("invalid %s: %%w", err)
+}`, varName, fieldName, fieldName)
+		},
+		RequiresError: true,
+	})
+
+	// decimal.Decimal - parsed with github.com/shopspring/decimal
+	r.Register(&Extractor{
+		TypeName: "decimal.Decimal",
+		Import:   "github.com/shopspring/decimal",
+		ParseFunc: func(varName, fieldName string, isPointer bool) string {
+			if isPointer {
+				return fmt.Sprintf(`if d, err := decimal.NewFromString(%s); err == nil {
+	payload.%s = &d
+} else {
+	return fmt.Errorf("invalid %s: %%w", err)
+}`, varName, fieldName, fieldName)
+			}
+			return fmt.Sprintf(`if d, err := decimal.NewFromString(%s); err == nil {
+	payload.%s = d
+} else {
+	return fmt.Errorf("invalid %s: %%w", err)
+}`, varName, fieldName, fieldName)
+		},
+		RequiresError: true,
+	})
+
+	// net.IP - parsed with net.ParseIP, which returns nil for invalid addresses
+	r.Register(&Extractor{
+		TypeName: "net.IP",
+		Import:   "net",
+		ParseFunc: func(varName, fieldName string, isPointer bool) string {
+			if isPointer {
+				return fmt.Sprintf(`if ip := net.ParseIP(%s); ip != nil {
+	payload.%s = &ip
+} else {
+	return fmt.Errorf("invalid %s: %%q is not an IP address", %s)
+}`, varName, fieldName, fieldName, varName)
+			}
+			return fmt.Sprintf(`if ip := net.ParseIP(%s); ip != nil {
+	payload.%s = ip
+} else {
+	return fmt.Errorf("invalid %s: %%q is not an IP address", %s)
+}`, varName, fieldName, fieldName, varName)
+		},
+		RequiresError: true,
+	})
+
+	// url.URL - parsed with url.Parse
+	r.Register(&Extractor{
+		TypeName: "url.URL",
+		Import:   "net/url",
+		ParseFunc: func(varName, fieldName string, isPointer bool) string {
+			if isPointer {
+				return fmt.Sprintf(`if u, err := url.Parse(%s); err == nil {
+	payload.%s = u
+} else {
+	return fmt.Errorf("invalid %s: %%w", err)
+}`, varName, fieldName, fieldName)
+			}
+			return fmt.Sprintf(`if u, err := url.Parse(%s); err == nil {
+	payload.%s = *u
+} else {
+	return fmt.Errorf("invalid %s: %%w", err)
 }`, varName, fieldName, fieldName)
 		},
 		RequiresError: true,
@@ -166,18 +287,18 @@ func (r *Registry) registerIntType(typeName string) {
 		TypeName: typeName,
 		ParseFunc: func(varName, fieldName string, isPointer bool) string {
 			if isPointer {
-				return fmt.Sprintf(`if i, err := strconv.ParseInt(%s, 10, 64); err == nil {
+				return fmt.Sprintf(`if i, err := strconv.ParseInt(%s, 10, %d); err == nil {
 	val := %s(i)
 	payload.%s = &val
 } else {
 	return fmt.Errorf("invalid %s: %%w", err)
-}`, varName, typeName, fieldName, fieldName)
+}`, varName, IntBitSize(typeName), typeName, fieldName, fieldName)
 			}
-			return fmt.Sprintf(`if i, err := strconv.ParseInt(%s, 10, 64); err == nil {
+			return fmt.Sprintf(`if i, err := strconv.ParseInt(%s, 10, %d); err == nil {
 	payload.%s = %s(i)
 } else {
 	return fmt.Errorf("invalid %s: %%w", err)
-}`, varName, fieldName, typeName, fieldName)
+}`, varName, IntBitSize(typeName), fieldName, typeName, fieldName)
 		},
 		RequiresError: true,
 	})
@@ -188,23 +309,35 @@ func (r *Registry) registerUintType(typeName string) {
 		TypeName: typeName,
 		ParseFunc: func(varName, fieldName string, isPointer bool) string {
 			if isPointer {
-				return fmt.Sprintf(`if u, err := strconv.ParseUint(%s, 10, 64); err == nil {
+				return fmt.Sprintf(`if u, err := strconv.ParseUint(%s, 10, %d); err == nil {
 	val := %s(u)
 	payload.%s = &val
 } else {
 	return fmt.Errorf("invalid %s: %%w", err)
-}`, varName, typeName, fieldName, fieldName)
+}`, varName, IntBitSize(typeName), typeName, fieldName, fieldName)
 			}
-			return fmt.Sprintf(`if u, err := strconv.ParseUint(%s, 10, 64); err == nil {
+			return fmt.Sprintf(`if u, err := strconv.ParseUint(%s, 10, %d); err == nil {
 	payload.%s = %s(u)
 } else {
 	return fmt.Errorf("invalid %s: %%w", err)
-}`, varName, fieldName, typeName, fieldName)
+}`, varName, IntBitSize(typeName), fieldName, typeName, fieldName)
 		},
 		RequiresError: true,
 	})
 }
 
+// IntBitSize returns the bit size to pass to strconv.ParseInt or strconv.ParseUint
+// for an integer type, so out-of-range values fail instead of wrapping
+// Returns 0 for int, uint and uintptr, which strconv reads as strconv.IntSize
+// Examples: "int8" -> 8, "uint32" -> 32, "int" -> 0
+func IntBitSize(typeName string) int {
+	bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typeName, "u"), "int"))
+	if err != nil {
+		return 0
+	}
+	return bits
+}
+
 func (r *Registry) registerFloatType(typeName string, bits int) {
 	r.Register(&Extractor{
 		TypeName: typeName,
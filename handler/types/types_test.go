@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -21,7 +22,8 @@ func TestNewRegistry(t *testing.T) {
 	expectedTypes := []string{
 		"string", "int", "int8", "int16", "int32", "int64",
 		"uint", "uint8", "uint16", "uint32", "uint64",
-		"float32", "float64", "bool", "time.Time",
+		"float32", "float64", "bool", "time.Time", "uuid.UUID", "json.RawMessage", "decimal.Decimal",
+		"net.IP", "url.URL", "time.Duration",
 	}
 
 	for _, typeName := range expectedTypes {
@@ -184,6 +186,29 @@ func TestUintExtractor(t *testing.T) {
 	}
 }
 
+func TestIntBitSize(t *testing.T) {
+	tests := map[string]int{
+		"int": 0, "int8": 8, "int16": 16, "int32": 32, "int64": 64,
+		"uint": 0, "uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64,
+	}
+
+	for typeName, want := range tests {
+		if got := IntBitSize(typeName); got != want {
+			t.Errorf("IntBitSize(%q) = %d, want %d", typeName, got, want)
+		}
+
+		// The registered extractors pass the same bit size
+		extractor, _ := Get(typeName)
+		if code := extractor.ParseFunc("value", "N", false); !strings.Contains(code, fmt.Sprintf("(value, 10, %d)", want)) {
+			t.Errorf("expected %s extractor to parse with bit size %d, got: %s", typeName, want, code)
+		}
+	}
+
+	if got := IntBitSize("uintptr"); got != 0 {
+		t.Errorf("IntBitSize(%q) = %d, want 0", "uintptr", got)
+	}
+}
+
 func TestFloatExtractor(t *testing.T) {
 	r := NewRegistry()
 
@@ -283,6 +308,198 @@ func TestTimeExtractor(t *testing.T) {
 	}
 }
 
+func TestRawMessageExtractor(t *testing.T) {
+	r := NewRegistry()
+	extractor, ok := r.Get("json.RawMessage")
+	if !ok {
+		t.Fatal("expected json.RawMessage extractor")
+	}
+
+	if extractor.Import != "encoding/json" {
+		t.Errorf("expected import %q, got %q", "encoding/json", extractor.Import)
+	}
+
+	// Test non-pointer
+	code := extractor.ParseFunc("value", "Filter", false)
+	if code != "payload.Filter = json.RawMessage(value)" {
+		t.Errorf("expected direct assignment, got: %s", code)
+	}
+
+	// Test pointer
+	code = extractor.ParseFunc("value", "Filter", true)
+	if !strings.Contains(code, "&raw") {
+		t.Errorf("expected pointer assignment, got: %s", code)
+	}
+
+	if extractor.RequiresError {
+		t.Error("json.RawMessage extractor should not require error handling")
+	}
+}
+
+func TestUUIDExtractor(t *testing.T) {
+	r := NewRegistry()
+	extractor, ok := r.Get("uuid.UUID")
+	if !ok {
+		t.Fatal("expected uuid.UUID extractor")
+	}
+
+	if extractor.Import != "github.com/google/uuid" {
+		t.Errorf("expected import %q, got %q", "github.com/google/uuid", extractor.Import)
+	}
+
+	// Test non-pointer
+	code := extractor.ParseFunc("value", "UserID", false)
+	if !strings.Contains(code, "uuid.Parse(value)") {
+		t.Errorf("expected uuid.Parse call, got: %s", code)
+	}
+	if !strings.Contains(code, "payload.UserID = u") {
+		t.Errorf("expected field assignment, got: %s", code)
+	}
+
+	// Test pointer
+	code = extractor.ParseFunc("value", "UserID", true)
+	if !strings.Contains(code, "uuid.Parse(value)") {
+		t.Errorf("expected uuid.Parse call, got: %s", code)
+	}
+	if !strings.Contains(code, "&u") {
+		t.Errorf("expected pointer assignment, got: %s", code)
+	}
+
+	if !extractor.RequiresError {
+		t.Error("uuid.UUID extractor should require error handling")
+	}
+}
+
+func TestDecimalExtractor(t *testing.T) {
+	r := NewRegistry()
+	extractor, ok := r.Get("decimal.Decimal")
+	if !ok {
+		t.Fatal("expected decimal.Decimal extractor")
+	}
+
+	if extractor.Import != "github.com/shopspring/decimal" {
+		t.Errorf("expected import %q, got %q", "github.com/shopspring/decimal", extractor.Import)
+	}
+
+	// Test non-pointer
+	code := extractor.ParseFunc("value", "Amount", false)
+	if !strings.Contains(code, "decimal.NewFromString(value)") {
+		t.Errorf("expected decimal.NewFromString call, got: %s", code)
+	}
+	if !strings.Contains(code, "payload.Amount = d") {
+		t.Errorf("expected field assignment, got: %s", code)
+	}
+
+	// Test pointer
+	code = extractor.ParseFunc("value", "Amount", true)
+	if !strings.Contains(code, "decimal.NewFromString(value)") {
+		t.Errorf("expected decimal.NewFromString call, got: %s", code)
+	}
+	if !strings.Contains(code, "&d") {
+		t.Errorf("expected pointer assignment, got: %s", code)
+	}
+
+	if !extractor.RequiresError {
+		t.Error("decimal.Decimal extractor should require error handling")
+	}
+}
+
+func TestDurationExtractor(t *testing.T) {
+	r := NewRegistry()
+	extractor, ok := r.Get("time.Duration")
+	if !ok {
+		t.Fatal("expected time.Duration extractor")
+	}
+
+	if extractor.Import != "time" {
+		t.Errorf("expected import %q, got %q", "time", extractor.Import)
+	}
+
+	// Test non-pointer
+	code := extractor.ParseFunc("value", "Timeout", false)
+	if !strings.Contains(code, "time.ParseDuration(value)") {
+		t.Errorf("expected time.ParseDuration call, got: %s", code)
+	}
+	if !strings.Contains(code, "payload.Timeout = d") {
+		t.Errorf("expected field assignment, got: %s", code)
+	}
+
+	// Test pointer
+	code = extractor.ParseFunc("value", "Timeout", true)
+	if !strings.Contains(code, "&d") {
+		t.Errorf("expected pointer assignment, got: %s", code)
+	}
+
+	if !extractor.RequiresError {
+		t.Error("time.Duration extractor should require error handling")
+	}
+}
+
+func TestNetIPExtractor(t *testing.T) {
+	r := NewRegistry()
+	extractor, ok := r.Get("net.IP")
+	if !ok {
+		t.Fatal("expected net.IP extractor")
+	}
+
+	if extractor.Import != "net" {
+		t.Errorf("expected import %q, got %q", "net", extractor.Import)
+	}
+
+	// Test non-pointer
+	code := extractor.ParseFunc("value", "ClientIP", false)
+	if !strings.Contains(code, "net.ParseIP(value)") {
+		t.Errorf("expected net.ParseIP call, got: %s", code)
+	}
+	if !strings.Contains(code, "ip != nil") {
+		t.Errorf("expected nil check, got: %s", code)
+	}
+	if !strings.Contains(code, "payload.ClientIP = ip") {
+		t.Errorf("expected field assignment, got: %s", code)
+	}
+
+	// Test pointer
+	code = extractor.ParseFunc("value", "ClientIP", true)
+	if !strings.Contains(code, "&ip") {
+		t.Errorf("expected pointer assignment, got: %s", code)
+	}
+
+	if !extractor.RequiresError {
+		t.Error("net.IP extractor should require error handling")
+	}
+}
+
+func TestURLExtractor(t *testing.T) {
+	r := NewRegistry()
+	extractor, ok := r.Get("url.URL")
+	if !ok {
+		t.Fatal("expected url.URL extractor")
+	}
+
+	if extractor.Import != "net/url" {
+		t.Errorf("expected import %q, got %q", "net/url", extractor.Import)
+	}
+
+	// Test non-pointer
+	code := extractor.ParseFunc("value", "Callback", false)
+	if !strings.Contains(code, "url.Parse(value)") {
+		t.Errorf("expected url.Parse call, got: %s", code)
+	}
+	if !strings.Contains(code, "payload.Callback = *u") {
+		t.Errorf("expected dereferenced assignment, got: %s", code)
+	}
+
+	// Test pointer
+	code = extractor.ParseFunc("value", "Callback", true)
+	if !strings.Contains(code, "payload.Callback = u") {
+		t.Errorf("expected pointer assignment, got: %s", code)
+	}
+
+	if !extractor.RequiresError {
+		t.Error("url.URL extractor should require error handling")
+	}
+}
+
 func TestDefaultRegistry(t *testing.T) {
 	// Test that DefaultRegistry is initialized
 	if DefaultRegistry == nil {
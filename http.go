@@ -1,9 +1,19 @@
 package apikit
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 )
 
 // HttpResponse represents an HTTP response with status code, body, headers, and content type
@@ -44,6 +54,69 @@ func (r *HttpResponse) WithContentType(contentType string) *HttpResponse {
 	return r
 }
 
+// NewFileResponse creates a file download response
+// It sets Content-Disposition to attachment with the given filename;
+// an empty content type defaults to application/octet-stream
+func NewFileResponse(status int, filename string, contentType string, data []byte) *HttpResponse {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return NewHttpResponse(status, data).
+		WithContentType(contentType).
+		WithHeader("Content-Disposition", contentDisposition(filename))
+}
+
+// ServeBytes writes binary data, honoring Range requests
+// A single range is answered with 206 Partial Content and a Content-Range header,
+// and an unsatisfiable range with 416
+// Requests for multiple ranges get the full body
+// An empty content type is left for http.ServeContent to detect
+func ServeBytes(w http.ResponseWriter, r *http.Request, contentType string, data []byte) {
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	// Advertised on every response, including 416
+	w.Header().Set("Accept-Ranges", "bytes")
+
+	// Serving several ranges would need a multipart/byteranges body
+	if strings.Contains(r.Header.Get("Range"), ",") {
+		r = r.Clone(r.Context())
+		r.Header.Del("Range")
+	}
+
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+}
+
+// dispositionEscaper escapes characters that would break a quoted-string header parameter
+var dispositionEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// contentDisposition builds an attachment Content-Disposition header value
+// Non-ASCII filenames also get an RFC 5987 filename* parameter, with an
+// ASCII-only fallback in filename
+// Example: `report "final".pdf` -> `attachment; filename="report \"final\".pdf"`
+func contentDisposition(filename string) string {
+	ascii := true
+	fallback := strings.Map(func(r rune) rune {
+		switch {
+		case r > unicode.MaxASCII:
+			ascii = false
+			return '_'
+		case !unicode.IsPrint(r):
+			// Drop control characters such as CR/LF
+			return -1
+		default:
+			return r
+		}
+	}, filename)
+
+	value := `attachment; filename="` + dispositionEscaper.Replace(fallback) + `"`
+	if !ascii {
+		value += "; filename*=UTF-8''" + url.PathEscape(filename)
+	}
+	return value
+}
+
 // statusCoder interface for errors that include their own status code
 type statusCoder interface {
 	StatusCode() int
@@ -67,32 +140,123 @@ func writeJSONWithStatus(w http.ResponseWriter, status int, data any) {
 	}
 }
 
+// WriteJSONWithETag writes a JSON response with a strong ETag computed from the body
+// If the request's If-None-Match header matches the ETag, it responds with
+// 304 Not Modified and no body instead
+func WriteJSONWithETag(w http.ResponseWriter, r *http.Request, data any) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		writeError(w, fmt.Errorf("encoding response: %w", err), http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	w.Header().Set("ETag", etag)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	writeJSONWithStatus(w, http.StatusOK, json.RawMessage(body))
+}
+
+// etagMatches reports whether an If-None-Match header value matches the ETag
+// The header may list several ETags or "*"; weak ETags compare by their value
+// Example: `W/"abc", "def"` matches `"def"`
+func etagMatches(ifNoneMatch, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// validStatus returns status, defaulting to 500 Internal Server Error for codes
+// outside 100-999, on which WriteHeader panics
+// Example: validStatus(0) -> 500, validStatus(404) -> 404
+func validStatus(status int) int {
+	if status < 100 || status > 999 {
+		return http.StatusInternalServerError
+	}
+	return status
+}
+
 // writeError writes an error response with the given status code
 func writeError(w http.ResponseWriter, err error, status int) {
+	writeErrorWithRequestID(w, err, status, "")
+}
+
+// writeErrorWithRequestID writes an error response with the given status code
+// A non-empty requestID is added to the body unless the error already carries one
+func writeErrorWithRequestID(w http.ResponseWriter, err error, status int, requestID string) {
+	status = validStatus(status)
+
+	apiErr, isAPIErr := err.(*Error)
+	if isAPIErr && ((requestID != "" && apiErr.RequestID == "") || apiErr.Code != status) {
+		// Copy so shared error values are not modified
+		normalized := *apiErr
+		if normalized.RequestID == "" {
+			normalized.RequestID = requestID
+		}
+		normalized.Code = status
+		apiErr = &normalized
+	}
+
+	if isAPIErr && apiErr.retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(apiErr.retryAfter))
+	}
+
+	if errorFormat == FormatProblemJSON {
+		if !isAPIErr {
+			apiErr = &Error{Code: status, Message: err.Error(), RequestID: requestID}
+		}
+		WriteProblem(w, apiErr)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
 	// Check if it's the custom Error type
-	if apiErr, ok := err.(*Error); ok {
+	if isAPIErr {
 		json.NewEncoder(w).Encode(apiErr)
 		return
 	}
 
 	// Default error format
-	json.NewEncoder(w).Encode(map[string]any{
+	body := map[string]any{
 		"error": err.Error(),
-	})
+	}
+	if requestID != "" {
+		body["requestId"] = requestID
+	}
+	json.NewEncoder(w).Encode(body)
 }
 
 // HandleError handles errors with custom status codes
 func HandleError(w http.ResponseWriter, err error) {
+	handleError(w, err, "")
+}
+
+// HandleErrorCtx handles errors like HandleError, adding the request ID
+// stored in ctx by the RequestID middleware to the error body
+func HandleErrorCtx(ctx context.Context, w http.ResponseWriter, err error) {
+	handleError(w, err, RequestIDFromContext(ctx))
+}
+
+// handleError writes err with its status code, defaulting to 500 Internal Server Error
+func handleError(w http.ResponseWriter, err error, requestID string) {
 	if sc, ok := err.(statusCoder); ok {
-		writeError(w, err, sc.StatusCode())
+		writeErrorWithRequestID(w, err, sc.StatusCode(), requestID)
 		return
 	}
 
 	// Default to 500 Internal Server Error
-	writeError(w, err, http.StatusInternalServerError)
+	writeErrorWithRequestID(w, err, http.StatusInternalServerError, requestID)
 }
 
 // HandleResponse handles both the response and error from a handler
@@ -149,8 +313,48 @@ func HandleResponse(w http.ResponseWriter, response any, err error) {
 				}
 			}
 		}
-	} else {
+	} else if !plainResponses || !writePlain(w, response) {
 		// Default: write JSON with 200 OK
 		writeJSONWithStatus(w, http.StatusOK, response)
 	}
 }
+
+// HandleResponseCtx handles the response like HandleResponse, adding the request ID
+// stored in ctx by the RequestID middleware to error bodies
+func HandleResponseCtx(ctx context.Context, w http.ResponseWriter, response any, err error) {
+	if err != nil {
+		HandleErrorCtx(ctx, w, err)
+		return
+	}
+	HandleResponse(w, response, nil)
+}
+
+// plainResponses reports whether HandleResponse writes bare strings and
+// byte slices as-is instead of JSON-encoding them
+var plainResponses = false
+
+// SetPlainResponses makes HandleResponse write a bare string response as
+// text/plain and a bare []byte response as application/octet-stream
+// By default both are JSON-encoded, as a quoted string and a base64 string
+// It should be called once during application startup
+func SetPlainResponses(enabled bool) {
+	plainResponses = enabled
+}
+
+// writePlain writes string and []byte responses with a 200 status
+// Returns false for other response types
+func writePlain(w http.ResponseWriter, response any) bool {
+	switch v := response.(type) {
+	case string:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, v)
+	case []byte:
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		w.Write(v)
+	default:
+		return false
+	}
+	return true
+}
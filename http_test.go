@@ -1,10 +1,12 @@
 package apikit
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -161,6 +163,16 @@ func TestHandleError(t *testing.T) {
 			err:            errors.New("generic error"),
 			expectedStatus: 500,
 		},
+		{
+			name:           "zero status code defaults to 500",
+			err:            &Error{Message: "no code"},
+			expectedStatus: 500,
+		},
+		{
+			name:           "out of range status code defaults to 500",
+			err:            NewError(1000, "too large"),
+			expectedStatus: 500,
+		},
 	}
 
 	for _, tt := range tests {
@@ -175,6 +187,31 @@ func TestHandleError(t *testing.T) {
 	}
 }
 
+func TestHandleError_RetryAfter(t *testing.T) {
+	defer SetErrorFormat(FormatJSON)
+
+	for _, format := range []ErrorFormat{FormatJSON, FormatProblemJSON} {
+		SetErrorFormat(format)
+
+		w := httptest.NewRecorder()
+		HandleError(w, TooManyRequests("rate limit exceeded").WithRetryAfter(120))
+
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("format %v: expected status %d, got %d", format, http.StatusTooManyRequests, w.Code)
+		}
+		if got := w.Header().Get("Retry-After"); got != "120" {
+			t.Errorf("format %v: expected Retry-After 120, got %q", format, got)
+		}
+	}
+
+	// Errors without a retry delay don't send the header
+	w := httptest.NewRecorder()
+	HandleError(w, TooManyRequests("rate limit exceeded"))
+	if got := w.Header().Get("Retry-After"); got != "" {
+		t.Errorf("expected no Retry-After header, got %q", got)
+	}
+}
+
 func TestHandleResponse(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -235,6 +272,86 @@ func TestHandleResponse(t *testing.T) {
 	}
 }
 
+func TestHandleResponse_PlainResponses(t *testing.T) {
+	tests := []struct {
+		name            string
+		plain           bool
+		response        any
+		err             error
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "string as text",
+			plain:           true,
+			response:        "hello",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "hello",
+		},
+		{
+			name:            "bytes as octet stream",
+			plain:           true,
+			response:        []byte{0x01, 0x02},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/octet-stream",
+			wantBody:        "\x01\x02",
+		},
+		{
+			name:            "other types stay JSON",
+			plain:           true,
+			response:        map[string]string{"status": "ok"},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `{"status":"ok"}` + "\n",
+		},
+		{
+			name:            "errors take precedence",
+			plain:           true,
+			response:        "ignored",
+			err:             NewError(http.StatusNotFound, "not found"),
+			wantStatus:      http.StatusNotFound,
+			wantContentType: "application/json",
+			wantBody:        `{"code":404,"errorCode":"Not Found","message":"not found"}` + "\n",
+		},
+		{
+			name:            "string as JSON by default",
+			response:        "hello",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `"hello"` + "\n",
+		},
+		{
+			name:            "bytes as JSON by default",
+			response:        []byte("hi"),
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `"aGk="` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetPlainResponses(tt.plain)
+			defer SetPlainResponses(false)
+
+			w := httptest.NewRecorder()
+			HandleResponse(w, tt.response, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("expected Content-Type %q, got %q", tt.wantContentType, got)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("expected body %q, got %q", tt.wantBody, got)
+			}
+		})
+	}
+}
+
 func TestStatusCoder(t *testing.T) {
 	// Test that Error implements statusCoder interface
 	var _ statusCoder = (*Error)(nil)
@@ -469,3 +586,183 @@ func TestHttpResponse_ValueNoBody(t *testing.T) {
 		t.Errorf("Expected empty body, got %s", w.Body.String())
 	}
 }
+
+func TestNewFileResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+	data := []byte{0x25, 0x50, 0x44, 0x46, 0x00, 0xFF}
+	resp := NewFileResponse(http.StatusOK, "report.pdf", "application/pdf", data)
+
+	HandleResponse(w, resp, nil)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/pdf" {
+		t.Errorf("Expected Content-Type application/pdf, got %s", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); cd != `attachment; filename="report.pdf"` {
+		t.Errorf("Expected attachment Content-Disposition, got %s", cd)
+	}
+	if !bytes.Equal(w.Body.Bytes(), data) {
+		t.Errorf("Expected body %v, got %v", data, w.Body.Bytes())
+	}
+}
+
+func TestNewFileResponse_DefaultContentType(t *testing.T) {
+	resp := NewFileResponse(http.StatusOK, "data.bin", "", []byte("raw"))
+
+	if resp.ContentType != "application/octet-stream" {
+		t.Errorf("Expected default content type application/octet-stream, got %s", resp.ContentType)
+	}
+}
+
+func TestContentDisposition(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"report.pdf", `attachment; filename="report.pdf"`},
+		{`my "final" report.pdf`, `attachment; filename="my \"final\" report.pdf"`},
+		{`back\slash.txt`, `attachment; filename="back\\slash.txt"`},
+		{"evil\r\nX-Injected: 1.txt", `attachment; filename="evilX-Injected: 1.txt"`},
+		{"résumé.pdf", `attachment; filename="r_sum_.pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9.pdf`},
+	}
+
+	for _, tt := range tests {
+		if got := contentDisposition(tt.filename); got != tt.want {
+			t.Errorf("contentDisposition(%q) = %s, want %s", tt.filename, got, tt.want)
+		}
+	}
+}
+
+func TestWriteJSONWithETag_Miss(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/users/1", nil)
+
+	WriteJSONWithETag(w, r, map[string]any{"id": 1, "name": "Ada"})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+	if got := w.Body.String(); got != "{\"id\":1,\"name\":\"Ada\"}\n" {
+		t.Errorf("unexpected body %q", got)
+	}
+
+	etag := w.Header().Get("ETag")
+	if len(etag) != 66 || !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) {
+		t.Errorf("expected quoted sha256 ETag, got %q", etag)
+	}
+
+	// Same data produces the same ETag
+	w2 := httptest.NewRecorder()
+	WriteJSONWithETag(w2, r, map[string]any{"name": "Ada", "id": 1})
+	if got := w2.Header().Get("ETag"); got != etag {
+		t.Errorf("expected stable ETag %q, got %q", etag, got)
+	}
+}
+
+func TestWriteJSONWithETag_Hit(t *testing.T) {
+	data := map[string]any{"id": 1, "name": "Ada"}
+
+	w := httptest.NewRecorder()
+	WriteJSONWithETag(w, httptest.NewRequest("GET", "/users/1", nil), data)
+	etag := w.Header().Get("ETag")
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{name: "exact match", ifNoneMatch: etag, wantStatus: http.StatusNotModified},
+		{name: "weak match in list", ifNoneMatch: `"other", W/` + etag, wantStatus: http.StatusNotModified},
+		{name: "wildcard", ifNoneMatch: "*", wantStatus: http.StatusNotModified},
+		{name: "stale", ifNoneMatch: `"stale"`, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/users/1", nil)
+			r.Header.Set("If-None-Match", tt.ifNoneMatch)
+
+			WriteJSONWithETag(w, r, data)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("ETag"); got != etag {
+				t.Errorf("expected ETag %q, got %q", etag, got)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() > 0 {
+				t.Errorf("expected empty body, got %q", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestServeBytes(t *testing.T) {
+	data := []byte("0123456789")
+
+	tests := []struct {
+		name         string
+		rangeHeader  string
+		wantStatus   int
+		wantBody     string
+		contentRange string
+	}{
+		{
+			name:       "full body",
+			wantStatus: http.StatusOK,
+			wantBody:   "0123456789",
+		},
+		{
+			name:         "single range",
+			rangeHeader:  "bytes=2-5",
+			wantStatus:   http.StatusPartialContent,
+			wantBody:     "2345",
+			contentRange: "bytes 2-5/10",
+		},
+		{
+			name:        "multiple ranges fall back to full body",
+			rangeHeader: "bytes=0-1,4-5",
+			wantStatus:  http.StatusOK,
+			wantBody:    "0123456789",
+		},
+		{
+			name:         "unsatisfiable range",
+			rangeHeader:  "bytes=20-30",
+			wantStatus:   http.StatusRequestedRangeNotSatisfiable,
+			contentRange: "bytes */10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/media", nil)
+			if tt.rangeHeader != "" {
+				r.Header.Set("Range", tt.rangeHeader)
+			}
+
+			ServeBytes(w, r, "video/mp4", data)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Accept-Ranges"); got != "bytes" {
+				t.Errorf("Expected Accept-Ranges bytes, got %q", got)
+			}
+			if got := w.Header().Get("Content-Range"); got != tt.contentRange {
+				t.Errorf("Expected Content-Range %q, got %q", tt.contentRange, got)
+			}
+			if tt.wantBody != "" {
+				if got := w.Body.String(); got != tt.wantBody {
+					t.Errorf("Expected body %q, got %q", tt.wantBody, got)
+				}
+				if ct := w.Header().Get("Content-Type"); ct != "video/mp4" {
+					t.Errorf("Expected Content-Type video/mp4, got %s", ct)
+				}
+			}
+		})
+	}
+}
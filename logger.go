@@ -0,0 +1,85 @@
+package apikit
+
+import (
+	"net/http"
+	"time"
+)
+
+// Logger receives events from handlers generated with the apikit:log directive
+type Logger interface {
+	// LogRequest is called once the handler has written its response
+	LogRequest(r *http.Request, status int, duration time.Duration)
+
+	// LogError is called when the handler returns an error
+	LogError(r *http.Request, err error)
+}
+
+// nopLogger discards all events
+type nopLogger struct{}
+
+func (nopLogger) LogRequest(*http.Request, int, time.Duration) {}
+func (nopLogger) LogError(*http.Request, error)                {}
+
+// logger is the Logger used by generated handlers
+var logger Logger = nopLogger{}
+
+// SetLogger sets the Logger used by generated handlers
+// Passing nil restores the no-op default
+// It should be called once during application startup
+func SetLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+	logger = l
+}
+
+// StartRequestLog wraps w to record the response status and returns a function
+// reporting the request to the Logger when called (typically deferred)
+func StartRequestLog(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w}
+	return rec, func() {
+		status := rec.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		logger.LogRequest(r, status, time.Since(start))
+	}
+}
+
+// LogError reports a handler error to the Logger
+func LogError(r *http.Request, err error) {
+	logger.LogError(r, err)
+}
+
+// statusRecorder is a ResponseWriter remembering the status code written
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if s.status == 0 {
+		s.status = code
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	return s.ResponseWriter.Write(b)
+}
+
+// Flush keeps streaming responses (e.g. SSE) working through the recorder
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
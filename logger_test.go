@@ -0,0 +1,90 @@
+package apikit
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeLogger captures the events reported to it
+type fakeLogger struct {
+	statuses []int
+	errs     []error
+}
+
+func (f *fakeLogger) LogRequest(r *http.Request, status int, duration time.Duration) {
+	f.statuses = append(f.statuses, status)
+}
+
+func (f *fakeLogger) LogError(r *http.Request, err error) {
+	f.errs = append(f.errs, err)
+}
+
+func TestStartRequestLog(t *testing.T) {
+	fake := &fakeLogger{}
+	SetLogger(fake)
+	defer SetLogger(nil)
+
+	tests := []struct {
+		name       string
+		write      func(w http.ResponseWriter)
+		wantStatus int
+	}{
+		{name: "explicit status", write: func(w http.ResponseWriter) { w.WriteHeader(http.StatusCreated) }, wantStatus: http.StatusCreated},
+		{name: "implicit status on write", write: func(w http.ResponseWriter) { _, _ = w.Write([]byte("ok")) }, wantStatus: http.StatusOK},
+		{name: "nothing written", write: func(w http.ResponseWriter) {}, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.statuses = nil
+			rec := httptest.NewRecorder()
+			w, done := StartRequestLog(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			tt.write(w)
+			done()
+
+			if len(fake.statuses) != 1 || fake.statuses[0] != tt.wantStatus {
+				t.Errorf("logged statuses = %v, want [%d]", fake.statuses, tt.wantStatus)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("response status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestStartRequestLog_Flush(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w, _ := StartRequestLog(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		t.Fatal("wrapped writer should implement http.Flusher")
+	}
+	flusher.Flush()
+	if !rec.Flushed {
+		t.Error("Flush should reach the underlying writer")
+	}
+}
+
+func TestLogError(t *testing.T) {
+	fake := &fakeLogger{}
+	SetLogger(fake)
+	defer SetLogger(nil)
+
+	err := errors.New("boom")
+	LogError(httptest.NewRequest(http.MethodGet, "/", nil), err)
+
+	if len(fake.errs) != 1 || fake.errs[0] != err {
+		t.Errorf("logged errors = %v, want [%v]", fake.errs, err)
+	}
+
+	// The default logger discards events
+	SetLogger(nil)
+	LogError(httptest.NewRequest(http.MethodGet, "/", nil), err)
+	if len(fake.errs) != 1 {
+		t.Error("events should not reach a replaced logger")
+	}
+}
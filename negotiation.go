@@ -0,0 +1,124 @@
+package apikit
+
+import (
+	"encoding/xml"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// WriteXML writes an XML response with default 200 OK status
+func WriteXML(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/xml")
+	if err := xml.NewEncoder(w).Encode(data); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// writeXMLWithStatus writes an XML response with a specific status code
+func writeXMLWithStatus(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	if err := xml.NewEncoder(w).Encode(data); err != nil {
+		// Status already written, can't change it
+		return
+	}
+}
+
+// HandleResponseNegotiated handles the response and error from a handler,
+// choosing JSON or XML based on the request's Accept header
+// JSON is used for empty Accept headers, */* and application/*
+// Responds with 406 Not Acceptable when no supported type is accepted
+func HandleResponseNegotiated(w http.ResponseWriter, r *http.Request, response any, err error) {
+	contentType, ok := NegotiateContentType(r.Header.Get("Accept"))
+	if !ok {
+		HandleError(w, NotAcceptable("supported content types: application/json, application/xml"))
+		return
+	}
+
+	if err != nil || contentType == "application/json" {
+		HandleResponse(w, response, err)
+		return
+	}
+
+	var httpResp *HttpResponse
+	if ptr, ok := response.(*HttpResponse); ok {
+		httpResp = ptr
+	} else if val, ok := response.(HttpResponse); ok {
+		httpResp = &val
+	}
+
+	if httpResp == nil {
+		writeXMLWithStatus(w, http.StatusOK, response)
+		return
+	}
+
+	// Responses with a custom (non-JSON) content type are written as-is
+	if httpResp.ContentType != "" && httpResp.ContentType != "application/json" {
+		HandleResponse(w, httpResp, nil)
+		return
+	}
+
+	for key, value := range httpResp.Headers {
+		w.Header().Set(key, value)
+	}
+	if httpResp.Body == nil {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(httpResp.StatusCode)
+		return
+	}
+	writeXMLWithStatus(w, httpResp.StatusCode, httpResp.Body)
+}
+
+// NegotiateContentType selects the response content type for an Accept header
+// Returns "application/json" or "application/xml", and false if neither is acceptable
+func NegotiateContentType(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return "application/json", true
+	}
+
+	type mediaRange struct {
+		mediaType string
+		q         float64
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		mr := mediaRange{mediaType: strings.ToLower(strings.TrimSpace(mediaType)), q: 1}
+		for _, param := range strings.Split(params, ";") {
+			if key, value, ok := strings.Cut(strings.TrimSpace(param), "="); ok && key == "q" {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					mr.q = q
+				}
+			}
+		}
+		if mr.q > 0 {
+			ranges = append(ranges, mr)
+		}
+	}
+
+	// Highest quality first, keeping the client's order for ties
+	slices.SortStableFunc(ranges, func(a, b mediaRange) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for _, mr := range ranges {
+		switch mr.mediaType {
+		case "application/json", "application/*", "*/*":
+			return "application/json", true
+		case "application/xml", "text/xml":
+			return "application/xml", true
+		}
+	}
+
+	return "", false
+}
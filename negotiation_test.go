@@ -0,0 +1,124 @@
+package apikit
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type negotiationPet struct {
+	XMLName xml.Name `json:"-" xml:"pet"`
+	Name    string   `json:"name" xml:"name"`
+}
+
+func TestWriteXML(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteXML(w, negotiationPet{Name: "doggie"})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/xml" {
+		t.Errorf("expected Content-Type 'application/xml', got %q", ct)
+	}
+	if body := w.Body.String(); body != "<pet><name>doggie</name></pet>" {
+		t.Errorf("unexpected body: %s", body)
+	}
+}
+
+func TestNegotiateContentType(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected string
+		ok       bool
+	}{
+		{accept: "", expected: "application/json", ok: true},
+		{accept: "*/*", expected: "application/json", ok: true},
+		{accept: "application/json", expected: "application/json", ok: true},
+		{accept: "application/xml", expected: "application/xml", ok: true},
+		{accept: "text/xml", expected: "application/xml", ok: true},
+		{accept: "text/html, application/xml;q=0.9, */*;q=0.8", expected: "application/xml", ok: true},
+		{accept: "application/json;q=0.5, application/xml", expected: "application/xml", ok: true},
+		{accept: "application/xml;q=0, application/json", expected: "application/json", ok: true},
+		{accept: "text/csv", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			contentType, ok := NegotiateContentType(tt.accept)
+			if ok != tt.ok {
+				t.Fatalf("expected ok=%v, got %v", tt.ok, ok)
+			}
+			if contentType != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, contentType)
+			}
+		})
+	}
+}
+
+func TestHandleResponseNegotiated(t *testing.T) {
+	tests := []struct {
+		name         string
+		accept       string
+		response     any
+		expectedCode int
+		expectedType string
+		expectedBody string
+	}{
+		{
+			name:         "accept xml",
+			accept:       "application/xml",
+			response:     negotiationPet{Name: "doggie"},
+			expectedCode: http.StatusOK,
+			expectedType: "application/xml",
+			expectedBody: "<pet><name>doggie</name></pet>",
+		},
+		{
+			name:         "accept json",
+			accept:       "application/json",
+			response:     negotiationPet{Name: "doggie"},
+			expectedCode: http.StatusOK,
+			expectedType: "application/json",
+			expectedBody: `{"name":"doggie"}`,
+		},
+		{
+			name:         "accept xml with HttpResponse",
+			accept:       "application/xml",
+			response:     NewHttpResponse(http.StatusCreated, negotiationPet{Name: "doggie"}),
+			expectedCode: http.StatusCreated,
+			expectedType: "application/xml",
+			expectedBody: "<pet><name>doggie</name></pet>",
+		},
+		{
+			name:         "unsupported type",
+			accept:       "text/csv",
+			response:     negotiationPet{Name: "doggie"},
+			expectedCode: http.StatusNotAcceptable,
+			expectedType: "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/pets/1", nil)
+			r.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+
+			HandleResponseNegotiated(w, r, tt.response, nil)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("expected status %d, got %d", tt.expectedCode, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != tt.expectedType {
+				t.Errorf("expected Content-Type %q, got %q", tt.expectedType, ct)
+			}
+			if tt.expectedBody != "" {
+				if body := strings.TrimSpace(w.Body.String()); body != tt.expectedBody {
+					t.Errorf("expected body %s, got %s", tt.expectedBody, body)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package builder
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	coreast "github.com/reation-io/apikit/core/ast"
@@ -26,6 +29,10 @@ func ExtractFromGeneric(results []*coreast.ParseResult) (*spec.OpenAPI, error) {
 		},
 	}
 
+	resolver := newTypeResolver(resultFilenames(results))
+	resolver.addStructs(results)
+	operationIDs := spec.NewOperationIDs()
+
 	for _, result := range results {
 		// Process swagger:meta
 		if err := extractMeta(result, openapi); err != nil {
@@ -33,16 +40,26 @@ func ExtractFromGeneric(results []*coreast.ParseResult) (*spec.OpenAPI, error) {
 		}
 
 		// Process swagger:route
-		if err := extractRoutes(result, openapi); err != nil {
+		if err := extractRoutes(result, openapi, resolver, operationIDs); err != nil {
 			return nil, fmt.Errorf("failed to extract routes from %s: %w", result.Filename, err)
 		}
 
 		// Process swagger:model
-		if err := extractModels(result, openapi); err != nil {
+		if err := extractModels(result, openapi, resolver); err != nil {
 			return nil, fmt.Errorf("failed to extract models from %s: %w", result.Filename, err)
 		}
 	}
 
+	// operationIds must be unique across the spec
+	if err := duplicateOperationIDs(operationIDs); err != nil {
+		return nil, err
+	}
+
+	// Emit structs referenced by models but not annotated themselves
+	if openapi.Components != nil {
+		addReferencedModels(openapi.Components.Schemas, collectStructs(results), resolver)
+	}
+
 	return openapi, nil
 }
 
@@ -132,12 +149,29 @@ func ExtractMultipleFromGeneric(results []*coreast.ParseResult) (map[string]*spe
 	}
 
 	// Second pass: extract routes and distribute them
+	resolver := newTypeResolver(resultFilenames(results))
+	resolver.addStructs(results)
+	operationIDs := make(map[string]*spec.OperationIDs)
 	for _, result := range results {
-		if err := extractRoutesMulti(result, specs); err != nil {
+		if err := extractRoutesMulti(result, specs, resolver, operationIDs); err != nil {
 			return nil, err
 		}
 	}
 
+	// operationIds must be unique within each spec
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(operationIDs)) {
+		if err := duplicateOperationIDs(operationIDs[name]); err != nil {
+			errs = append(errs, fmt.Errorf("spec %q: %w", name, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	// Untagged security schemes are shared so operations in any spec can reference them
+	shareSecuritySchemes(specs)
+
 	// Third pass: extract models (shared across all specs)
 	allModels := make(map[string]*spec.Schema)
 	for _, result := range results {
@@ -146,27 +180,23 @@ func ExtractMultipleFromGeneric(results []*coreast.ParseResult) (map[string]*spe
 				continue
 			}
 
-			schema := convertStructToSchema(s)
+			schema := convertStructToSchema(s, resolver)
 
-			// Parse field tags
-			for _, field := range s.Fields {
-				if field.Doc != nil || field.Comment != nil {
-					fieldSchema := schema.Properties[getJSONName(field)]
-					if fieldSchema != nil {
-						if field.Doc != nil {
-							parsers.GlobalRegistry().Parse("swagger:model", field.Doc, fieldSchema, parsers.ContextField)
-						}
-						if field.Comment != nil {
-							parsers.GlobalRegistry().Parse("swagger:model", field.Comment, fieldSchema, parsers.ContextField)
-						}
-					}
-				}
+			// Parse model tags (oneOf, anyOf, allOf)
+			if err := parsers.GlobalRegistry().Parse("swagger:model", s.Doc, schema, parsers.ContextModel); err != nil {
+				return nil, fmt.Errorf("failed to parse model %s: %w", s.Name, err)
 			}
 
+			// Parse field tags
+			applyFieldTags(s, schema)
+
 			allModels[s.Name] = schema
 		}
 	}
 
+	// Emit structs referenced by models but not annotated themselves
+	addReferencedModels(allModels, collectStructs(results), resolver)
+
 	// Add models to all specs
 	for _, openapi := range specs {
 		if len(allModels) > 0 {
@@ -185,6 +215,44 @@ func ExtractMultipleFromGeneric(results []*coreast.ParseResult) (map[string]*spe
 	return specs, nil
 }
 
+// shareSecuritySchemes copies the security schemes of meta blocks without a
+// Spec: tag into every spec that doesn't define a scheme with the same name
+// Schemes from Spec:-tagged meta blocks stay in the specs they name
+func shareSecuritySchemes(specs map[string]*spec.OpenAPI) {
+	allSchemes := make(map[string]*spec.SecurityScheme)
+	if components := specs["default"].Components; components != nil {
+		maps.Copy(allSchemes, components.SecuritySchemes)
+	}
+	if len(allSchemes) == 0 {
+		return
+	}
+
+	for _, openapi := range specs {
+		if openapi.Components == nil {
+			openapi.Components = &spec.Components{}
+		}
+		if openapi.Components.SecuritySchemes == nil {
+			openapi.Components.SecuritySchemes = make(map[string]*spec.SecurityScheme)
+		}
+		for name, scheme := range allSchemes {
+			if _, ok := openapi.Components.SecuritySchemes[name]; !ok {
+				openapi.Components.SecuritySchemes[name] = scheme
+			}
+		}
+	}
+}
+
+// resultFilenames returns the source files of the given parse results
+func resultFilenames(results []*coreast.ParseResult) []string {
+	filenames := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Filename != "" {
+			filenames = append(filenames, result.Filename)
+		}
+	}
+	return filenames
+}
+
 // extractMeta extracts swagger:meta information
 func extractMeta(result *coreast.ParseResult, openapi *spec.OpenAPI) error {
 	for _, s := range result.Structs {
@@ -211,7 +279,7 @@ func extractMeta(result *coreast.ParseResult, openapi *spec.OpenAPI) error {
 }
 
 // extractRoutes extracts swagger:route information
-func extractRoutes(result *coreast.ParseResult, openapi *spec.OpenAPI) error {
+func extractRoutes(result *coreast.ParseResult, openapi *spec.OpenAPI, resolver *typeResolver, operationIDs *spec.OperationIDs) error {
 	for _, s := range result.Structs {
 		if !hasDirective(s.Doc, "swagger:route") {
 			continue
@@ -227,6 +295,8 @@ func extractRoutes(result *coreast.ParseResult, openapi *spec.OpenAPI) error {
 		operation := &spec.Operation{
 			OperationID: routeInfo.OperationID,
 			Tags:        []string{routeInfo.Tag},
+			Parameters:  extractParameters(s, resolver),
+			RequestBody: extractRequestBody(s, resolver),
 			Responses: &spec.Responses{
 				StatusCodeResponses: make(map[string]*spec.Response),
 			},
@@ -238,6 +308,9 @@ func extractRoutes(result *coreast.ParseResult, openapi *spec.OpenAPI) error {
 				return err
 			}
 		}
+		applyProduces(operation)
+
+		operationIDs.Add(operation.OperationID, operationLocation(s.Pos, routeInfo.Method, routeInfo.Path))
 
 		// Add operation to path
 		if openapi.Paths.PathItems[routeInfo.Path] == nil {
@@ -267,7 +340,8 @@ func extractRoutes(result *coreast.ParseResult, openapi *spec.OpenAPI) error {
 }
 
 // extractRoutesMulti extracts swagger:route information and distributes to multiple specs
-func extractRoutesMulti(result *coreast.ParseResult, specs map[string]*spec.OpenAPI) error {
+// operationIDs collects the operationIds of each spec by spec name
+func extractRoutesMulti(result *coreast.ParseResult, specs map[string]*spec.OpenAPI, resolver *typeResolver, operationIDs map[string]*spec.OperationIDs) error {
 	for _, s := range result.Structs {
 		if !hasDirective(s.Doc, "swagger:route") {
 			continue
@@ -283,6 +357,8 @@ func extractRoutesMulti(result *coreast.ParseResult, specs map[string]*spec.Open
 		operation := &spec.Operation{
 			OperationID: routeInfo.OperationID,
 			Tags:        []string{routeInfo.Tag},
+			Parameters:  extractParameters(s, resolver),
+			RequestBody: extractRequestBody(s, resolver),
 			Responses: &spec.Responses{
 				StatusCodeResponses: make(map[string]*spec.Response),
 			},
@@ -294,6 +370,7 @@ func extractRoutesMulti(result *coreast.ParseResult, specs map[string]*spec.Open
 				return err
 			}
 		}
+		applyProduces(operation)
 
 		// Get spec names from operation extensions
 		var specNames []string
@@ -326,6 +403,11 @@ func extractRoutesMulti(result *coreast.ParseResult, specs map[string]*spec.Open
 
 			targetSpec := specs[specName]
 
+			if operationIDs[specName] == nil {
+				operationIDs[specName] = spec.NewOperationIDs()
+			}
+			operationIDs[specName].Add(operation.OperationID, operationLocation(s.Pos, routeInfo.Method, routeInfo.Path))
+
 			// Clone operation to avoid sharing references
 			clonedOp := cloneOperationForAdapter(operation)
 
@@ -410,33 +492,58 @@ func cloneOperationForAdapter(op *spec.Operation) *spec.Operation {
 	return cloned
 }
 
+// extractParameters builds operation parameters from the fields of a route struct
+func extractParameters(s *coreast.Struct, resolver *typeResolver) []*spec.Parameter {
+	resolver = resolver.forStruct(s)
+	var params []*spec.Parameter
+	for _, field := range s.Fields {
+		if field.IsEmbedded {
+			continue
+		}
+
+		name, _ := getJSONName(field)
+		if name == "-" {
+			continue
+		}
+
+		schema := typeToSchema(field.Type, field.IsPointer, field.IsSlice, resolver)
+		if param := buildParameter(name, field.Tag, schema, field.Doc, field.Comment); param != nil {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// extractRequestBody builds the operation request body from the "in: body" field of a route struct
+func extractRequestBody(s *coreast.Struct, resolver *typeResolver) *spec.RequestBody {
+	resolver = resolver.forStruct(s)
+	for _, field := range s.Fields {
+		schema := typeToSchema(field.Type, field.IsPointer, field.IsSlice, resolver)
+		if body := buildRequestBody(schema, field.Doc, field.Comment); body != nil {
+			return body
+		}
+	}
+	return nil
+}
+
 // extractModels extracts swagger:model information
-func extractModels(result *coreast.ParseResult, openapi *spec.OpenAPI) error {
+func extractModels(result *coreast.ParseResult, openapi *spec.OpenAPI, resolver *typeResolver) error {
 	for _, s := range result.Structs {
 		if !hasDirective(s.Doc, "swagger:model") {
 			continue
 		}
 
 		// Convert struct to schema
-		schema := convertStructToSchema(s)
+		schema := convertStructToSchema(s, resolver)
 
-		// Parse field tags
-		for i, field := range s.Fields {
-			if field.Doc != nil || field.Comment != nil {
-				fieldSchema := schema.Properties[getJSONName(field)]
-				if fieldSchema != nil {
-					// Parse field documentation
-					if field.Doc != nil {
-						parsers.GlobalRegistry().Parse("swagger:model", field.Doc, fieldSchema, parsers.ContextField)
-					}
-					if field.Comment != nil {
-						parsers.GlobalRegistry().Parse("swagger:model", field.Comment, fieldSchema, parsers.ContextField)
-					}
-				}
-			}
-			_ = i // unused
+		// Parse model tags (oneOf, anyOf, allOf)
+		if err := parsers.GlobalRegistry().Parse("swagger:model", s.Doc, schema, parsers.ContextModel); err != nil {
+			return fmt.Errorf("failed to parse model %s: %w", s.Name, err)
 		}
 
+		// Parse field tags
+		applyFieldTags(s, schema)
+
 		// Add to components
 		if openapi.Components == nil {
 			openapi.Components = &spec.Components{
@@ -449,35 +556,182 @@ func extractModels(result *coreast.ParseResult, openapi *spec.OpenAPI) error {
 	return nil
 }
 
+// collectStructs indexes the structs of all parse results by name
+func collectStructs(results []*coreast.ParseResult) map[string]*coreast.Struct {
+	structs := make(map[string]*coreast.Struct)
+	for _, result := range results {
+		for name, s := range result.Structs {
+			structs[name] = s
+		}
+	}
+	return structs
+}
+
+// addReferencedModels adds the structs referenced by the given models to schemas,
+// following references transitively
+// Each model is registered before its own references are followed, so cycles terminate
+func addReferencedModels(schemas map[string]*spec.Schema, structs map[string]*coreast.Struct, resolver *typeResolver) {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		addModelRefs(schemas[name], schemas, structs, resolver)
+	}
+}
+
+// addModelRefs registers the unknown structs referenced by schema and recurses into them
+func addModelRefs(schema *spec.Schema, schemas map[string]*spec.Schema, structs map[string]*coreast.Struct, resolver *typeResolver) {
+	for _, name := range schemaRefs(schema) {
+		if _, ok := schemas[name]; ok {
+			continue
+		}
+		s, ok := structs[name]
+		if !ok {
+			continue
+		}
+
+		model := convertStructToSchema(s, resolver)
+		applyFieldTags(s, model)
+		schemas[name] = model
+
+		addModelRefs(model, schemas, structs, resolver)
+	}
+}
+
+// schemaRefs returns the component names referenced by a schema and its subschemas
+// Example: {"$ref": "#/components/schemas/Category"} -> ["Category"]
+func schemaRefs(schema *spec.Schema) []string {
+	if schema == nil {
+		return nil
+	}
+
+	var refs []string
+	if name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/"); ok {
+		refs = append(refs, name)
+	}
+
+	propNames := make([]string, 0, len(schema.Properties))
+	for propName := range schema.Properties {
+		propNames = append(propNames, propName)
+	}
+	slices.Sort(propNames)
+	for _, propName := range propNames {
+		refs = append(refs, schemaRefs(schema.Properties[propName])...)
+	}
+
+	refs = append(refs, schemaRefs(schema.Items)...)
+	if additional, ok := schema.AdditionalProperties.(*spec.Schema); ok {
+		refs = append(refs, schemaRefs(additional)...)
+	}
+	for _, sub := range slices.Concat(schema.AllOf, schema.OneOf, schema.AnyOf) {
+		refs = append(refs, schemaRefs(sub)...)
+	}
+
+	return refs
+}
+
+// applyFieldTags parses the field comments of a model into its property schemas
+// Fields marked with "required: true" are added to the model's required list
+func applyFieldTags(s *coreast.Struct, schema *spec.Schema) {
+	for _, field := range s.Fields {
+		if field.Doc == nil && field.Comment == nil {
+			continue
+		}
+
+		jsonName, _ := getJSONName(field)
+		fieldSchema := schema.Properties[jsonName]
+		if fieldSchema == nil {
+			continue
+		}
+
+		var text string
+		if field.Doc != nil {
+			parsers.GlobalRegistry().Parse("swagger:model", field.Doc, fieldSchema, parsers.ContextField)
+			text += field.Doc.Text()
+		}
+		if field.Comment != nil {
+			parsers.GlobalRegistry().Parse("swagger:model", field.Comment, fieldSchema, parsers.ContextField)
+			text += field.Comment.Text()
+		}
+
+		// An explicit "required:" comment overrides the inferred requirement
+		applyRequiredDirective(schema, jsonName, text)
+	}
+}
+
 // convertStructToSchema converts a generic struct to OpenAPI schema
-func convertStructToSchema(s *coreast.Struct) *spec.Schema {
+// Fields of embedded structs are promoted into the schema, as encoding/json does
+func convertStructToSchema(s *coreast.Struct, resolver *typeResolver) *spec.Schema {
+	return convertStructVisiting(s, resolver, map[string]bool{resolver.structKey(s): true})
+}
+
+// convertStructVisiting converts a struct, skipping the embedded structs in visited
+// so that embedding cycles terminate
+func convertStructVisiting(s *coreast.Struct, resolver *typeResolver, visited map[string]bool) *spec.Schema {
+	resolver = resolver.forStruct(s)
 	schema := &spec.Schema{
 		Type:       "object",
 		Properties: make(map[string]*spec.Schema),
 	}
 
+	var embedded []*coreast.Field
 	for _, field := range s.Fields {
-		// Skip embedded fields for now
-		if field.IsEmbedded {
+		jsonName, omitempty := getJSONName(field)
+		if jsonName == "-" {
 			continue
 		}
 
-		jsonName := getJSONName(field)
-		if jsonName == "-" {
+		// Untagged embedded structs are promoted once the direct fields are known,
+		// since those take precedence
+		if field.IsEmbedded && jsonName == field.Name {
+			embedded = append(embedded, field)
 			continue
 		}
 
-		fieldSchema := typeToSchema(field.Type, field.IsPointer, field.IsSlice)
+		fieldSchema := typeToSchema(field.Type, field.IsPointer, field.IsSlice, resolver)
 		schema.Properties[jsonName] = fieldSchema
+		schema.PropertyOrder = append(schema.PropertyOrder, jsonName)
+
+		// Pointer fields may be null, plain fields are always serialized unless omitempty
+		inferRequirement(schema, jsonName, field.IsPointer, omitempty)
+	}
+
+	for _, field := range embedded {
+		promoteEmbeddedFields(schema, field, resolver, visited)
 	}
 
 	return schema
 }
 
-// getJSONName extracts the JSON name from struct tag
-func getJSONName(field *coreast.Field) string {
+// promoteEmbeddedFields adds the properties of an embedded struct that the schema doesn't define yet
+// Embedded types that aren't parsed structs are skipped
+func promoteEmbeddedFields(schema *spec.Schema, field *coreast.Field, resolver *typeResolver, visited map[string]bool) {
+	base, ok := resolver.structType(strings.TrimPrefix(field.Type, "*"))
+	if !ok {
+		return
+	}
+	key := resolver.structKey(base)
+	if visited[key] {
+		return
+	}
+
+	visited[key] = true
+	baseSchema := convertStructVisiting(base, resolver, visited)
+	applyFieldTags(base, baseSchema)
+	delete(visited, key)
+
+	schema.PromoteProperties(baseSchema, field.IsPointer)
+}
+
+// getJSONName extracts the JSON name from struct tag and reports whether
+// the tag carries the omitempty option
+// Example: `json:"email,omitempty"` -> ("email", true)
+func getJSONName(field *coreast.Field) (string, bool) {
 	if field.Tag == "" {
-		return field.Name
+		return field.Name, false
 	}
 
 	// Parse json tag
@@ -487,28 +741,47 @@ func getJSONName(field *coreast.Field) string {
 		rest = strings.TrimPrefix(rest, "\"")
 		if endIdx := strings.Index(rest, "\""); endIdx != -1 {
 			jsonTag := rest[:endIdx]
-			// Split by comma to get just the name
+			// Split by comma to separate the name from its options
 			parts := strings.Split(jsonTag, ",")
-			if len(parts) > 0 && parts[0] != "" {
-				return parts[0]
+			omitempty := slices.Contains(parts[1:], "omitempty")
+			if parts[0] != "" {
+				return parts[0], omitempty
 			}
+			return field.Name, omitempty
 		}
 	}
 
-	return field.Name
+	return field.Name, false
 }
 
 // typeToSchema converts a Go type to OpenAPI schema
-func typeToSchema(goType string, isPointer bool, isSlice bool) *spec.Schema {
+func typeToSchema(goType string, isPointer bool, isSlice bool, resolver *typeResolver) *spec.Schema {
 	// Remove pointer prefix
 	goType = strings.TrimPrefix(goType, "*")
 
+	// Well-known types (time.Time, uuid.UUID, []byte, ...) map to formatted primitives
+	if schema := wellKnownTypeSchema(goType); schema != nil {
+		return schema
+	}
+
+	// Maps become objects whose additionalProperties describe the values
+	if _, valueType, ok := splitMapType(goType); ok {
+		schema := &spec.Schema{Type: "object"}
+		if valueType == "any" || valueType == "interface{}" {
+			schema.AdditionalProperties = true
+		} else {
+			schema.AdditionalProperties = typeToSchema(valueType,
+				strings.HasPrefix(valueType, "*"), strings.HasPrefix(valueType, "[]"), resolver)
+		}
+		return schema
+	}
+
 	// Handle slices
 	if isSlice {
 		elemType := strings.TrimPrefix(goType, "[]")
 		return &spec.Schema{
 			Type:  "array",
-			Items: typeToSchema(elemType, false, false),
+			Items: typeToSchema(elemType, false, false, resolver),
 		}
 	}
 
@@ -524,6 +797,11 @@ func typeToSchema(goType string, isPointer bool, isSlice bool) *spec.Schema {
 	case "bool":
 		return &spec.Schema{Type: "boolean"}
 	default:
+		// Named types backed by a primitive (e.g., type UserID int64) use that primitive
+		if jsonType, ok := resolver.jsonType(goType); ok {
+			return &spec.Schema{Type: jsonType}
+		}
+
 		// Assume it's a reference to another schema
 		return &spec.Schema{
 			Ref: "#/components/schemas/" + goType,
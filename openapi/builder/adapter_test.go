@@ -1,11 +1,18 @@
 package builder
 
 import (
+	"bytes"
+	"encoding/json"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	coreast "github.com/reation-io/apikit/core/ast"
+	"github.com/reation-io/apikit/openapi/spec"
 )
 
 func TestExtractFromGeneric(t *testing.T) {
@@ -150,3 +157,1344 @@ type CreateUserRequest struct {
 	}
 }
 
+
+func TestExtractFromGeneric_Parameters(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:route GET /pets/{petId} pet getPet
+// Spec: public
+type GetPetRequest struct {
+	// ID of pet to return
+	// in: path
+	PetID int64 ` + "`json:\"petId\"`" + `
+
+	// Status values to filter by
+	// in: query
+	// required: true
+	// enum: available,pending,sold
+	Status string ` + "`json:\"status\"`" + `
+
+	// in: header
+	APIKey string ` + "`json:\"api_key\"`" + `
+
+	// Not a parameter
+	Internal string
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	checkParams := func(t *testing.T, params []*spec.Parameter) {
+		t.Helper()
+
+		if len(params) != 3 {
+			t.Fatalf("expected 3 parameters, got %d", len(params))
+		}
+
+		petID := params[0]
+		if petID.Name != "petId" || petID.In != "path" {
+			t.Errorf("expected path parameter 'petId', got %s %q", petID.In, petID.Name)
+		}
+		if !petID.Required {
+			t.Error("expected path parameter to be required")
+		}
+		if petID.Description != "ID of pet to return" {
+			t.Errorf("expected description 'ID of pet to return', got %q", petID.Description)
+		}
+		if petID.Schema == nil || petID.Schema.Type != "integer" {
+			t.Errorf("expected integer schema, got %+v", petID.Schema)
+		}
+
+		status := params[1]
+		if status.Name != "status" || status.In != "query" {
+			t.Errorf("expected query parameter 'status', got %s %q", status.In, status.Name)
+		}
+		if !status.Required {
+			t.Error("expected status parameter to be required")
+		}
+		if status.Schema == nil || len(status.Schema.Enum) != 3 || status.Schema.Enum[0] != "available" {
+			t.Errorf("expected enum [available pending sold], got %+v", status.Schema)
+		}
+
+		apiKey := params[2]
+		if apiKey.Name != "api_key" || apiKey.In != "header" {
+			t.Errorf("expected header parameter 'api_key', got %s %q", apiKey.In, apiKey.Name)
+		}
+		if apiKey.Required {
+			t.Error("expected header parameter to be optional")
+		}
+	}
+
+	t.Run("single spec", func(t *testing.T) {
+		openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+		if err != nil {
+			t.Fatalf("ExtractFromGeneric failed: %v", err)
+		}
+		checkParams(t, openapi.Paths.PathItems["/pets/{petId}"].Get.Parameters)
+	})
+
+	t.Run("multi spec", func(t *testing.T) {
+		specs, err := ExtractMultipleFromGeneric([]*coreast.ParseResult{result})
+		if err != nil {
+			t.Fatalf("ExtractMultipleFromGeneric failed: %v", err)
+		}
+		public, ok := specs["public"]
+		if !ok {
+			t.Fatal("expected public spec")
+		}
+		checkParams(t, public.Paths.PathItems["/pets/{petId}"].Get.Parameters)
+	})
+}
+
+func TestExtractFromGeneric_RequiredFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:model
+type Error struct {
+	// Error code
+	// required: true
+	Code string ` + "`json:\"code\"`" + `
+
+	Message string ` + "`json:\"message\"`" + ` // required: true
+
+	Details string ` + "`json:\"details,omitempty\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	schema := openapi.Components.Schemas["Error"]
+	if len(schema.Required) != 2 || schema.Required[0] != "code" || schema.Required[1] != "message" {
+		t.Errorf("expected required [code message], got %v", schema.Required)
+	}
+}
+
+func TestExtractFromGeneric_Composition(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:model
+type Dog struct {
+	Bark bool ` + "`json:\"bark\"`" + `
+}
+
+// swagger:model
+type Cat struct {
+	Meow bool ` + "`json:\"meow\"`" + `
+}
+
+// Animal is either a dog or a cat
+// swagger:model
+// oneOf: Dog,Cat
+type Animal struct{}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	animal := openapi.Components.Schemas["Animal"]
+	if len(animal.OneOf) != 2 {
+		t.Fatalf("expected 2 oneOf entries, got %d", len(animal.OneOf))
+	}
+	if animal.OneOf[0].Ref != "#/components/schemas/Dog" || animal.OneOf[1].Ref != "#/components/schemas/Cat" {
+		t.Errorf("expected refs to Dog and Cat, got %q and %q", animal.OneOf[0].Ref, animal.OneOf[1].Ref)
+	}
+}
+
+func TestExtractFromGeneric_NamedTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Named types are resolved through go/packages, which needs a module
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/test\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	testFile := filepath.Join(tmpDir, "test.go")
+	content := `package test
+
+type UserID int64
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusDisabled Status = "disabled"
+)
+
+// swagger:model
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// swagger:model
+type User struct {
+	ID        UserID   ` + "`json:\"id\"`" + `
+	Status    Status   ` + "`json:\"status\"`" + `
+	Address   Address  ` + "`json:\"address\"`" + `
+	Followers []UserID ` + "`json:\"followers\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	genericResult, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{genericResult})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	user := openapi.Components.Schemas["User"]
+	if user == nil {
+		t.Fatal("expected User schema")
+	}
+
+	id := user.Properties["id"]
+	if id.Type != "integer" || id.Ref != "" {
+		t.Errorf("expected id to resolve to integer, got type %q ref %q", id.Type, id.Ref)
+	}
+
+	status := user.Properties["status"]
+	if status.Type != "string" || status.Ref != "" {
+		t.Errorf("expected status to resolve to string, got type %q ref %q", status.Type, status.Ref)
+	}
+
+	// Struct-backed named types keep their reference
+	if got := user.Properties["address"].Ref; got != "#/components/schemas/Address" {
+		t.Errorf("expected address to reference Address, got %q", got)
+	}
+
+	followers := user.Properties["followers"]
+	if followers.Items == nil || followers.Items.Type != "integer" {
+		t.Errorf("expected followers items to resolve to integer, got %+v", followers.Items)
+	}
+}
+
+func TestExtractFromGeneric_PackageQualifiedTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Both packages declare a Status type, with different underlying types
+	files := map[string]string{
+		"go.mod": "module example.com/test\n\ngo 1.22\n",
+		"models/models.go": `package models
+
+type Status int
+
+type Base struct {
+	Code Status ` + "`json:\"code\"`" + `
+}
+`,
+		"api/api.go": `package api
+
+import "example.com/test/models"
+
+type Status string
+
+// swagger:model
+type Order struct {
+	models.Base
+	Status Status        ` + "`json:\"status\"`" + `
+	Level  models.Status ` + "`json:\"level\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	var results []*coreast.ParseResult
+	for _, name := range []string{"models/models.go", "api/api.go"} {
+		result, err := coreast.New().Parse(filepath.Join(tmpDir, name))
+		if err != nil {
+			t.Fatalf("generic parse failed: %v", err)
+		}
+		results = append(results, result)
+	}
+
+	openapi, err := ExtractFromGeneric(results)
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	order := openapi.Components.Schemas["Order"]
+	if order == nil {
+		t.Fatal("expected Order schema")
+	}
+	if got := order.Properties["status"].Type; got != "string" {
+		t.Errorf("expected status to resolve to api.Status (string), got %q", got)
+	}
+	if got := order.Properties["level"].Type; got != "integer" {
+		t.Errorf("expected level to resolve to models.Status (integer), got %q", got)
+	}
+	if code := order.Properties["code"]; code == nil || code.Type != "integer" {
+		t.Errorf("expected promoted code to resolve against package models, got %+v", code)
+	}
+}
+
+func TestExtractFromGeneric_ImportedPackageTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Only package api is parsed; models is reached through its imports
+	files := map[string]string{
+		"go.mod": "module example.com/test\n\ngo 1.22\n",
+		"models/models.go": `package models
+
+type UserID int64
+`,
+		"api/api.go": `package api
+
+import "example.com/test/models"
+
+// swagger:model
+type Order struct {
+	Owner models.UserID ` + "`json:\"owner\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	result, err := coreast.New().Parse(filepath.Join(tmpDir, "api", "api.go"))
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	order := openapi.Components.Schemas["Order"]
+	if order == nil {
+		t.Fatal("expected Order schema")
+	}
+	if owner := order.Properties["owner"]; owner.Type != "integer" || owner.Ref != "" {
+		t.Errorf("expected owner to resolve to models.UserID (integer), got %+v", owner)
+	}
+}
+
+func TestExtractFromGeneric_ReferencedModels(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:model
+type Pet struct {
+	Name     string    ` + "`json:\"name\"`" + `
+	Category *Category ` + "`json:\"category\"`" + `
+}
+
+// Category is not annotated but referenced by Pet
+type Category struct {
+	Name   string    ` + "`json:\"name\"`" + `
+	Parent *Category ` + "`json:\"parent\"`" + `
+	Tags   []Tag     ` + "`json:\"tags\"`" + `
+}
+
+type Tag struct {
+	Label string ` + "`json:\"label\"`" + `
+}
+
+// Unused is never referenced
+type Unused struct {
+	Value string ` + "`json:\"value\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	schemas := openapi.Components.Schemas
+	if got := schemas["Pet"].Properties["category"].Ref; got != "#/components/schemas/Category" {
+		t.Errorf("expected category to reference Category, got %q", got)
+	}
+
+	// Referenced structs are emitted transitively, including through the Parent cycle
+	category := schemas["Category"]
+	if category == nil {
+		t.Fatal("expected referenced Category schema to be emitted")
+	}
+	if got := category.Properties["parent"].Ref; got != "#/components/schemas/Category" {
+		t.Errorf("expected parent to reference Category, got %q", got)
+	}
+	if schemas["Tag"] == nil {
+		t.Error("expected Tag schema referenced by Category to be emitted")
+	}
+
+	if schemas["Unused"] != nil {
+		t.Error("expected unreferenced struct not to be emitted")
+	}
+}
+
+func TestExtractFromGeneric_WellKnownTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Event struct {
+	ID        uuid.UUID     ` + "`json:\"id\"`" + `
+	CreatedAt time.Time     ` + "`json:\"createdAt\"`" + `
+	UpdatedAt *time.Time    ` + "`json:\"updatedAt\"`" + `
+	Timeout   time.Duration ` + "`json:\"timeout\"`" + `
+	Payload   []byte        ` + "`json:\"payload\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	event := openapi.Components.Schemas["Event"]
+	if event == nil {
+		t.Fatal("expected Event schema")
+	}
+
+	tests := []struct {
+		property   string
+		wantType   string
+		wantFormat string
+	}{
+		{"id", "string", "uuid"},
+		{"createdAt", "string", "date-time"},
+		{"updatedAt", "string", "date-time"},
+		{"timeout", "string", ""},
+		{"payload", "string", "byte"},
+	}
+
+	for _, tt := range tests {
+		schema := event.Properties[tt.property]
+		if schema == nil {
+			t.Errorf("expected property %q", tt.property)
+			continue
+		}
+		if schema.Ref != "" {
+			t.Errorf("%s: expected no $ref, got %q", tt.property, schema.Ref)
+		}
+		if schema.Type != tt.wantType || schema.Format != tt.wantFormat {
+			t.Errorf("%s: expected %s/%s, got %s/%s", tt.property, tt.wantType, tt.wantFormat, schema.Type, schema.Format)
+		}
+	}
+}
+
+func TestExtractFromGeneric_FormatComment(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+import "time"
+
+// swagger:model
+type Account struct {
+	// Account identifier
+	// format: uuid
+	ID string ` + "`json:\"id\"`" + `
+
+	Balance int ` + "`json:\"balance\"`" + ` // format: int64
+
+	// format: date
+	OpenedAt time.Time ` + "`json:\"openedAt\"`" + `
+
+	Owner Owner ` + "`json:\"owner\"`" + `
+}
+
+type Owner struct {
+	Email string ` + "`json:\"email\"`" + ` // format: email
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	tests := []struct {
+		model      string
+		property   string
+		wantType   string
+		wantFormat string
+	}{
+		{"Account", "id", "string", "uuid"},
+		{"Account", "balance", "integer", "int64"},
+		{"Account", "openedAt", "string", "date"},
+		{"Owner", "email", "string", "email"},
+	}
+
+	for _, tt := range tests {
+		model := openapi.Components.Schemas[tt.model]
+		if model == nil {
+			t.Fatalf("expected %s schema", tt.model)
+		}
+		schema := model.Properties[tt.property]
+		if schema == nil {
+			t.Errorf("expected property %s.%s", tt.model, tt.property)
+			continue
+		}
+		if schema.Type != tt.wantType || schema.Format != tt.wantFormat {
+			t.Errorf("%s.%s: expected %s/%s, got %s/%s", tt.model, tt.property, tt.wantType, tt.wantFormat, schema.Type, schema.Format)
+		}
+	}
+}
+
+func TestExtractFromGeneric_PropertyOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+type Audit struct {
+	CreatedBy string ` + "`json:\"createdBy\"`" + `
+	Name      string ` + "`json:\"name\"`" + `
+}
+
+// swagger:model
+type Pet struct {
+	Zone  string ` + "`json:\"zone\"`" + `
+	Name  string ` + "`json:\"name\"`" + `
+	Audit
+	Breed string ` + "`json:\"breed\"`" + `
+	Age   int    ` + "`json:\"age\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	build := func() []byte {
+		t.Helper()
+
+		result, err := coreast.New().Parse(testFile)
+		if err != nil {
+			t.Fatalf("generic parse failed: %v", err)
+		}
+		openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+		if err != nil {
+			t.Fatalf("ExtractFromGeneric failed: %v", err)
+		}
+		data, err := json.Marshal(openapi.Components.Schemas["Pet"])
+		if err != nil {
+			t.Fatalf("failed to marshal schema: %v", err)
+		}
+		return data
+	}
+
+	first, second := build(), build()
+	if !bytes.Equal(first, second) {
+		t.Errorf("expected identical JSON across builds:\n%s\n%s", first, second)
+	}
+
+	// Direct fields keep declaration order; promoted fields follow
+	props := string(first[bytes.Index(first, []byte(`"properties"`)):])
+	last := -1
+	for _, name := range []string{`"zone"`, `"name"`, `"breed"`, `"age"`, `"createdBy"`} {
+		idx := strings.Index(props, name)
+		if idx <= last {
+			t.Fatalf("expected %s after the previous properties, got %s", name, props)
+		}
+		last = idx
+	}
+}
+
+func TestExtractFromGeneric_NullableAndOmitempty(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:model
+type Profile struct {
+	Name     string  ` + "`json:\"name\"`" + `
+	Nickname *string ` + "`json:\"nickname\"`" + `
+	Bio      string  ` + "`json:\"bio,omitempty\"`" + `
+
+	// required: false
+	Avatar string ` + "`json:\"avatar\"`" + `
+
+	// required: true
+	Website string ` + "`json:\"website,omitempty\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	schema := openapi.Components.Schemas["Profile"]
+	if schema == nil {
+		t.Fatal("expected Profile schema")
+	}
+
+	// Plain field is required, pointer and omitempty fields are not,
+	// and explicit required: comments override the inference
+	wantRequired := []string{"name", "website"}
+	if !reflect.DeepEqual(schema.Required, wantRequired) {
+		t.Errorf("expected required %v, got %v", wantRequired, schema.Required)
+	}
+
+	if !schema.Properties["nickname"].Nullable {
+		t.Error("expected pointer field nickname to be nullable")
+	}
+	if schema.Properties["name"].Nullable {
+		t.Error("expected plain field name not to be nullable")
+	}
+	if schema.Properties["bio"].Nullable {
+		t.Error("expected omitempty field bio not to be nullable")
+	}
+}
+
+func TestExtractFromGeneric_MapFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:model
+type Tag struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:model
+type Inventory struct {
+	Counts   map[string]int32    ` + "`json:\"counts\"`" + `
+	Tags     map[string]Tag      ` + "`json:\"tags\"`" + `
+	Aliases  map[string][]string ` + "`json:\"aliases\"`" + `
+	Metadata map[string]any      ` + "`json:\"metadata\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	props := openapi.Components.Schemas["Inventory"].Properties
+	tests := map[string]*spec.Schema{
+		"counts":   {Type: "object", AdditionalProperties: &spec.Schema{Type: "integer"}},
+		"tags":     {Type: "object", AdditionalProperties: &spec.Schema{Ref: "#/components/schemas/Tag"}},
+		"aliases":  {Type: "object", AdditionalProperties: &spec.Schema{Type: "array", Items: &spec.Schema{Type: "string"}}},
+		"metadata": {Type: "object", AdditionalProperties: true},
+	}
+	for name, want := range tests {
+		if !reflect.DeepEqual(props[name], want) {
+			t.Errorf("%s: expected %+v, got %+v", name, want, props[name])
+		}
+	}
+}
+
+func TestExtractFromGeneric_EmbeddedStructs(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+type Base struct {
+	// minimum: 1
+	ID        int64  ` + "`json:\"id\"`" + `
+	CreatedAt string ` + "`json:\"createdAt,omitempty\"`" + `
+	Name      string ` + "`json:\"name\"`" + `
+}
+
+type Audit struct {
+	UpdatedBy string ` + "`json:\"updatedBy\"`" + `
+	*Loop
+}
+
+type Loop struct {
+	Audit
+	Revision int ` + "`json:\"revision\"`" + `
+}
+
+type Owner struct {
+	Email string ` + "`json:\"email\"`" + `
+}
+
+// swagger:model
+type Pet struct {
+	Base
+	*Audit
+	Owner ` + "`json:\"owner\"`" + `
+
+	// Name shadows Base.Name
+	Name int ` + "`json:\"name\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	pet := openapi.Components.Schemas["Pet"]
+	for _, name := range []string{"id", "createdAt", "name", "updatedBy", "revision", "owner"} {
+		if pet.Properties[name] == nil {
+			t.Errorf("expected property %q in Pet, got %v", name, slices.Sorted(maps.Keys(pet.Properties)))
+		}
+	}
+	if _, ok := pet.Properties["Base"]; ok {
+		t.Error("expected embedded Base to be promoted, not nested")
+	}
+
+	if id := pet.Properties["id"]; id.Type != "integer" || id.Minimum == nil || *id.Minimum != 1 {
+		t.Errorf("expected promoted id with its field tags, got %+v", id)
+	}
+	if name := pet.Properties["name"]; name.Type != "integer" {
+		t.Errorf("expected Pet.Name to shadow Base.Name, got %+v", name)
+	}
+	if owner := pet.Properties["owner"]; owner.Ref != "#/components/schemas/Owner" {
+		t.Errorf("expected tagged embedded field to stay a property, got %+v", owner)
+	}
+
+	// Fields of a non-pointer embed keep their requirement, pointer embeds may be nil
+	slices.Sort(pet.Required)
+	if want := []string{"id", "name", "owner"}; !slices.Equal(pet.Required, want) {
+		t.Errorf("expected required %v, got %v", want, pet.Required)
+	}
+}
+
+func TestGetJSONName(t *testing.T) {
+	tests := []struct {
+		tag           string
+		wantName      string
+		wantOmitempty bool
+	}{
+		{``, "Field", false},
+		{`json:"email"`, "email", false},
+		{`json:"email,omitempty"`, "email", true},
+		{`json:",omitempty"`, "Field", true},
+		{`json:"-"`, "-", false},
+	}
+
+	for _, tt := range tests {
+		name, omitempty := getJSONName(&coreast.Field{Name: "Field", Tag: tt.tag})
+		if name != tt.wantName || omitempty != tt.wantOmitempty {
+			t.Errorf("getJSONName(%q) = (%q, %v), want (%q, %v)", tt.tag, name, omitempty, tt.wantName, tt.wantOmitempty)
+		}
+	}
+}
+
+func TestExtractFromGeneric_MetaTags(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// Pet Store API
+//
+// swagger:meta
+//
+// Title: Pet Store
+// Version: 1.0.0
+//
+// Tags:
+// - name: users description: "User management"
+// - name: pets description: "Everything about pets"
+type Meta struct{}
+
+// ListPetsRequest lists pets
+// swagger:route GET /pets pets listPets
+type ListPetsRequest struct{}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	wantTags := []*spec.Tag{
+		{Name: "users", Description: "User management"},
+		{Name: "pets", Description: "Everything about pets"},
+	}
+
+	t.Run("single spec", func(t *testing.T) {
+		openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+		if err != nil {
+			t.Fatalf("ExtractFromGeneric failed: %v", err)
+		}
+		if !reflect.DeepEqual(openapi.Tags, wantTags) {
+			t.Errorf("expected tags %+v, got %+v", wantTags, openapi.Tags)
+		}
+	})
+
+	t.Run("multi spec", func(t *testing.T) {
+		specs, err := ExtractMultipleFromGeneric([]*coreast.ParseResult{result})
+		if err != nil {
+			t.Fatalf("ExtractMultipleFromGeneric failed: %v", err)
+		}
+		if !reflect.DeepEqual(specs["default"].Tags, wantTags) {
+			t.Errorf("expected tags %+v, got %+v", wantTags, specs["default"].Tags)
+		}
+	})
+}
+
+func TestExtractMultipleFromGeneric_SharesSecuritySchemes(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:meta
+//
+// Title: Pet Store
+// Version: 1.0.0
+//
+// SecuritySchemes:
+//
+//	api_key:
+//	  type: apiKey
+//	  name: api_key
+//	  in: header
+type Meta struct{}
+
+// ListPetsRequest lists pets
+// swagger:route GET /pets pets listPets
+// Spec: public
+// Security:
+// - api_key
+type ListPetsRequest struct{}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	specs, err := ExtractMultipleFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractMultipleFromGeneric failed: %v", err)
+	}
+
+	for _, specName := range []string{"default", "public"} {
+		openapi := specs[specName]
+		if openapi == nil {
+			t.Fatalf("expected spec %q", specName)
+		}
+		if openapi.Components == nil || openapi.Components.SecuritySchemes["api_key"] == nil {
+			t.Errorf("expected api_key security scheme in spec %q", specName)
+		}
+	}
+}
+
+func TestExtractMultipleFromGeneric_ScopesServersAndSecuritySchemes(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:meta
+//
+// Title: Shared
+// Version: 1.0.0
+//
+// SecuritySchemes:
+//
+//	api_key:
+//	  type: apiKey
+//	  name: api_key
+//	  in: header
+type Meta struct{}
+
+// swagger:meta
+//
+// Title: Admin API
+// Version: 1.0.0
+// Spec: admin
+//
+// Servers:
+// - url: https://admin.example.com
+//
+// SecuritySchemes:
+//
+//	admin_auth:
+//	  type: http
+//	  scheme: bearer
+type AdminMeta struct{}
+
+// swagger:meta
+//
+// Title: Public API
+// Version: 1.0.0
+// Spec: public
+//
+// Servers:
+// - url: https://api.example.com
+type PublicMeta struct{}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	specs, err := ExtractMultipleFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractMultipleFromGeneric failed: %v", err)
+	}
+
+	wantServers := map[string][]string{
+		"default": nil,
+		"admin":   {"https://admin.example.com"},
+		"public":  {"https://api.example.com"},
+	}
+	for specName, want := range wantServers {
+		openapi := specs[specName]
+		if openapi == nil {
+			t.Fatalf("expected spec %q", specName)
+		}
+		var got []string
+		for _, server := range openapi.Servers {
+			got = append(got, server.URL)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("spec %q: expected servers %v, got %v", specName, want, got)
+		}
+	}
+
+	wantSchemes := map[string][]string{
+		"default": {"api_key"},
+		"admin":   {"admin_auth", "api_key"},
+		"public":  {"api_key"},
+	}
+	for specName, want := range wantSchemes {
+		var got []string
+		if components := specs[specName].Components; components != nil {
+			got = slices.Sorted(maps.Keys(components.SecuritySchemes))
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("spec %q: expected security schemes %v, got %v", specName, want, got)
+		}
+	}
+}
+
+func TestExtractFromGeneric_MetaConsumesNotMarshaled(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:meta
+//
+// Title: Pet Store
+// Version: 1.0.0
+// Consumes: application/json
+// Produces: application/json, application/xml
+type Meta struct{}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+	if _, ok := openapi.Extensions["x-consumes"]; !ok {
+		t.Fatal("expected the meta Consumes to be recorded for the builder")
+	}
+
+	data, err := json.Marshal(openapi)
+	if err != nil {
+		t.Fatalf("failed to marshal spec: %v", err)
+	}
+	for _, key := range []string{"x-consumes", "x-produces"} {
+		if strings.Contains(string(data), key) {
+			t.Errorf("internal %s extension leaked into output:\n%s", key, data)
+		}
+	}
+}
+
+func TestExtractMultipleFromGeneric_NullableAndRequired(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// ListPetsRequest lists pets
+// swagger:route GET /pets pets listPets
+// Spec: public
+type ListPetsRequest struct{}
+
+// swagger:model
+type Pet struct {
+	Name     string  ` + "`json:\"name\"`" + `
+	Nickname *string ` + "`json:\"nickname\"`" + `
+	Age      *int    ` + "`json:\"age,omitempty\"`" + `
+	Breed    string  ` + "`json:\"breed,omitempty\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	single, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+	specs, err := ExtractMultipleFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractMultipleFromGeneric failed: %v", err)
+	}
+
+	// Single- and multi-spec extraction derive the same nullability and required list
+	builds := map[string]*spec.OpenAPI{"single": single, "default": specs["default"], "public": specs["public"]}
+	for name, openapi := range builds {
+		if openapi == nil || openapi.Components == nil || openapi.Components.Schemas["Pet"] == nil {
+			t.Fatalf("%s: expected Pet schema", name)
+		}
+		pet := openapi.Components.Schemas["Pet"]
+
+		if want := []string{"name"}; !slices.Equal(pet.Required, want) {
+			t.Errorf("%s: expected required %v, got %v", name, want, pet.Required)
+		}
+		for _, prop := range []string{"nickname", "age"} {
+			if !pet.Properties[prop].Nullable {
+				t.Errorf("%s: expected pointer field %s to be nullable", name, prop)
+			}
+		}
+		for _, prop := range []string{"name", "breed"} {
+			if pet.Properties[prop].Nullable {
+				t.Errorf("%s: expected plain field %s not to be nullable", name, prop)
+			}
+		}
+	}
+}
+
+func TestExtractFromGeneric_DuplicateOperationID(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:route GET /pets pets listPets
+type ListPetsRequest struct{}
+
+// swagger:route GET /animals pets listPets
+type ListAnimalsRequest struct{}
+
+// swagger:route GET /owners owners listOwners
+// Spec: public
+type ListOwnersRequest struct{}
+
+// swagger:route GET /people owners listOwners
+// Spec: internal
+type ListPeopleRequest struct{}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	_, err = ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err == nil {
+		t.Fatal("expected an error for the duplicate operationId")
+	}
+	for _, want := range []string{
+		`duplicate operationId "listPets"`,
+		"test.go:4:6 (GET /pets)",
+		"test.go:7:6 (GET /animals)",
+		`duplicate operationId "listOwners"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %v", want, err)
+		}
+	}
+
+	// Routes in different specs may share an operationId
+	_, err = ExtractMultipleFromGeneric([]*coreast.ParseResult{result})
+	if err == nil {
+		t.Fatal("expected an error for the duplicate operationId in the default spec")
+	}
+	if !strings.Contains(err.Error(), `spec "default": duplicate operationId "listPets"`) {
+		t.Errorf("expected listPets to be reported for the default spec, got %v", err)
+	}
+	if strings.Contains(err.Error(), "listOwners") {
+		t.Errorf("expected listOwners in separate specs to be accepted, got %v", err)
+	}
+}
+
+func TestExtractFromGeneric_RouteExample(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:route POST /pets pets createPet
+// Example: {"name": "Rex", "age": 3}
+type CreatePetRequest struct {
+	// in: body
+	Body Pet
+}
+
+// swagger:route GET /pets pets listPets
+// Example: ignored
+type ListPetsRequest struct{}
+
+// swagger:model
+type Pet struct {
+	Name string ` + "`json:\"name\"`" + `
+	Age  int    ` + "`json:\"age\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	post := openapi.Paths.PathItems["/pets"].Post
+	if post == nil || post.RequestBody == nil {
+		t.Fatalf("expected POST /pets with a request body, got %#v", post)
+	}
+	media := post.RequestBody.Content["application/json"]
+	if media == nil {
+		t.Fatal("expected an application/json request body")
+	}
+	want := map[string]any{"name": "Rex", "age": float64(3)}
+	if !reflect.DeepEqual(media.Example, want) {
+		t.Errorf("request body example = %#v, want %#v", media.Example, want)
+	}
+
+	get := openapi.Paths.PathItems["/pets"].Get
+	if get == nil || get.RequestBody != nil {
+		t.Errorf("expected GET /pets without a request body, got %#v", get)
+	}
+}
+
+func TestExtractFromGeneric_ConsumesProduces(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package test
+
+// swagger:route POST /pets pets createPet
+// Consumes: application/xml, text/xml
+// Produces: application/xml
+// Responses:
+//   - 200: Pet
+//   - 202: Pet as text/plain
+//   - 400: Error
+type CreatePetRequest struct {
+	// in: body
+	Body Pet
+}
+
+// swagger:model
+type Pet struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:model
+type Error struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+`
+
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+
+	openapi, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+
+	post := openapi.Paths.PathItems["/pets"].Post
+	if post == nil || post.RequestBody == nil {
+		t.Fatalf("expected POST /pets with a request body, got %#v", post)
+	}
+
+	// Consumes replaces the default JSON body, keeping the body field schema
+	body := post.RequestBody.Content
+	if _, ok := body["application/json"]; ok {
+		t.Error("expected application/json to be replaced by the Consumes media types")
+	}
+	for _, mimeType := range []string{"application/xml", "text/xml"} {
+		if media := body[mimeType]; media == nil || media.Schema == nil || media.Schema.Ref != "#/components/schemas/Pet" {
+			t.Errorf("expected %s request body with the Pet schema, got %#v", mimeType, media)
+		}
+	}
+
+	// Produces sets the media type of success responses
+	ok := post.Responses.StatusCodeResponses["200"]
+	if media := ok.Content["application/xml"]; media == nil || media.Schema == nil || media.Schema.Ref != "#/components/schemas/Pet" {
+		t.Errorf("expected application/xml 200 response with the Pet schema, got %#v", ok.Content)
+	}
+	if _, found := ok.Content["application/json"]; found {
+		t.Error("expected application/json to be replaced on the 200 response")
+	}
+	if accepted := post.Responses.StatusCodeResponses["202"]; accepted.Content["text/plain"] == nil || len(accepted.Content) != 1 {
+		t.Errorf("expected explicit text/plain 202 response to be kept, got %#v", accepted.Content)
+	}
+	if failed := post.Responses.StatusCodeResponses["400"]; failed.Content["application/json"] == nil {
+		t.Errorf("expected error response to stay application/json, got %#v", failed.Content)
+	}
+	if _, found := post.Extensions["x-produces"]; found {
+		t.Error("expected x-produces to be consumed by the builder")
+	}
+}
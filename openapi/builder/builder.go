@@ -5,7 +5,10 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
+	"io/fs"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/reation-io/apikit/openapi/parsers"
@@ -20,6 +23,14 @@ type Builder struct {
 	spec     *spec.OpenAPI
 	fset     *token.FileSet
 	patterns []string // File patterns to scan
+	types    *typeResolver
+
+	// structs indexes the struct types declared in the scanned files by package
+	// and name, for promoting embedded fields
+	structs map[string]*structDecl
+
+	// operationIDs tracks the routes declaring each operationId
+	operationIDs *spec.OperationIDs
 }
 
 // NewBuilder creates a new OpenAPI builder
@@ -52,11 +63,30 @@ func (b *Builder) Build() (*spec.OpenAPI, error) {
 		return nil, fmt.Errorf("failed to find files: %w", err)
 	}
 
-	// Parse each file
+	// Resolve named types (e.g., type UserID int64) to their underlying primitive
+	b.types = newTypeResolver(files)
+	b.operationIDs = spec.NewOperationIDs()
+
+	// Parse every file up front, so embedded structs resolve across files
+	parsed := make([]*ast.File, 0, len(files))
 	for _, file := range files {
-		if err := b.parseFile(file); err != nil {
+		f, err := parser.ParseFile(b.fset, file, nil, parser.ParseComments)
+		if err != nil {
 			return nil, fmt.Errorf("failed to parse file %s: %w", file, err)
 		}
+		parsed = append(parsed, f)
+	}
+	b.structs = collectStructTypes(parsed)
+
+	for i, file := range parsed {
+		if err := b.parseFile(file); err != nil {
+			return nil, fmt.Errorf("failed to parse file %s: %w", files[i], err)
+		}
+	}
+
+	// operationIds must be unique across the spec
+	if err := duplicateOperationIDs(b.operationIDs); err != nil {
+		return nil, err
 	}
 
 	return b.spec, nil
@@ -64,24 +94,99 @@ func (b *Builder) Build() (*spec.OpenAPI, error) {
 
 // findFiles finds all Go files matching the patterns
 func (b *Builder) findFiles() ([]string, error) {
+	return FindFiles(b.patterns...)
+}
+
+// FindFiles expands file patterns into the matching files, without duplicates
+// "dir/..." matches every Go file under dir and "dir/**/*.go" every file under
+// dir matching *.go; both skip vendor, testdata, hidden and _-prefixed directories
+// Other patterns are expanded with filepath.Glob
+// Example: FindFiles("./...") -> [main.go api/users.go api/v2/pets.go]
+func FindFiles(patterns ...string) ([]string, error) {
 	var files []string
-	for _, pattern := range b.patterns {
-		matches, err := filepath.Glob(pattern)
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		var matches []string
+		var err error
+		if IsRecursivePattern(pattern) {
+			matches, err = walkPattern(pattern)
+		} else {
+			matches, err = filepath.Glob(pattern)
+		}
 		if err != nil {
 			return nil, err
 		}
-		files = append(files, matches...)
+
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
 	}
 	return files, nil
 }
 
-// parseFile parses a single Go file and extracts OpenAPI information
-func (b *Builder) parseFile(filename string) error {
-	// Parse the file
-	file, err := parser.ParseFile(b.fset, filename, nil, parser.ParseComments)
+// IsRecursivePattern reports whether a pattern scans subdirectories
+// Example: "./...", "api/...", "**/*.go"
+func IsRecursivePattern(pattern string) bool {
+	return pattern == "..." || strings.HasSuffix(pattern, "/...") || strings.Contains(pattern, "**")
+}
+
+// walkPattern walks the root of a recursive pattern for the files it matches
+// Example: "api/**/*.go" walks api and matches base names against *.go
+func walkPattern(pattern string) ([]string, error) {
+	root, rest := strings.TrimSuffix(pattern, "..."), "*.go"
+	if before, after, ok := strings.Cut(pattern, "**"); ok {
+		root, rest = before, strings.TrimPrefix(after, "/")
+		if rest == "" {
+			rest = "*"
+		}
+	}
+	root = strings.TrimSuffix(root, "/")
+	if root == "" {
+		root = "."
+	}
+	if _, err := filepath.Match(rest, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	// Patterns after ** match the trailing path elements of each file
+	// Example: "models/*.go" matches api/v1/models/user.go
+	depth := strings.Count(rest, "/") + 1
+
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		elems := strings.Split(filepath.ToSlash(path), "/")
+		if len(elems) < depth {
+			return nil
+		}
+		if ok, _ := filepath.Match(rest, strings.Join(elems[len(elems)-depth:], "/")); ok {
+			files = append(files, path)
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("walking %s: %w", root, err)
 	}
+	return files, nil
+}
+
+// parseFile extracts OpenAPI information from a parsed Go file
+func (b *Builder) parseFile(file *ast.File) error {
+	// Unqualified type names resolve against the file's package
+	b.types = b.types.in(file.Name.Name)
 
 	// Look for swagger:meta comments
 	if err := b.parseMeta(file); err != nil {
@@ -156,6 +261,8 @@ func (b *Builder) parseRoutes(file *ast.File) error {
 		operation := &spec.Operation{
 			OperationID: routeInfo.OperationID,
 			Tags:        []string{routeInfo.Tag},
+			Parameters:  b.parseParameters(genDecl),
+			RequestBody: b.parseRequestBody(genDecl),
 			Responses: &spec.Responses{
 				StatusCodeResponses: make(map[string]*spec.Response),
 			},
@@ -167,6 +274,9 @@ func (b *Builder) parseRoutes(file *ast.File) error {
 				return err
 			}
 		}
+		applyProduces(operation)
+
+		b.operationIDs.Add(operation.OperationID, operationLocation(b.fset.Position(genDecl.Pos()), routeInfo.Method, routeInfo.Path))
 
 		// Add operation to path
 		if b.spec.Paths.PathItems[routeInfo.Path] == nil {
@@ -195,6 +305,89 @@ func (b *Builder) parseRoutes(file *ast.File) error {
 	return nil
 }
 
+// parseParameters builds operation parameters from the fields of a route struct
+func (b *Builder) parseParameters(genDecl *ast.GenDecl) []*spec.Parameter {
+	var params []*spec.Parameter
+	for _, s := range genDecl.Specs {
+		typeSpec, ok := s.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+
+		for _, field := range structType.Fields.List {
+			// Skip fields without names (embedded structs)
+			if len(field.Names) == 0 {
+				continue
+			}
+
+			name, _ := b.getJSONName(field)
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Names[0].Name
+			}
+
+			var tag string
+			if field.Tag != nil {
+				tag = strings.Trim(field.Tag.Value, "`")
+			}
+
+			schema := b.parseFieldType(field.Type)
+			if param := buildParameter(name, tag, schema, field.Doc, field.Comment); param != nil {
+				params = append(params, param)
+			}
+		}
+	}
+	return params
+}
+
+// parseRequestBody builds the operation request body from the "in: body" field of a route struct
+func (b *Builder) parseRequestBody(genDecl *ast.GenDecl) *spec.RequestBody {
+	for _, s := range genDecl.Specs {
+		typeSpec, ok := s.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+
+		for _, field := range structType.Fields.List {
+			if body := buildRequestBody(b.parseBodyType(field.Type), field.Doc, field.Comment); body != nil {
+				return body
+			}
+		}
+	}
+	return nil
+}
+
+// parseBodyType parses a body field type into a schema, referencing named types
+// Example: Pet -> #/components/schemas/Pet, []Pet -> array of #/components/schemas/Pet
+func (b *Builder) parseBodyType(expr ast.Expr) *spec.Schema {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if b.jsonType(t.Name) == "object" {
+			return &spec.Schema{Ref: "#/components/schemas/" + t.Name}
+		}
+	case *ast.ArrayType:
+		return &spec.Schema{
+			Type:  "array",
+			Items: b.parseBodyType(t.Elt),
+		}
+	case *ast.StarExpr:
+		return b.parseBodyType(t.X)
+	}
+	return b.parseFieldType(expr)
+}
+
 // parseModels parses swagger:model comments
 func (b *Builder) parseModels(file *ast.File) error {
 	for _, decl := range file.Decls {
@@ -222,7 +415,12 @@ func (b *Builder) parseModels(file *ast.File) error {
 			}
 
 			// Create schema
-			schema := b.parseStruct(structType)
+			schema := b.parseStruct(typeSpec.Name.Name, structType)
+
+			// Parse model tags (oneOf, anyOf, allOf)
+			if err := parsers.GlobalRegistry().Parse("swagger:model", genDecl.Doc, schema, parsers.ContextModel); err != nil {
+				return fmt.Errorf("model %s: %w", typeSpec.Name.Name, err)
+			}
 
 			// Initialize Components if needed
 			if b.spec.Components == nil {
@@ -241,68 +439,177 @@ func (b *Builder) parseModels(file *ast.File) error {
 }
 
 // parseStruct parses a struct type into a schema
-func (b *Builder) parseStruct(structType *ast.StructType) *spec.Schema {
+// Fields of embedded structs are promoted into the schema, as encoding/json does
+func (b *Builder) parseStruct(name string, structType *ast.StructType) *spec.Schema {
+	return b.parseStructVisiting(structType, map[string]bool{b.types.key(name): true})
+}
+
+// parseStructVisiting parses a struct type, skipping the embedded structs in visited
+// so that embedding cycles terminate
+func (b *Builder) parseStructVisiting(structType *ast.StructType, visited map[string]bool) *spec.Schema {
 	schema := &spec.Schema{
 		Type:       "object",
 		Properties: make(map[string]*spec.Schema),
 	}
 
+	var embedded []*ast.Field
 	for _, field := range structType.Fields.List {
-		// Skip fields without names (embedded structs)
-		if len(field.Names) == 0 {
+		// Get JSON tag name
+		jsonName, omitempty := b.getJSONName(field)
+		if jsonName == "-" {
+			continue
+		}
+
+		// Untagged embedded structs are promoted once the direct fields are known,
+		// since those take precedence
+		if len(field.Names) == 0 && jsonName == "" {
+			embedded = append(embedded, field)
+			continue
+		}
+		if jsonName == "" {
 			continue
 		}
 
 		// Create field schema
 		fieldSchema := b.parseFieldType(field.Type)
 
-		// Parse field tags (Description, Example, Format, etc.)
-		if field.Doc != nil {
-			if err := parsers.GlobalRegistry().Parse("swagger:model", field.Doc, fieldSchema, parsers.ContextField); err != nil {
+		// Parse field tags (Description, Example, Format, etc.) from the doc
+		// comment and the trailing line comment
+		var text string
+		for _, comment := range []*ast.CommentGroup{field.Doc, field.Comment} {
+			if comment == nil {
+				continue
+			}
+			if err := parsers.GlobalRegistry().Parse("swagger:model", comment, fieldSchema, parsers.ContextField); err != nil {
 				// Ignore errors for now
 				_ = err
 			}
-		}
-
-		// Get JSON tag name
-		jsonName := b.getJSONName(field)
-		if jsonName == "" || jsonName == "-" {
-			continue
+			text += comment.Text()
 		}
 
 		schema.Properties[jsonName] = fieldSchema
+		schema.PropertyOrder = append(schema.PropertyOrder, jsonName)
+
+		// Pointer fields may be null, plain fields are always serialized unless omitempty
+		_, isPointer := field.Type.(*ast.StarExpr)
+		inferRequirement(schema, jsonName, isPointer, omitempty)
+
+		// An explicit "required:" comment overrides the inferred requirement
+		applyRequiredDirective(schema, jsonName, text)
+	}
+
+	for _, field := range embedded {
+		b.promoteEmbeddedFields(schema, field, visited)
 	}
 
 	return schema
 }
 
+// promoteEmbeddedFields adds the properties of an embedded struct that the schema doesn't define yet
+// Embedded types that aren't structs declared in the scanned files are skipped
+func (b *Builder) promoteEmbeddedFields(schema *spec.Schema, field *ast.Field, visited map[string]bool) {
+	_, isPointer := field.Type.(*ast.StarExpr)
+	key := b.types.key(embeddedTypeName(field.Type))
+	base, ok := b.structs[key]
+	if !ok || visited[key] {
+		return
+	}
+
+	// The embedded struct's field types resolve against its own package
+	outer := b.types
+	b.types = b.types.in(base.pkg)
+	visited[key] = true
+	baseSchema := b.parseStructVisiting(base.structType, visited)
+	delete(visited, key)
+	b.types = outer
+
+	schema.PromoteProperties(baseSchema, isPointer)
+}
+
+// structDecl is a struct type declared in a scanned file
+type structDecl struct {
+	pkg        string
+	structType *ast.StructType
+}
+
+// collectStructTypes indexes the struct types declared in the given files by package and name
+// Example: "type Base struct{...}" in package models -> "models.Base"
+func collectStructTypes(files []*ast.File) map[string]*structDecl {
+	structs := make(map[string]*structDecl)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, s := range genDecl.Specs {
+				typeSpec, ok := s.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+					pkg := file.Name.Name
+					structs[pkg+"."+typeSpec.Name.Name] = &structDecl{pkg: pkg, structType: structType}
+				}
+			}
+		}
+	}
+	return structs
+}
+
+// embeddedTypeName returns the type name of an embedded field
+// Example: Base -> "Base", *Base -> "Base", models.Base -> "models.Base"
+func embeddedTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	return types.ExprString(expr)
+}
+
 // parseFieldType parses a field type into a schema type
 func (b *Builder) parseFieldType(expr ast.Expr) *spec.Schema {
+	// Well-known types (time.Time, uuid.UUID, []byte, ...) map to formatted primitives
+	if schema := wellKnownTypeSchema(types.ExprString(expr)); schema != nil {
+		return schema
+	}
+
 	schema := &spec.Schema{}
 
 	switch t := expr.(type) {
 	case *ast.Ident:
 		// Basic types
-		schema.Type = goTypeToJSONType(t.Name)
+		schema.Type = b.jsonType(t.Name)
+	case *ast.SelectorExpr:
+		// Named types of other packages (e.g., models.UserID)
+		schema.Type = b.jsonType(types.ExprString(t))
 	case *ast.ArrayType:
 		schema.Type = "array"
 		schema.Items = b.parseFieldType(t.Elt)
+	case *ast.MapType:
+		// Maps become objects whose additionalProperties describe the values
+		schema.Type = "object"
+		if _, ok := t.Value.(*ast.InterfaceType); ok || types.ExprString(t.Value) == "any" {
+			schema.AdditionalProperties = true
+		} else {
+			schema.AdditionalProperties = b.parseFieldType(t.Value)
+		}
 	case *ast.StarExpr:
 		// Pointer type
 		return b.parseFieldType(t.X)
-	case *ast.SelectorExpr:
-		// External type (e.g., time.Time)
-		if ident, ok := t.X.(*ast.Ident); ok {
-			if ident.Name == "time" && t.Sel.Name == "Time" {
-				schema.Type = "string"
-				schema.Format = "date-time"
-			}
-		}
 	}
 
 	return schema
 }
 
+// jsonType converts a Go type name to its JSON Schema type,
+// resolving named types to their underlying primitive
+func (b *Builder) jsonType(goType string) string {
+	if jsonType, ok := b.types.jsonType(goType); ok {
+		return jsonType
+	}
+	return goTypeToJSONType(goType)
+}
+
 // goTypeToJSONType converts Go types to JSON Schema types
 func goTypeToJSONType(goType string) string {
 	switch goType {
@@ -320,10 +627,12 @@ func goTypeToJSONType(goType string) string {
 	}
 }
 
-// getJSONName extracts the JSON name from struct tags
-func (b *Builder) getJSONName(field *ast.Field) string {
+// getJSONName extracts the JSON name from struct tags and reports whether
+// the tag carries the omitempty option
+// Example: `json:"email,omitempty"` -> ("email", true)
+func (b *Builder) getJSONName(field *ast.Field) (string, bool) {
 	if field.Tag == nil {
-		return ""
+		return "", false
 	}
 
 	tag := field.Tag.Value
@@ -335,13 +644,11 @@ func (b *Builder) getJSONName(field *ast.Field) string {
 			jsonTag := strings.TrimPrefix(part, "json:")
 			jsonTag = strings.Trim(jsonTag, `"`)
 			parts := strings.Split(jsonTag, ",")
-			if len(parts) > 0 {
-				return parts[0]
-			}
+			return parts[0], slices.Contains(parts[1:], "omitempty")
 		}
 	}
 
-	return ""
+	return "", false
 }
 
 // BuildMultiple scans files and builds multiple OpenAPI specifications based on Spec: tags
@@ -471,6 +778,12 @@ func (b *Builder) createEmptySpec() *spec.OpenAPI {
 		copy(newSpec.Servers, b.spec.Servers)
 	}
 
+	// Copy Tags from main spec
+	if b.spec.Tags != nil {
+		newSpec.Tags = make([]*spec.Tag, len(b.spec.Tags))
+		copy(newSpec.Tags, b.spec.Tags)
+	}
+
 	return newSpec
 }
 
@@ -481,28 +794,8 @@ func (b *Builder) addOperationToSpec(targetSpec *spec.OpenAPI, path, method stri
 		targetSpec.Paths.PathItems[path] = &spec.PathItem{}
 	}
 
-	pathItem := targetSpec.Paths.PathItems[path]
-
 	// Clone the operation to avoid sharing references
-	clonedOp := cloneOperation(operation)
-
-	// Add operation to the appropriate method
-	switch strings.ToUpper(method) {
-	case "GET":
-		pathItem.Get = clonedOp
-	case "POST":
-		pathItem.Post = clonedOp
-	case "PUT":
-		pathItem.Put = clonedOp
-	case "DELETE":
-		pathItem.Delete = clonedOp
-	case "PATCH":
-		pathItem.Patch = clonedOp
-	case "OPTIONS":
-		pathItem.Options = clonedOp
-	case "HEAD":
-		pathItem.Head = clonedOp
-	}
+	targetSpec.Paths.PathItems[path].SetOperation(method, cloneOperation(operation))
 }
 
 // getSpecNamesFromOperation extracts spec names from operation's x-specs extension
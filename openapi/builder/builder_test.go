@@ -4,7 +4,13 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+
+	coreast "github.com/reation-io/apikit/core/ast"
+	"github.com/reation-io/apikit/openapi/spec"
 )
 
 func TestBuilder_Meta(t *testing.T) {
@@ -45,6 +51,59 @@ type API struct{}
 	}
 }
 
+func TestBuilder_MetaServers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "api.go")
+	content := `package main
+
+// swagger:meta
+// Title: My Test API
+// Servers:
+// - url: https://api.example.com description: "Production"
+// - url: https://{region}.staging.example.com
+//   description: Staging
+//   variables:
+//     region:
+//       default: eu
+//       enum: [eu, us]
+// Version: 1.0.0
+type API struct{}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "*.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	if len(openapi.Servers) != 2 {
+		t.Fatalf("expected 2 servers, got %d", len(openapi.Servers))
+	}
+
+	prod := openapi.Servers[0]
+	if prod.URL != "https://api.example.com" || prod.Description != "Production" {
+		t.Errorf("unexpected first server: %+v", prod)
+	}
+
+	staging := openapi.Servers[1]
+	if staging.URL != "https://{region}.staging.example.com" || staging.Description != "Staging" {
+		t.Errorf("unexpected second server: %+v", staging)
+	}
+	region := staging.Variables["region"]
+	if region == nil || region.Default != "eu" || len(region.Enum) != 2 {
+		t.Errorf("unexpected region variable: %+v", region)
+	}
+
+	// Directives after the block are still parsed
+	if openapi.Info.Version != "1.0.0" {
+		t.Errorf("expected version '1.0.0', got %q", openapi.Info.Version)
+	}
+}
+
 func TestBuilder_Route(t *testing.T) {
 	// Create a temporary directory
 	tmpDir := t.TempDir()
@@ -90,6 +149,204 @@ type CreateUserRequest struct{}
 	}
 }
 
+func TestBuilder_RouteParameters(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "handlers.go")
+	content := `package main
+
+// swagger:route GET /pets pet listPets
+type ListPetsRequest struct {
+	// Tags to filter by
+	// in: query
+	Tags []string ` + "`json:\"tags\"`" + `
+
+	// in: body
+	Body string
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "*.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	operation := openapi.Paths.PathItems["/pets"].Get
+	if len(operation.Parameters) != 1 {
+		t.Fatalf("expected 1 parameter, got %d", len(operation.Parameters))
+	}
+
+	param := operation.Parameters[0]
+	if param.Name != "tags" || param.In != "query" {
+		t.Errorf("expected query parameter 'tags', got %s %q", param.In, param.Name)
+	}
+	if param.Description != "Tags to filter by" {
+		t.Errorf("expected description 'Tags to filter by', got %q", param.Description)
+	}
+	if param.Schema == nil || param.Schema.Type != "array" || param.Schema.Items.Type != "string" {
+		t.Errorf("expected array of strings schema, got %+v", param.Schema)
+	}
+}
+
+func TestBuilder_RouteParameterStyle(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "handlers.go")
+	content := `package main
+
+// swagger:route GET /pets pet listPets
+type ListPetsRequest struct {
+	// in: query tags,style=pipeDelimited,explode=false
+	Tags []string ` + "`json:\"tags\"`" + `
+
+	// in: query
+	IDs []int ` + "`json:\"ids\" query:\"ids,style=spaceDelimited\"`" + `
+
+	// in: query
+	Sort []string ` + "`json:\"sort\"`" + `
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "*.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	params := openapi.Paths.PathItems["/pets"].Get.Parameters
+	if len(params) != 3 {
+		t.Fatalf("expected 3 parameters, got %d", len(params))
+	}
+
+	if tags := params[0]; tags.Style != "pipeDelimited" || tags.Explode == nil || *tags.Explode {
+		t.Errorf("expected tags to be pipeDelimited without explode, got style %q explode %v", tags.Style, tags.Explode)
+	}
+	if ids := params[1]; ids.Style != "spaceDelimited" || ids.Explode != nil {
+		t.Errorf("expected ids to be spaceDelimited from the tag, got style %q explode %v", ids.Style, ids.Explode)
+	}
+	if sort := params[2]; sort.Style != "" || sort.Explode != nil {
+		t.Errorf("expected sort to keep the default style, got style %q explode %v", sort.Style, sort.Explode)
+	}
+
+	data, err := json.Marshal(params[0])
+	if err != nil {
+		t.Fatalf("marshal parameter: %v", err)
+	}
+	if !strings.Contains(string(data), `"style":"pipeDelimited","explode":false`) {
+		t.Errorf("expected style and explode in JSON, got %s", data)
+	}
+}
+
+func TestBuilder_DuplicateOperationID(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pets := `package main
+
+// swagger:route GET /pets pet listPets
+type ListPetsRequest struct{}
+`
+	animals := `package main
+
+// swagger:route GET /animals pet listPets
+type ListAnimalsRequest struct{}
+`
+	for name, content := range map[string]string{"animals.go": animals, "pets.go": pets} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+
+	_, err := NewBuilder(filepath.Join(tmpDir, "*.go")).Build()
+	if err == nil {
+		t.Fatal("expected an error for the duplicate operationId")
+	}
+	for _, want := range []string{
+		`duplicate operationId "listPets"`,
+		filepath.Join(tmpDir, "animals.go") + ":4:1 (GET /animals)",
+		filepath.Join(tmpDir, "pets.go") + ":4:1 (GET /pets)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %v", want, err)
+		}
+	}
+}
+
+func TestBuilder_RouteRequestBody(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "handlers.go")
+	content := `package main
+
+// swagger:model
+type Pet struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// swagger:route PUT /pet pet updatePet
+type UpdatePetRequest struct {
+	// Pet object that needs to be updated
+	// in: body
+	// required: true
+	Body Pet
+}
+
+// swagger:route POST /pets pet addPets
+type AddPetsRequest struct {
+	// in: body
+	Body []Pet
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "*.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	put := openapi.Paths.PathItems["/pet"].Put
+	if put.RequestBody == nil {
+		t.Fatal("expected PUT /pet to have a requestBody")
+	}
+	if !put.RequestBody.Required {
+		t.Error("expected PUT /pet requestBody to be required")
+	}
+	if put.RequestBody.Description != "Pet object that needs to be updated" {
+		t.Errorf("expected requestBody description, got %q", put.RequestBody.Description)
+	}
+	media := put.RequestBody.Content["application/json"]
+	if media == nil || media.Schema == nil {
+		t.Fatal("expected application/json media type with schema")
+	}
+	if media.Schema.Ref != "#/components/schemas/Pet" {
+		t.Errorf("expected schema ref to Pet, got %q", media.Schema.Ref)
+	}
+	if len(put.Parameters) != 0 {
+		t.Errorf("expected body field not to be a parameter, got %d parameters", len(put.Parameters))
+	}
+
+	post := openapi.Paths.PathItems["/pets"].Post
+	if post.RequestBody == nil {
+		t.Fatal("expected POST /pets to have a requestBody")
+	}
+	if post.RequestBody.Required {
+		t.Error("expected POST /pets requestBody to be optional")
+	}
+	schema := post.RequestBody.Content["application/json"].Schema
+	if schema.Type != "array" || schema.Items == nil || schema.Items.Ref != "#/components/schemas/Pet" {
+		t.Errorf("expected array of Pet refs, got %+v", schema)
+	}
+}
+
 func TestBuilder_Model(t *testing.T) {
 	// Create a temporary directory
 	tmpDir := t.TempDir()
@@ -149,6 +406,210 @@ type User struct {
 	}
 }
 
+func TestBuilder_ModelMapField(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "models.go")
+	content := `package main
+
+// swagger:model
+type Inventory struct {
+	Counts map[string]int32 ` + "`json:\"counts\"`" + `
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "*.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	counts := openapi.Components.Schemas["Inventory"].Properties["counts"]
+	if counts == nil || counts.Type != "object" {
+		t.Fatalf("expected counts to be an object, got %+v", counts)
+	}
+	additional, ok := counts.AdditionalProperties.(*spec.Schema)
+	if !ok || additional.Type != "integer" {
+		t.Errorf("expected integer additionalProperties, got %+v", counts.AdditionalProperties)
+	}
+}
+
+func TestBuilder_ModelRequired(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "models.go")
+	content := `package main
+
+// swagger:model
+type Pet struct {
+	// required: true
+	Name string ` + "`json:\"name\"`" + `
+
+	// required: true
+	PhotoUrls []string ` + "`json:\"photoUrls\"`" + `
+
+	// required: false
+	Status string ` + "`json:\"status,omitempty\"`" + `
+
+	Tag  string ` + "`json:\"tag,omitempty\"`" + ` // required: true
+	Note string ` + "`json:\"note\"`" + `           // required: false
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "*.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	schema := openapi.Components.Schemas["Pet"]
+	// Trailing line comments count like doc comments
+	if want := []string{"name", "photoUrls", "tag"}; !slices.Equal(schema.Required, want) {
+		t.Errorf("expected required %v, got %v", want, schema.Required)
+	}
+}
+
+func TestBuilder_ModelNullableAndOmitempty(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFile := filepath.Join(tmpDir, "models.go")
+	content := `package main
+
+// swagger:model
+type Profile struct {
+	Name     string  ` + "`json:\"name\"`" + `
+	Nickname *string ` + "`json:\"nickname\"`" + `
+	Bio      string  ` + "`json:\"bio,omitempty\"`" + `
+
+	// required: false
+	Avatar string ` + "`json:\"avatar\"`" + `
+
+	// required: true
+	Website string ` + "`json:\"website,omitempty\"`" + `
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "*.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	schema := openapi.Components.Schemas["Profile"]
+	if schema == nil {
+		t.Fatal("expected Profile schema")
+	}
+
+	wantRequired := []string{"name", "website"}
+	if !reflect.DeepEqual(schema.Required, wantRequired) {
+		t.Errorf("expected required %v, got %v", wantRequired, schema.Required)
+	}
+	if !schema.Properties["nickname"].Nullable {
+		t.Error("expected pointer field nickname to be nullable")
+	}
+	if schema.Properties["name"].Nullable {
+		t.Error("expected plain field name not to be nullable")
+	}
+
+	// The generic adapter infers the same requirements from the same source
+	result, err := coreast.New().Parse(testFile)
+	if err != nil {
+		t.Fatalf("generic parse failed: %v", err)
+	}
+	generic, err := ExtractFromGeneric([]*coreast.ParseResult{result})
+	if err != nil {
+		t.Fatalf("ExtractFromGeneric failed: %v", err)
+	}
+	genericSchema := generic.Components.Schemas["Profile"]
+	if !reflect.DeepEqual(genericSchema.Required, schema.Required) {
+		t.Errorf("builder required %v differs from adapter required %v", schema.Required, genericSchema.Required)
+	}
+	for name, prop := range schema.Properties {
+		if prop.Nullable != genericSchema.Properties[name].Nullable {
+			t.Errorf("property %s: builder nullable %v differs from adapter", name, prop.Nullable)
+		}
+	}
+}
+
+func TestBuilder_ModelEmbeddedStructs(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Embedded structs declared in another file are promoted too
+	base := `package main
+
+type Base struct {
+	// minimum: 1
+	ID        int64  ` + "`json:\"id\"`" + `
+	CreatedAt string ` + "`json:\"createdAt,omitempty\"`" + `
+	Name      string ` + "`json:\"name\"`" + `
+}
+
+type Audit struct {
+	UpdatedBy string ` + "`json:\"updatedBy\"`" + `
+	*Loop
+}
+
+type Loop struct {
+	Audit
+	Revision int ` + "`json:\"revision\"`" + `
+}
+`
+	models := `package main
+
+type Owner struct {
+	Email string ` + "`json:\"email\"`" + `
+}
+
+// swagger:model
+type Pet struct {
+	Base
+	*Audit
+	Owner ` + "`json:\"owner\"`" + `
+
+	// Name shadows Base.Name
+	Name int ` + "`json:\"name\"`" + `
+}
+`
+	for name, content := range map[string]string{"base.go": base, "models.go": models} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "*.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	pet := openapi.Components.Schemas["Pet"]
+	want := []string{"owner", "name", "id", "createdAt", "updatedBy", "revision"}
+	if !slices.Equal(pet.PropertyOrder, want) {
+		t.Errorf("expected properties %v, got %v", want, pet.PropertyOrder)
+	}
+	if id := pet.Properties["id"]; id.Type != "integer" || id.Minimum == nil || *id.Minimum != 1 {
+		t.Errorf("expected promoted id with its field tags, got %+v", id)
+	}
+	if name := pet.Properties["name"]; name.Type != "integer" {
+		t.Errorf("expected Pet.Name to shadow Base.Name, got %+v", name)
+	}
+
+	// Fields of a non-pointer embed keep their requirement, pointer embeds may be nil
+	slices.Sort(pet.Required)
+	if want := []string{"id", "name", "owner"}; !slices.Equal(pet.Required, want) {
+		t.Errorf("expected required %v, got %v", want, pet.Required)
+	}
+}
+
 func TestBuilder_JSON(t *testing.T) {
 	// Create a simple spec
 	builder := NewBuilder()
@@ -174,3 +635,271 @@ func TestBuilder_JSON(t *testing.T) {
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 || (len(s) > 0 && (s[0:len(substr)] == substr || contains(s[1:], substr))))
 }
+
+func TestBuilder_NamedTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Named types are resolved through go/packages, which needs a module
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/test\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	testFile := filepath.Join(tmpDir, "models.go")
+	content := `package main
+
+type UserID int64
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusDisabled Status = "disabled"
+)
+
+// swagger:model
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// swagger:model
+type User struct {
+	ID      UserID  ` + "`json:\"id\"`" + `
+	Status  Status  ` + "`json:\"status\"`" + `
+	Address Address ` + "`json:\"address\"`" + `
+}
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "*.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	schema := openapi.Components.Schemas["User"]
+	if schema == nil {
+		t.Fatal("expected User schema to exist")
+	}
+
+	if got := schema.Properties["id"].Type; got != "integer" {
+		t.Errorf("expected id type 'integer', got %q", got)
+	}
+	if got := schema.Properties["status"].Type; got != "string" {
+		t.Errorf("expected status type 'string', got %q", got)
+	}
+}
+
+// writeNestedTree creates a directory tree with Go files at several depths,
+// plus files in directories that recursive patterns skip
+func writeNestedTree(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"main.go":             "package main\n",
+		"api/users.go":        "package api\n",
+		"api/notes.txt":       "not go\n",
+		"api/v2/pets.go":      "package v2\n",
+		"vendor/dep/dep.go":   "package dep\n",
+		".git/hooks/hook.go":  "package hooks\n",
+		"api/testdata/fix.go": "package fix\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return tmpDir
+}
+
+func TestBuilder_PackageQualifiedTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Both packages declare a Status type, with different underlying types
+	files := map[string]string{
+		"go.mod": "module example.com/test\n\ngo 1.22\n",
+		"models/models.go": `package models
+
+type Status int
+
+type Base struct {
+	Code Status ` + "`json:\"code\"`" + `
+}
+`,
+		"api/api.go": `package api
+
+import "example.com/test/models"
+
+type Status string
+
+// swagger:model
+type Order struct {
+	models.Base
+	Status Status        ` + "`json:\"status\"`" + `
+	Level  models.Status ` + "`json:\"level\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "..."))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	order := openapi.Components.Schemas["Order"]
+	if order == nil {
+		t.Fatal("expected Order schema")
+	}
+	if got := order.Properties["status"].Type; got != "string" {
+		t.Errorf("expected status to resolve to api.Status (string), got %q", got)
+	}
+	if got := order.Properties["level"].Type; got != "integer" {
+		t.Errorf("expected level to resolve to models.Status (integer), got %q", got)
+	}
+	if code := order.Properties["code"]; code == nil || code.Type != "integer" {
+		t.Errorf("expected promoted code to resolve against package models, got %+v", code)
+	}
+}
+
+func TestBuilder_ImportedPackageTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Only package api is parsed; models is reached through its imports
+	files := map[string]string{
+		"go.mod": "module example.com/test\n\ngo 1.22\n",
+		"models/models.go": `package models
+
+type UserID int64
+`,
+		"api/api.go": `package api
+
+import "example.com/test/models"
+
+// swagger:model
+type Order struct {
+	Owner models.UserID ` + "`json:\"owner\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	builder := NewBuilder(filepath.Join(tmpDir, "api", "api.go"))
+	openapi, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+
+	order := openapi.Components.Schemas["Order"]
+	if order == nil {
+		t.Fatal("expected Order schema")
+	}
+	if owner := order.Properties["owner"]; owner.Type != "integer" || owner.Ref != "" {
+		t.Errorf("expected owner to resolve to models.UserID (integer), got %+v", owner)
+	}
+}
+
+func TestFindFiles_Recursive(t *testing.T) {
+	tmpDir := writeNestedTree(t)
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+	}{
+		{
+			name:    "dots",
+			pattern: filepath.Join(tmpDir, "..."),
+			want:    []string{"api/users.go", "api/v2/pets.go", "main.go"},
+		},
+		{
+			name:    "subdirectory dots",
+			pattern: filepath.Join(tmpDir, "api") + "/...",
+			want:    []string{"api/users.go", "api/v2/pets.go"},
+		},
+		{
+			name:    "double star",
+			pattern: filepath.Join(tmpDir, "**/*.go"),
+			want:    []string{"api/users.go", "api/v2/pets.go", "main.go"},
+		},
+		{
+			name:    "double star with directory",
+			pattern: filepath.Join(tmpDir, "**/v2/*.go"),
+			want:    []string{"api/v2/pets.go"},
+		},
+		{
+			name:    "plain glob stays in the directory",
+			pattern: filepath.Join(tmpDir, "*.go"),
+			want:    []string{"main.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := FindFiles(tt.pattern)
+			if err != nil {
+				t.Fatalf("FindFiles failed: %v", err)
+			}
+
+			var got []string
+			for _, file := range files {
+				rel, _ := filepath.Rel(tmpDir, file)
+				got = append(got, filepath.ToSlash(rel))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuilder_RecursivePattern(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	modelFile := filepath.Join(tmpDir, "internal", "models", "user.go")
+	content := `package models
+
+// User is a user
+// swagger:model
+type User struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+`
+	if err := os.MkdirAll(filepath.Dir(modelFile), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(modelFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	openapi, err := NewBuilder(filepath.Join(tmpDir, "...")).Build()
+	if err != nil {
+		t.Fatalf("failed to build spec: %v", err)
+	}
+	if openapi.Components == nil || openapi.Components.Schemas["User"] == nil {
+		t.Errorf("expected User schema from a nested package, got %+v", openapi.Components)
+	}
+}
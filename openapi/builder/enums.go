@@ -0,0 +1,201 @@
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	coreast "github.com/reation-io/apikit/core/ast"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// enumType is a Go string type generated for an enum annotation
+type enumType struct {
+	Name      string
+	Source    string // Schema and property the enum was declared on, e.g. "Order.status"
+	Constants []enumConstant
+}
+
+// enumConstant is a single constant of an enumType
+type enumConstant struct {
+	Name  string
+	Value string
+}
+
+// GenerateEnums generates Go types and constants for the string enums of the spec's models
+// Each string property with an enum annotation becomes a type named after the
+// model and property, with one constant per value:
+//
+//	type OrderStatus string
+//
+//	const (
+//		OrderStatusPlaced    OrderStatus = "placed"
+//		OrderStatusDelivered OrderStatus = "delivered"
+//	)
+//
+// Array properties use the enum of their items, and generated names avoid the
+// reserved identifiers already declared in the package (see DeclaredNames)
+// Returns the formatted source of a file in the given package, or nil if the
+// spec has no string enums
+func GenerateEnums(openapi *spec.OpenAPI, packageName string, reserved []string) ([]byte, error) {
+	enums := collectEnums(openapi, reserved)
+	if len(enums) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by apikit. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n", packageName)
+
+	for _, enum := range enums {
+		fmt.Fprintf(&buf, "\n// %s enumerates the values of %s\n", enum.Name, enum.Source)
+		fmt.Fprintf(&buf, "type %s string\n\n", enum.Name)
+		buf.WriteString("const (\n")
+		for _, c := range enum.Constants {
+			fmt.Fprintf(&buf, "\t%s %s = %s\n", c.Name, enum.Name, strconv.Quote(c.Value))
+		}
+		buf.WriteString(")\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting enums: %w", err)
+	}
+	return src, nil
+}
+
+// collectEnums finds the string enums declared on component schemas, sorted by type name
+func collectEnums(openapi *spec.OpenAPI, reserved []string) []enumType {
+	if openapi == nil || openapi.Components == nil {
+		return nil
+	}
+
+	schemas := openapi.Components.Schemas
+
+	// Model types live in the same package, so their names are taken
+	var enums []enumType
+	seen := make(map[string]bool)
+	for schemaName := range schemas {
+		seen[goIdentifier(schemaName)] = true
+	}
+	for _, name := range reserved {
+		seen[name] = true
+	}
+	add := func(typeName, source string, schema *spec.Schema) {
+		if schema != nil && schema.Type == "array" {
+			schema = schema.Items
+		}
+		if schema == nil || schema.Type != "string" || len(schema.Enum) == 0 {
+			return
+		}
+
+		typeName = uniqueIdentifier(typeName, seen)
+		enums = append(enums, enumType{
+			Name:      typeName,
+			Source:    source,
+			Constants: enumConstants(typeName, schema.Enum, seen),
+		})
+	}
+
+	for _, schemaName := range slices.Sorted(maps.Keys(schemas)) {
+		schema := schemas[schemaName]
+		if schema == nil {
+			continue
+		}
+
+		for _, propName := range slices.Sorted(maps.Keys(schema.Properties)) {
+			add(goIdentifier(schemaName)+goIdentifier(propName), schemaName+"."+propName, schema.Properties[propName])
+		}
+	}
+
+	slices.SortStableFunc(enums, func(a, b enumType) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return enums
+}
+
+// DeclaredNames returns the package-level identifiers declared by the parsed files,
+// such as a "type OrderStatus string" that isn't a component schema
+func DeclaredNames(results []*coreast.ParseResult) []string {
+	var names []string
+	for _, result := range results {
+		if result.File == nil {
+			continue
+		}
+		for _, decl := range result.File.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					names = append(names, decl.Name.Name)
+				}
+			case *ast.GenDecl:
+				for _, s := range decl.Specs {
+					switch s := s.(type) {
+					case *ast.TypeSpec:
+						names = append(names, s.Name.Name)
+					case *ast.ValueSpec:
+						for _, name := range s.Names {
+							names = append(names, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// enumConstants builds the constants of an enum type, prefixed with the type name
+// Names already in seen are made unique, since constants share the package scope
+// Example: ("OrderStatus", ["in-progress"]) -> OrderStatusInProgress = "in-progress"
+func enumConstants(typeName string, values []any, seen map[string]bool) []enumConstant {
+	var constants []enumConstant
+	for _, v := range values {
+		value := fmt.Sprint(v)
+
+		suffix := goIdentifier(value)
+		if suffix == "" {
+			suffix = "Empty"
+			if value != "" {
+				suffix = "Value"
+			}
+		}
+
+		constants = append(constants, enumConstant{
+			Name:  uniqueIdentifier(typeName+suffix, seen),
+			Value: value,
+		})
+	}
+	return constants
+}
+
+// goIdentifier converts a name into an exported Go identifier
+// Letters and digits are kept and every other character starts a new word
+// Example: "photo_urls" -> "PhotoUrls", "write:pets" -> "WritePets", "2xx" -> "2xx"
+func goIdentifier(name string) string {
+	var b strings.Builder
+	for word := range strings.FieldsFuncSeq(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// uniqueIdentifier returns name, or name with a numeric suffix if it's already taken
+func uniqueIdentifier(name string, seen map[string]bool) string {
+	unique := name
+	for i := 2; seen[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	seen[unique] = true
+	return unique
+}
@@ -0,0 +1,146 @@
+package builder
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestGenerateEnums(t *testing.T) {
+	openapi := &spec.OpenAPI{
+		Components: &spec.Components{
+			Schemas: map[string]*spec.Schema{
+				"Order": {
+					Type: "object",
+					Properties: map[string]*spec.Schema{
+						"status":   {Type: "string", Enum: []any{"placed", "approved", "delivered"}},
+						"quantity": {Type: "integer", Enum: []any{int64(1), int64(2)}},
+					},
+				},
+				"Pet": {
+					Type: "object",
+					Properties: map[string]*spec.Schema{
+						"pet_tags": {
+							Type:  "array",
+							Items: &spec.Schema{Type: "string", Enum: []any{"in-stock", "write:pets", "2xx", "", "+"}},
+						},
+					},
+				},
+				// Collides with the generated name for Order.status
+				"OrderStatus": {Type: "object"},
+			},
+		},
+	}
+
+	src, err := GenerateEnums(openapi, "models", nil)
+	if err != nil {
+		t.Fatalf("GenerateEnums failed: %v", err)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "enums.go", src, 0)
+	if err != nil {
+		t.Fatalf("generated code doesn't parse: %v\n%s", err, src)
+	}
+	if file.Name.Name != "models" {
+		t.Errorf("expected package models, got %s", file.Name.Name)
+	}
+
+	types := make(map[string]bool)
+	constants := make(map[string]string)
+	for _, decl := range file.Decls {
+		for _, s := range decl.(*ast.GenDecl).Specs {
+			switch s := s.(type) {
+			case *ast.TypeSpec:
+				types[s.Name.Name] = true
+			case *ast.ValueSpec:
+				value, _ := strconv.Unquote(s.Values[0].(*ast.BasicLit).Value)
+				constants[s.Names[0].Name] = value
+			}
+		}
+	}
+
+	for _, name := range []string{"OrderStatus2", "PetPetTags"} {
+		if !types[name] {
+			t.Errorf("expected type %s, got %v", name, types)
+		}
+	}
+	if types["OrderQuantity"] {
+		t.Error("expected no type for integer enum")
+	}
+
+	want := map[string]string{
+		"OrderStatus2Placed":    "placed",
+		"OrderStatus2Approved":  "approved",
+		"OrderStatus2Delivered": "delivered",
+		"PetPetTagsInStock":     "in-stock",
+		"PetPetTagsWritePets":   "write:pets",
+		"PetPetTags2xx":         "2xx",
+		"PetPetTagsEmpty":       "",
+		"PetPetTagsValue":       "+",
+	}
+	if len(constants) != len(want) {
+		t.Errorf("expected %d constants, got %v", len(want), constants)
+	}
+	for name, value := range want {
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			t.Errorf("%q is not an exported identifier", name)
+		}
+		if got, ok := constants[name]; !ok || got != value {
+			t.Errorf("expected constant %s = %q, got %q (present: %v)", name, value, got, ok)
+		}
+	}
+
+	if !strings.HasPrefix(string(src), "// Code generated by apikit. DO NOT EDIT.") {
+		t.Error("expected generated code header")
+	}
+}
+
+func TestGenerateEnums_NoEnums(t *testing.T) {
+	openapi := &spec.OpenAPI{
+		Components: &spec.Components{
+			Schemas: map[string]*spec.Schema{
+				"User": {Type: "object", Properties: map[string]*spec.Schema{"name": {Type: "string"}}},
+			},
+		},
+	}
+
+	src, err := GenerateEnums(openapi, "models", nil)
+	if err != nil {
+		t.Fatalf("GenerateEnums failed: %v", err)
+	}
+	if src != nil {
+		t.Errorf("expected no output, got:\n%s", src)
+	}
+}
+
+func TestGenerateEnums_ReservedNames(t *testing.T) {
+	openapi := &spec.OpenAPI{
+		Components: &spec.Components{
+			Schemas: map[string]*spec.Schema{
+				"Order": {
+					Type: "object",
+					Properties: map[string]*spec.Schema{
+						"status": {Type: "string", Enum: []any{"placed"}},
+					},
+				},
+			},
+		},
+	}
+
+	// The package already declares the status field's type and a constant
+	src, err := GenerateEnums(openapi, "models", []string{"OrderStatus", "OrderStatus2Placed"})
+	if err != nil {
+		t.Fatalf("GenerateEnums failed: %v", err)
+	}
+
+	for _, want := range []string{"type OrderStatus2 string", `OrderStatus2Placed2 OrderStatus2 = "placed"`} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("expected %q in generated enums:\n%s", want, src)
+		}
+	}
+}
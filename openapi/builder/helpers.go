@@ -3,9 +3,26 @@ package builder
 import (
 	"fmt"
 	"go/ast"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+var (
+	// rxIn matches the "in:" field directive (e.g., "in: query")
+	rxIn = regexp.MustCompile(`(?im)^\s*in\s*:\s*(\w+)`)
+
+	// rxInModifiers matches the text following the "in:" location (e.g., "in: query tags,explode")
+	rxInModifiers = regexp.MustCompile(`(?im)^\s*in\s*:\s*\w+[ \t]+(.+)$`)
+
+	// rxDirectiveLine matches comment lines carrying a "Key: value" directive
+	rxDirectiveLine = regexp.MustCompile(`^[A-Za-z][\w-]*\s*:`)
 )
 
 // hasDirective checks if comments contain a specific directive
@@ -113,3 +130,260 @@ func parseQuotedFields(s string) []string {
 
 	return fields
 }
+
+// buildParameter builds an OpenAPI parameter from a request struct field
+// The field comments must contain an "in:" directive with a parameter location
+// (query, path, header or cookie); otherwise nil is returned
+// Field directives (example, format, enum, ...) are applied to the schema,
+// and the leading free-text comment becomes the parameter description
+// Style and explode come from the struct tag of the location
+// (e.g., `query:"tags,style=pipeDelimited"`) or the "in:" directive
+// (e.g., "in: query tags,explode=false"), the directive taking precedence
+func buildParameter(name, tag string, schema *spec.Schema, comments ...*ast.CommentGroup) *spec.Parameter {
+	var text strings.Builder
+	for _, c := range comments {
+		if c != nil {
+			text.WriteString(c.Text())
+		}
+	}
+
+	m := rxIn.FindStringSubmatch(text.String())
+	if m == nil {
+		return nil
+	}
+
+	in := strings.ToLower(m[1])
+	switch in {
+	case "query", "path", "header", "cookie":
+	default:
+		return nil
+	}
+
+	// Apply field directives to the parameter schema
+	for _, c := range comments {
+		if c != nil {
+			parsers.GlobalRegistry().Parse("swagger:model", c, schema, parsers.ContextField)
+		}
+	}
+
+	param := &spec.Parameter{
+		Name:        name,
+		In:          in,
+		Description: leadingDescription(text.String()),
+		Schema:      schema,
+	}
+	if param.Description == "" {
+		param.Description = schema.Description
+	}
+
+	param.Required = isRequired(text.String())
+
+	tagValue, _ := reflect.StructTag(tag).Lookup(in)
+	_, tagOptions, _ := strings.Cut(tagValue, ",")
+	applyParameterStyle(param, tagOptions)
+	if m := rxInModifiers.FindStringSubmatch(text.String()); m != nil {
+		applyParameterStyle(param, m[1])
+	}
+
+	// Path parameters are always required
+	if in == "path" {
+		param.Required = true
+	}
+
+	return param
+}
+
+// applyParameterStyle sets the style and explode of a parameter from a list of modifiers
+// separated by commas or spaces; other tokens (such as a parameter name) are ignored
+// Example: "tags,style=pipeDelimited,explode=false" -> style pipeDelimited, explode false
+func applyParameterStyle(param *spec.Parameter, modifiers string) {
+	for token := range strings.FieldsFuncSeq(modifiers, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	}) {
+		key, value, hasValue := strings.Cut(token, "=")
+		switch key {
+		case "style":
+			param.Style = value
+		case "explode":
+			explode := true
+			if hasValue {
+				if b, err := strconv.ParseBool(value); err == nil {
+					explode = b
+				}
+			}
+			param.Explode = &explode
+		}
+	}
+}
+
+// buildRequestBody builds an OpenAPI request body from a request struct field
+// The field comments must contain an "in: body" directive; otherwise nil is returned
+// The body is described as application/json using the given schema
+func buildRequestBody(schema *spec.Schema, comments ...*ast.CommentGroup) *spec.RequestBody {
+	var text strings.Builder
+	for _, c := range comments {
+		if c != nil {
+			text.WriteString(c.Text())
+		}
+	}
+
+	m := rxIn.FindStringSubmatch(text.String())
+	if m == nil || !strings.EqualFold(m[1], "body") {
+		return nil
+	}
+
+	body := &spec.RequestBody{
+		Description: leadingDescription(text.String()),
+		Content: map[string]*spec.MediaType{
+			"application/json": {Schema: schema},
+		},
+	}
+
+	body.Required = isRequired(text.String())
+
+	return body
+}
+
+// applyProduces sets the media types of a route's "Produces:" tag on its success responses
+// The default application/json content of 2xx responses is replaced by the declared
+// media types, sharing its schema; media types set explicitly on a response line are kept
+// The x-produces extension stored by the Produces parser is removed
+func applyProduces(operation *spec.Operation) {
+	mimeTypes, ok := operation.Extensions["x-produces"].([]string)
+	if !ok {
+		return
+	}
+	delete(operation.Extensions, "x-produces")
+	if len(operation.Extensions) == 0 {
+		operation.Extensions = nil
+	}
+
+	if operation.Responses == nil {
+		return
+	}
+	for code, response := range operation.Responses.StatusCodeResponses {
+		media := response.Content["application/json"]
+		if !strings.HasPrefix(code, "2") || media == nil {
+			continue
+		}
+
+		content := make(map[string]*spec.MediaType, len(response.Content)+len(mimeTypes))
+		for mimeType, existing := range response.Content {
+			if mimeType != "application/json" {
+				content[mimeType] = existing
+			}
+		}
+		for _, mimeType := range mimeTypes {
+			if content[mimeType] == nil {
+				content[mimeType] = &spec.MediaType{Schema: media.Schema, Example: media.Example}
+			}
+		}
+		response.Content = content
+	}
+}
+
+// leadingDescription returns the free-text lines that precede the first directive
+// Example: "ID of pet to return\nin: path" -> "ID of pet to return"
+func leadingDescription(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if rxDirectiveLine.MatchString(line) {
+			break
+		}
+		if line == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " ")
+}
+
+// inferRequirement applies the requirement inference shared by the AST builder and
+// the generic adapter: pointer properties are nullable, other properties are
+// required unless their json tag has omitempty
+// Example: Nick *string -> nullable, Name string -> required, Bio string `json:"bio,omitempty"` -> optional
+func inferRequirement(schema *spec.Schema, jsonName string, isPointer, omitempty bool) {
+	if isPointer {
+		schema.Properties[jsonName].Nullable = true
+	} else if !omitempty {
+		schema.Required = append(schema.Required, jsonName)
+	}
+}
+
+// applyRequiredDirective lets an explicit "required:" comment override the inferred requirement
+// Example: "required: false" removes the property from the required list
+func applyRequiredDirective(schema *spec.Schema, jsonName, text string) {
+	required, ok := requiredDirective(text)
+	if !ok {
+		return
+	}
+	schema.Required = slices.DeleteFunc(schema.Required, func(name string) bool {
+		return name == jsonName
+	})
+	if required {
+		schema.Required = append(schema.Required, jsonName)
+	}
+}
+
+// isRequired reports whether the comment text contains a truthy "required:" directive
+func isRequired(text string) bool {
+	required, _ := requiredDirective(text)
+	return required
+}
+
+// requiredDirective parses the "required:" directive from comment text
+// The second return value reports whether the directive is present
+// Example: "required: false" -> (false, true)
+func requiredDirective(text string) (bool, bool) {
+	m := parsers.RxRequired.FindStringSubmatch(text)
+	if m == nil {
+		return false, false
+	}
+	value := strings.ToLower(m[1])
+	return value == "true" || value == "yes", true
+}
+
+// splitMapType splits a map type string into its key and value types
+// Example: "map[string][]int32" -> ("string", "[]int32", true)
+func splitMapType(goType string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(goType, "map[")
+	if !ok {
+		return "", "", false
+	}
+
+	depth := 1
+	for i, r := range rest {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return rest[:i], rest[i+1:], true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// wellKnownTypeSchema returns the schema for well-known external Go types
+// shared by the AST builder and the generic adapter
+// Returns nil if the type is not well-known
+func wellKnownTypeSchema(goType string) *spec.Schema {
+	switch goType {
+	case "time.Time":
+		return &spec.Schema{Type: "string", Format: "date-time"}
+	case "time.Duration":
+		return &spec.Schema{Type: "string"}
+	case "uuid.UUID":
+		return &spec.Schema{Type: "string", Format: "uuid"}
+	case "[]byte":
+		return &spec.Schema{Type: "string", Format: "byte"}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,215 @@
+package builder
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// httpMethods lists the methods of a path item in a fixed order
+var httpMethods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+// Merge combines several specs into one, e.g. specs generated from different packages
+// Paths, component schemas, security schemes, servers and tags are unioned
+// Info and the OpenAPI version are taken from the first spec that sets them
+// Returns an error listing every conflict: the same path and method with
+// different operationIds, or the same schema or security scheme name with
+// different definitions
+func Merge(specs ...*spec.OpenAPI) (*spec.OpenAPI, error) {
+	merged := &spec.OpenAPI{
+		Paths: &spec.Paths{
+			PathItems: make(map[string]*spec.PathItem),
+		},
+	}
+
+	var conflicts []string
+	for _, s := range specs {
+		if s == nil {
+			continue
+		}
+
+		if merged.OpenAPI == "" {
+			merged.OpenAPI = s.OpenAPI
+		}
+		if merged.Info == nil && s.Info != nil {
+			info := *s.Info
+			merged.Info = &info
+		}
+
+		conflicts = append(conflicts, mergePaths(merged, s)...)
+		conflicts = append(conflicts, mergeComponents(merged, s)...)
+		mergeServers(merged, s)
+		mergeTags(merged, s)
+		mergeSecurity(merged, s)
+	}
+
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("merge conflicts: %s", strings.Join(conflicts, "; "))
+	}
+
+	if merged.OpenAPI == "" {
+		merged.OpenAPI = "3.0.3"
+	}
+	if merged.Info == nil {
+		merged.Info = &spec.Info{Title: "API", Version: "1.0.0"}
+	}
+
+	return merged, nil
+}
+
+// mergePaths adds the operations of src to merged
+// An operation already present with the same operationId is kept as-is
+func mergePaths(merged, src *spec.OpenAPI) []string {
+	if src.Paths == nil {
+		return nil
+	}
+
+	var conflicts []string
+	for _, path := range slices.Sorted(maps.Keys(src.Paths.PathItems)) {
+		srcItem := src.Paths.PathItems[path]
+		if srcItem == nil {
+			continue
+		}
+
+		item := merged.Paths.PathItems[path]
+		if item == nil {
+			item = &spec.PathItem{
+				Summary:     srcItem.Summary,
+				Description: srcItem.Description,
+			}
+			merged.Paths.PathItems[path] = item
+		}
+
+		// Union path-level parameters by name and location
+		for _, param := range srcItem.Parameters {
+			if !slices.ContainsFunc(item.Parameters, func(p *spec.Parameter) bool {
+				return p.Name == param.Name && p.In == param.In
+			}) {
+				item.Parameters = append(item.Parameters, param)
+			}
+		}
+
+		for _, method := range httpMethods {
+			op := pathOperation(srcItem, method)
+			if op == nil {
+				continue
+			}
+
+			existing := pathOperation(item, method)
+			if existing == nil {
+				item.SetOperation(method, op)
+				continue
+			}
+			if existing.OperationID != op.OperationID {
+				conflicts = append(conflicts, fmt.Sprintf("%s %s has conflicting operationIds %q and %q",
+					method, path, existing.OperationID, op.OperationID))
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// mergeComponents adds the schemas and security schemes of src to merged
+func mergeComponents(merged, src *spec.OpenAPI) []string {
+	if src.Components == nil {
+		return nil
+	}
+	if merged.Components == nil {
+		merged.Components = &spec.Components{}
+	}
+
+	var conflicts []string
+	for _, name := range slices.Sorted(maps.Keys(src.Components.Schemas)) {
+		if merged.Components.Schemas == nil {
+			merged.Components.Schemas = make(map[string]*spec.Schema)
+		}
+
+		schema := src.Components.Schemas[name]
+		if existing, ok := merged.Components.Schemas[name]; ok {
+			if !reflect.DeepEqual(existing, schema) {
+				conflicts = append(conflicts, fmt.Sprintf("schema %q has conflicting definitions", name))
+			}
+			continue
+		}
+		merged.Components.Schemas[name] = schema
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(src.Components.SecuritySchemes)) {
+		if merged.Components.SecuritySchemes == nil {
+			merged.Components.SecuritySchemes = make(map[string]*spec.SecurityScheme)
+		}
+
+		scheme := src.Components.SecuritySchemes[name]
+		if existing, ok := merged.Components.SecuritySchemes[name]; ok {
+			if !reflect.DeepEqual(existing, scheme) {
+				conflicts = append(conflicts, fmt.Sprintf("security scheme %q has conflicting definitions", name))
+			}
+			continue
+		}
+		merged.Components.SecuritySchemes[name] = scheme
+	}
+
+	return conflicts
+}
+
+// mergeServers adds the servers of src that aren't present yet, matched by URL
+func mergeServers(merged, src *spec.OpenAPI) {
+	for _, server := range src.Servers {
+		if !slices.ContainsFunc(merged.Servers, func(s *spec.Server) bool {
+			return s.URL == server.URL
+		}) {
+			merged.Servers = append(merged.Servers, server)
+		}
+	}
+}
+
+// mergeTags adds the tags of src that aren't present yet, matched by name
+func mergeTags(merged, src *spec.OpenAPI) {
+	for _, tag := range src.Tags {
+		if !slices.ContainsFunc(merged.Tags, func(t *spec.Tag) bool {
+			return t.Name == tag.Name
+		}) {
+			merged.Tags = append(merged.Tags, tag)
+		}
+	}
+}
+
+// mergeSecurity adds the global security requirements of src that aren't present yet
+func mergeSecurity(merged, src *spec.OpenAPI) {
+	for _, requirement := range src.Security {
+		if !slices.ContainsFunc(merged.Security, func(r spec.SecurityRequirement) bool {
+			return reflect.DeepEqual(r, requirement)
+		}) {
+			merged.Security = append(merged.Security, requirement)
+		}
+	}
+}
+
+// pathOperation returns the operation for the given HTTP method on a path item
+func pathOperation(pathItem *spec.PathItem, method string) *spec.Operation {
+	switch method {
+	case "GET":
+		return pathItem.Get
+	case "PUT":
+		return pathItem.Put
+	case "POST":
+		return pathItem.Post
+	case "DELETE":
+		return pathItem.Delete
+	case "OPTIONS":
+		return pathItem.Options
+	case "HEAD":
+		return pathItem.Head
+	case "PATCH":
+		return pathItem.Patch
+	case "TRACE":
+		return pathItem.Trace
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// newMergeSpec creates a spec with a single GET operation and schema
+func newMergeSpec(path, operationID, schemaName string) *spec.OpenAPI {
+	return &spec.OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    &spec.Info{Title: operationID, Version: "1.0.0"},
+		Paths: &spec.Paths{
+			PathItems: map[string]*spec.PathItem{
+				path: {
+					Get: &spec.Operation{
+						OperationID: operationID,
+						Responses: &spec.Responses{
+							StatusCodeResponses: map[string]*spec.Response{
+								"200": {Description: "OK"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Components: &spec.Components{
+			Schemas: map[string]*spec.Schema{
+				schemaName: {Type: "object"},
+			},
+		},
+	}
+}
+
+func TestMerge(t *testing.T) {
+	users := newMergeSpec("/users", "listUsers", "User")
+	users.Servers = []*spec.Server{{URL: "https://api.example.com"}}
+	users.Tags = []*spec.Tag{{Name: "users"}}
+	users.Components.Schemas["Error"] = &spec.Schema{Type: "object"}
+	users.Components.SecuritySchemes = map[string]*spec.SecurityScheme{
+		"bearer": {Type: "http", Scheme: "bearer"},
+	}
+
+	pets := newMergeSpec("/pets", "listPets", "Pet")
+	pets.Servers = []*spec.Server{{URL: "https://api.example.com"}, {URL: "https://staging.example.com"}}
+	pets.Tags = []*spec.Tag{{Name: "pets"}, {Name: "users"}}
+	pets.Components.Schemas["Error"] = &spec.Schema{Type: "object"}
+	pets.Paths.PathItems["/users"] = &spec.PathItem{
+		Post: &spec.Operation{OperationID: "createUser"},
+	}
+
+	merged, err := Merge(users, pets)
+	if err != nil {
+		t.Fatalf("Merge() failed: %v", err)
+	}
+
+	if merged.Info.Title != "listUsers" {
+		t.Errorf("expected info from first spec, got %q", merged.Info.Title)
+	}
+
+	if len(merged.Paths.PathItems) != 2 {
+		t.Errorf("expected 2 paths, got %d", len(merged.Paths.PathItems))
+	}
+	usersItem := merged.Paths.PathItems["/users"]
+	if usersItem.Get == nil || usersItem.Post == nil {
+		t.Error("expected GET and POST operations on /users")
+	}
+
+	for _, name := range []string{"User", "Pet", "Error"} {
+		if _, ok := merged.Components.Schemas[name]; !ok {
+			t.Errorf("expected schema %q in merged spec", name)
+		}
+	}
+	if _, ok := merged.Components.SecuritySchemes["bearer"]; !ok {
+		t.Error("expected bearer security scheme in merged spec")
+	}
+
+	if len(merged.Servers) != 2 {
+		t.Errorf("expected 2 unique servers, got %d", len(merged.Servers))
+	}
+	if len(merged.Tags) != 2 {
+		t.Errorf("expected 2 unique tags, got %d", len(merged.Tags))
+	}
+}
+
+func TestMerge_ConflictingOperation(t *testing.T) {
+	first := newMergeSpec("/users", "listUsers", "User")
+	second := newMergeSpec("/users", "getUsers", "Account")
+
+	merged, err := Merge(first, second)
+	if err == nil {
+		t.Fatal("expected conflict error")
+	}
+	if merged != nil {
+		t.Error("expected no spec on conflict")
+	}
+	if !strings.Contains(err.Error(), `GET /users has conflicting operationIds "listUsers" and "getUsers"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMerge_ConflictingSchema(t *testing.T) {
+	first := newMergeSpec("/users", "listUsers", "User")
+	second := newMergeSpec("/accounts", "listAccounts", "User")
+	second.Components.Schemas["User"] = &spec.Schema{Type: "string"}
+
+	_, err := Merge(first, second)
+	if err == nil {
+		t.Fatal("expected conflict error")
+	}
+	if !strings.Contains(err.Error(), `schema "User" has conflicting definitions`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
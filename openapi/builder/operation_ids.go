@@ -0,0 +1,26 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// operationLocation describes the route declaring an operationId
+// Example: "pets.go:3:1 (GET /pets)"
+func operationLocation(pos token.Position, method, path string) string {
+	return fmt.Sprintf("%s (%s %s)", pos, strings.ToUpper(method), path)
+}
+
+// duplicateOperationIDs returns an error listing every operationId declared by more than one route, or nil
+// Example: duplicate operationId "listPets": pets.go:3:1 (GET /pets), animals.go:8:1 (GET /animals)
+func duplicateOperationIDs(ids *spec.OperationIDs) error {
+	duplicates := ids.Duplicates()
+	if len(duplicates) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(duplicates, "; "))
+}
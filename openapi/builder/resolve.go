@@ -0,0 +1,181 @@
+package builder
+
+import (
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	coreast "github.com/reation-io/apikit/core/ast"
+	"golang.org/x/tools/go/packages"
+)
+
+// typeResolver maps named types to the JSON type of their underlying primitive
+// Types are keyed by package name and type name, so equally named types of
+// different packages don't collide
+// Example: "type UserID int64" in package models -> "models.UserID": "integer"
+// Named types backed by structs are not recorded, so they keep their $ref
+type typeResolver struct {
+	jsonTypes map[string]string
+
+	// structs indexes the parsed structs by package and name, for promoting embedded fields
+	structs map[string]*coreast.Struct
+
+	// packages records the package each parsed struct belongs to
+	packages map[*coreast.Struct]string
+
+	// pkg is the package unqualified type names resolve against
+	pkg string
+}
+
+// newTypeResolver loads the packages containing the given files and records
+// every named type whose underlying type is a primitive, including those of the
+// packages they import from the same module
+// Packages that fail to load are skipped, so their named types fall back to $ref
+func newTypeResolver(filenames []string) *typeResolver {
+	r := &typeResolver{jsonTypes: make(map[string]string)}
+
+	dirs := make(map[string]bool)
+	for _, filename := range filenames {
+		dirs[filepath.Dir(filename)] = true
+	}
+
+	for dir := range dirs {
+		// NeedSyntax makes go/packages type-check the package from source, so it
+		// doesn't have to compile, and type-checking from source needs NeedDeps
+		cfg := &packages.Config{
+			Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax |
+				packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+			Dir: dir,
+		}
+		pkgs, err := packages.Load(cfg, ".")
+		if err != nil {
+			continue
+		}
+
+		// Imported packages of the module, such as its models package, are recorded
+		// too; the standard library and other modules are not
+		modules := make(map[string]bool)
+		for _, pkg := range pkgs {
+			if pkg.Module != nil {
+				modules[pkg.Module.Path] = true
+			}
+		}
+		packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+			if pkg.Module == nil || !modules[pkg.Module.Path] {
+				return
+			}
+			r.addPackage(pkg)
+		})
+	}
+
+	return r
+}
+
+// addPackage records the named types of a loaded package that resolve to a primitive
+func (r *typeResolver) addPackage(pkg *packages.Package) {
+	if pkg.Types == nil {
+		return
+	}
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		if jsonType := basicJSONType(typeName.Type().Underlying()); jsonType != "" {
+			r.jsonTypes[pkg.Name+"."+name] = jsonType
+		}
+	}
+}
+
+// addStructs indexes the structs of all parse results by package and name
+func (r *typeResolver) addStructs(results []*coreast.ParseResult) {
+	r.structs = make(map[string]*coreast.Struct)
+	r.packages = make(map[*coreast.Struct]string)
+	for _, result := range results {
+		for name, s := range result.Structs {
+			r.structs[result.Package+"."+name] = s
+			r.packages[s] = result.Package
+		}
+	}
+}
+
+// in returns a resolver that resolves unqualified type names against pkg
+func (r *typeResolver) in(pkg string) *typeResolver {
+	if r == nil {
+		return nil
+	}
+	scoped := *r
+	scoped.pkg = pkg
+	return &scoped
+}
+
+// forStruct returns a resolver that resolves the field types of a parsed struct
+// against the package declaring it
+func (r *typeResolver) forStruct(s *coreast.Struct) *typeResolver {
+	if r == nil {
+		return nil
+	}
+	return r.in(r.packages[s])
+}
+
+// structKey returns the package-qualified name of a parsed struct
+// Example: struct Base of package models -> "models.Base"
+func (r *typeResolver) structKey(s *coreast.Struct) string {
+	if r == nil {
+		return s.Name
+	}
+	return r.packages[s] + "." + s.Name
+}
+
+// key qualifies a type name with the resolver's package unless it is qualified already
+// Example: "UserID" in package api -> "api.UserID", "models.UserID" -> "models.UserID"
+func (r *typeResolver) key(name string) string {
+	if r == nil || strings.Contains(name, ".") {
+		return name
+	}
+	return r.pkg + "." + name
+}
+
+// jsonType returns the JSON type a named type resolves to
+// Returns false for unknown names and for types that don't resolve to a primitive
+func (r *typeResolver) jsonType(name string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	jsonType, ok := r.jsonTypes[r.key(name)]
+	return jsonType, ok
+}
+
+// structType returns the parsed struct with the given name
+// Example: "models.Base" resolves to the struct Base of package models
+func (r *typeResolver) structType(name string) (*coreast.Struct, bool) {
+	if r == nil {
+		return nil, false
+	}
+	s, ok := r.structs[r.key(name)]
+	return s, ok
+}
+
+// basicJSONType converts a basic Go type to its JSON Schema type
+// Returns an empty string for non-basic types
+func basicJSONType(t types.Type) string {
+	basic, ok := t.(*types.Basic)
+	if !ok {
+		return ""
+	}
+
+	info := basic.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return "boolean"
+	case info&types.IsInteger != 0:
+		return "integer"
+	case info&types.IsFloat != 0:
+		return "number"
+	case info&types.IsString != 0:
+		return "string"
+	default:
+		return ""
+	}
+}
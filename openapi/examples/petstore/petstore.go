@@ -3,6 +3,21 @@ package petstore
 // swagger:meta
 // title: Swagger Petstore - OpenAPI 3.0
 // version: 1.0.12
+// SecuritySchemes:
+//
+//	petstore_auth:
+//	  type: oauth2
+//	  flows:
+//	    implicit:
+//	      authorizationUrl: https://petstore3.swagger.io/oauth/authorize
+//	      scopes:
+//	        write:pets: modify pets in your account
+//	        read:pets: read your pets
+//	api_key:
+//	  type: apiKey
+//	  name: api_key
+//	  in: header
+//
 // description:
 //
 //	This is a sample Pet Store Server based on the OpenAPI 3.0 specification.  You can find out more about
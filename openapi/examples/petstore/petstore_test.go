@@ -126,6 +126,35 @@ func TestPetstoreGeneration(t *testing.T) {
 		t.Logf("✓ PUT /pet has %d security schemes and %d responses",
 			len(petRoute.Put.Security), responseCount)
 	}
+
+	// Verify the security schemes referenced by operations are defined
+	if spec.Components == nil || spec.Components.SecuritySchemes == nil {
+		t.Error("Components.SecuritySchemes is nil")
+	} else {
+		auth := spec.Components.SecuritySchemes["petstore_auth"]
+		if auth == nil || auth.Type != "oauth2" || auth.Flows == nil || auth.Flows.Implicit == nil {
+			t.Errorf("petstore_auth should be an oauth2 implicit scheme, got %+v", auth)
+		} else if len(auth.Flows.Implicit.Scopes) != 2 {
+			t.Errorf("petstore_auth should have 2 scopes, got %d", len(auth.Flows.Implicit.Scopes))
+		}
+
+		apiKey := spec.Components.SecuritySchemes["api_key"]
+		if apiKey == nil || apiKey.Type != "apiKey" || apiKey.Name != "api_key" || apiKey.In != "header" {
+			t.Errorf("api_key should be a header apiKey scheme, got %+v", apiKey)
+		}
+	}
+
+	// Verify path parameters are emitted from the request struct
+	petByID := spec.Paths.PathItems["/pet/{petId}"]
+	if petByID != nil && petByID.Get != nil {
+		if len(petByID.Get.Parameters) != 1 {
+			t.Fatalf("GET /pet/{petId} should have 1 parameter, got %d", len(petByID.Get.Parameters))
+		}
+		param := petByID.Get.Parameters[0]
+		if param.Name != "petId" || param.In != "path" || !param.Required {
+			t.Errorf("GET /pet/{petId} should have required path parameter petId, got %+v", param)
+		}
+	}
 }
 
 func TestPetstoreJSONOutput(t *testing.T) {
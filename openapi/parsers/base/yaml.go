@@ -19,7 +19,8 @@ import (
 //	  scheme: bearer
 type YAMLParser struct {
 	parsers.BaseParser
-	pattern *regexp.Regexp
+	pattern   *regexp.Regexp
+	normalize func(string) string // Optional rewrite of the block before unmarshaling
 }
 
 // NewYAMLParser creates a new YAML parser
@@ -35,6 +36,13 @@ func NewYAMLParser(
 	}
 }
 
+// WithNormalizer sets a function that rewrites the extracted block into YAML
+// Used to accept shorthand forms that aren't valid YAML on their own
+func (p *YAMLParser) WithNormalizer(normalize func(string) string) *YAMLParser {
+	p.normalize = normalize
+	return p
+}
+
 // Matches checks if the comment matches the pattern
 func (p *YAMLParser) Matches(comment string, ctx parsers.ParseContext) bool {
 	// Check if the context is supported
@@ -54,28 +62,11 @@ func (p *YAMLParser) Parse(comments *ast.CommentGroup, ctx parsers.ParseContext)
 		return nil, nil
 	}
 
-	// Extract YAML content
-	yamlContent := matches[1]
-
-	// Clean comment indentation
-	lines := strings.Split(yamlContent, "\n")
-	cleaned := make([]string, 0, len(lines))
-
-	for _, line := range lines {
-		// Remove comment prefixes (//,  /*, etc.)
-		trimmed := strings.TrimSpace(line)
-		trimmed = strings.TrimPrefix(trimmed, "//")
-		trimmed = strings.TrimPrefix(trimmed, "/*")
-		trimmed = strings.TrimPrefix(trimmed, "*")
-		trimmed = strings.TrimSpace(trimmed)
-
-		if trimmed != "" {
-			cleaned = append(cleaned, trimmed)
-		}
+	yamlText := yamlBlock(matches[1])
+	if p.normalize != nil {
+		yamlText = p.normalize(yamlText)
 	}
 
-	yamlText := strings.Join(cleaned, "\n")
-
 	// Parse YAML
 	var yamlValue any
 	if err := yaml.Unmarshal([]byte(yamlText), &yamlValue); err != nil {
@@ -99,6 +90,43 @@ func (p *YAMLParser) Parse(comments *ast.CommentGroup, ctx parsers.ParseContext)
 	return json.RawMessage(jsonBytes), nil
 }
 
+// yamlBlock extracts the indented YAML block that follows a directive
+// The block ends at the first non-indented line that isn't a list item,
+// which is the next directive
+// Leading tabs (as written by gofmt) are expanded and the common indentation
+// is removed so nesting is preserved
+func yamlBlock(content string) string {
+	var lines []string
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, " \t\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Expand leading tabs so they mix with space indentation
+		trimmed := strings.TrimLeft(line, "\t")
+		line = strings.Repeat("    ", len(line)-len(trimmed)) + trimmed
+
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "- ") {
+			break
+		}
+		lines = append(lines, line)
+	}
+
+	indent := -1
+	for _, line := range lines {
+		n := len(line) - len(strings.TrimLeft(line, " "))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, line := range lines {
+		lines[i] = line[indent:]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 // Apply applies the value to the target using the context's setter
 func (p *YAMLParser) Apply(target any, value any, ctx parsers.ParseContext) error {
 	return p.ApplyWithSetter(target, value, ctx)
@@ -35,18 +35,29 @@ var (
 	RxParameters  = regexp.MustCompile(`(?is)Parameters\s*:\s*\n((?:.*\n?)*)`)
 
 	// Field patterns - all single line
-	RxExample   = regexp.MustCompile(`(?i)Example\s*:\s*([^\n]+)`)
-	RxDefault   = regexp.MustCompile(`(?i)Default\s*:\s*([^\n]+)`)
-	RxEnum      = regexp.MustCompile(`(?i)Enum\s*:\s*([^\n]+)`)
-	RxFormat    = regexp.MustCompile(`(?i)Format\s*:\s*([^\n]+)`)
-	RxMinimum   = regexp.MustCompile(`(?i)Minimum\s*:\s*([^\n]+)`)
-	RxMaximum   = regexp.MustCompile(`(?i)Maximum\s*:\s*([^\n]+)`)
-	RxMinLength = regexp.MustCompile(`(?i)MinLength\s*:\s*([^\n]+)`)
-	RxMaxLength = regexp.MustCompile(`(?i)MaxLength\s*:\s*([^\n]+)`)
-	RxPattern   = regexp.MustCompile(`(?i)Pattern\s*:\s*([^\n]+)`)
-	RxRequired  = regexp.MustCompile(`(?i)Required\s*:\s*(true|false|yes|no)`)
-	RxReadOnly  = regexp.MustCompile(`(?i)ReadOnly\s*:\s*(true|false|yes|no)`)
-	RxWriteOnly = regexp.MustCompile(`(?i)WriteOnly\s*:\s*(true|false|yes|no)`)
+	RxExample          = regexp.MustCompile(`(?i)Example\s*:\s*([^\n]+)`)
+	RxDefault          = regexp.MustCompile(`(?i)Default\s*:\s*([^\n]+)`)
+	RxEnum             = regexp.MustCompile(`(?i)Enum\s*:\s*([^\n]+)`)
+	RxFormat           = regexp.MustCompile(`(?i)Format\s*:\s*([^\n]+)`)
+	RxMinimum          = regexp.MustCompile(`(?i)\bMinimum\s*:\s*([^\n]+)`)
+	RxMaximum          = regexp.MustCompile(`(?i)\bMaximum\s*:\s*([^\n]+)`)
+	RxExclusiveMinimum = regexp.MustCompile(`(?i)ExclusiveMinimum\s*:\s*(true|false|yes|no)`)
+	RxExclusiveMaximum = regexp.MustCompile(`(?i)ExclusiveMaximum\s*:\s*(true|false|yes|no)`)
+	RxMultipleOf       = regexp.MustCompile(`(?i)MultipleOf\s*:\s*([^\n]+)`)
+	RxMinLength        = regexp.MustCompile(`(?i)MinLength\s*:\s*([^\n]+)`)
+	RxMaxLength        = regexp.MustCompile(`(?i)MaxLength\s*:\s*([^\n]+)`)
+	RxMinItems         = regexp.MustCompile(`(?i)MinItems\s*:\s*([^\n]+)`)
+	RxMaxItems         = regexp.MustCompile(`(?i)MaxItems\s*:\s*([^\n]+)`)
+	RxUniqueItems      = regexp.MustCompile(`(?i)UniqueItems\s*:\s*(true|false|yes|no)`)
+	RxPattern          = regexp.MustCompile(`(?i)Pattern\s*:\s*([^\n]+)`)
+	RxRequired         = regexp.MustCompile(`(?i)Required\s*:\s*(true|false|yes|no)`)
+	RxReadOnly         = regexp.MustCompile(`(?i)ReadOnly\s*:\s*(true|false|yes|no)`)
+	RxWriteOnly        = regexp.MustCompile(`(?i)WriteOnly\s*:\s*(true|false|yes|no)`)
+
+	// Model patterns (swagger:model) - schema composition
+	RxOneOf = regexp.MustCompile(`(?i)OneOf\s*:\s*([^\n]+)`)
+	RxAnyOf = regexp.MustCompile(`(?i)AnyOf\s*:\s*([^\n]+)`)
+	RxAllOf = regexp.MustCompile(`(?i)AllOf\s*:\s*([^\n]+)`)
 
 	// Extension patterns
 	RxExtensions = regexp.MustCompile(`(?is)Extensions\s*:\s*\n((?:.*\n?)*)`)
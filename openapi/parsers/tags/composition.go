@@ -0,0 +1,91 @@
+package tags
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/parsers/base"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// NewOneOfParser creates a OneOf parser for model comments
+// Example: "oneOf: Dog,Cat"
+func NewOneOfParser() parsers.TagParser {
+	return newCompositionParser("OneOf", parsers.RxOneOf, func(schema *spec.Schema, refs []*spec.Schema) {
+		schema.OneOf = refs
+	})
+}
+
+// NewAnyOfParser creates an AnyOf parser for model comments
+// Example: "anyOf: Dog,Cat"
+func NewAnyOfParser() parsers.TagParser {
+	return newCompositionParser("AnyOf", parsers.RxAnyOf, func(schema *spec.Schema, refs []*spec.Schema) {
+		schema.AnyOf = refs
+	})
+}
+
+// NewAllOfParser creates an AllOf parser for model comments
+// Example: "allOf: Pet,Timestamps"
+func NewAllOfParser() parsers.TagParser {
+	return newCompositionParser("AllOf", parsers.RxAllOf, func(schema *spec.Schema, refs []*spec.Schema) {
+		schema.AllOf = refs
+	})
+}
+
+// newCompositionParser creates a parser that turns a comma-separated list of
+// model names into component schema references
+func newCompositionParser(name string, pattern *regexp.Regexp, assign func(*spec.Schema, []*spec.Schema)) parsers.TagParser {
+	return base.NewSingleLineParser(
+		name,
+		pattern,
+		[]parsers.ParseContext{parsers.ContextModel},
+		parsers.SetterMap{
+			parsers.ContextModel: func(target any, value any) error {
+				schema, ok := target.(*spec.Schema)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   name,
+						Context:      parsers.ContextModel,
+						ExpectedType: "*spec.Schema",
+						ActualType:   getTypeName(target),
+					}
+				}
+				namesStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   name,
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
+				}
+
+				var refs []*spec.Schema
+				for _, modelName := range strings.Split(namesStr, ",") {
+					modelName = strings.TrimSpace(modelName)
+					if modelName == "" {
+						continue
+					}
+					refs = append(refs, &spec.Schema{Ref: schemaRef(modelName)})
+				}
+				assign(schema, refs)
+				return nil
+			},
+		},
+	)
+}
+
+// schemaRef returns a component schema reference for a model name
+// Names that are already references are returned unchanged
+func schemaRef(name string) string {
+	if strings.HasPrefix(name, "#/") {
+		return name
+	}
+	return "#/components/schemas/" + name
+}
+
+func init() {
+	parsers.Register("swagger:model", NewOneOfParser())
+	parsers.Register("swagger:model", NewAnyOfParser())
+	parsers.Register("swagger:model", NewAllOfParser())
+}
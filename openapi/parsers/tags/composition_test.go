@@ -0,0 +1,73 @@
+package tags
+
+import (
+	"encoding/json"
+	"go/ast"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestCompositionParsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    string
+	}{
+		{
+			name:    "oneOf",
+			comment: "oneOf: Dog,Cat",
+			want:    `{"oneOf":[{"$ref":"#/components/schemas/Dog"},{"$ref":"#/components/schemas/Cat"}]}`,
+		},
+		{
+			name:    "anyOf with spaces",
+			comment: "AnyOf: Dog, Cat",
+			want:    `{"anyOf":[{"$ref":"#/components/schemas/Dog"},{"$ref":"#/components/schemas/Cat"}]}`,
+		},
+		{
+			name:    "allOf with explicit ref",
+			comment: "allOf: Pet,#/components/schemas/Timestamps",
+			want:    `{"allOf":[{"$ref":"#/components/schemas/Pet"},{"$ref":"#/components/schemas/Timestamps"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := &ast.CommentGroup{
+				List: []*ast.Comment{
+					{Text: "// swagger:model"},
+					{Text: "// " + tt.comment},
+				},
+			}
+
+			schema := &spec.Schema{}
+			if err := parsers.GlobalRegistry().Parse("swagger:model", comments, schema, parsers.ContextModel); err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+
+			data, err := json.Marshal(schema)
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, data)
+			}
+		})
+	}
+}
+
+func TestCompositionParsers_FieldContext(t *testing.T) {
+	comments := &ast.CommentGroup{
+		List: []*ast.Comment{{Text: "// oneOf: Dog,Cat"}},
+	}
+
+	// Composition only applies to model doc comments, not to fields
+	schema := &spec.Schema{}
+	if err := parsers.GlobalRegistry().Parse("swagger:model", comments, schema, parsers.ContextField); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(schema.OneOf) != 0 {
+		t.Errorf("expected no oneOf in field context, got %v", schema.OneOf)
+	}
+}
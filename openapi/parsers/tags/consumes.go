@@ -1,6 +1,7 @@
 package tags
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/reation-io/apikit/openapi/parsers"
@@ -79,10 +80,20 @@ func NewConsumesParser() parsers.TagParser {
 					}
 				}
 
+				// The "in: body" field is described as application/json by default;
+				// the declared content types replace it and share its schema
+				var schema *spec.Schema
+				if media := operation.RequestBody.Content["application/json"]; media != nil {
+					schema = media.Schema
+					if !slices.Contains(mimeTypes, "application/json") {
+						delete(operation.RequestBody.Content, "application/json")
+					}
+				}
+
 				// Add content types to RequestBody
 				for _, mimeType := range mimeTypes {
 					if operation.RequestBody.Content[mimeType] == nil {
-						operation.RequestBody.Content[mimeType] = &spec.MediaType{}
+						operation.RequestBody.Content[mimeType] = &spec.MediaType{Schema: schema}
 					}
 				}
 
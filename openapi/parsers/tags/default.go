@@ -0,0 +1,98 @@
+package tags
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/parsers/base"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// NewDefaultParser creates a Default parser for field comments
+// The literal is converted according to the schema type
+// Example: "default: 20" on an integer field -> 20, "default: available" on a string field -> "available"
+func NewDefaultParser() parsers.TagParser {
+	return base.NewSingleLineParser(
+		"Default",
+		parsers.RxDefault,
+		[]parsers.ParseContext{parsers.ContextField},
+		parsers.SetterMap{
+			parsers.ContextField: func(target any, value any) error {
+				schema, ok := target.(*spec.Schema)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   "Default",
+						Context:      parsers.ContextField,
+						ExpectedType: "*spec.Schema",
+						ActualType:   getTypeName(target),
+					}
+				}
+				defaultStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   "Default",
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
+				}
+				defaultValue, err := parseDefaultValue(defaultStr, schema)
+				if err != nil {
+					return &parsers.ErrParseFailure{
+						ParserName: "Default",
+						Context:    parsers.ContextField,
+						Cause:      err,
+					}
+				}
+				schema.Default = defaultValue
+				return nil
+			},
+		},
+	)
+}
+
+// parseDefaultValue converts a default literal to the type of the schema
+// Array defaults are a JSON array or a comma-separated list of item literals
+func parseDefaultValue(literal string, schema *spec.Schema) (any, error) {
+	switch schema.Type {
+	case "string":
+		// A quoted literal allows leading and trailing spaces
+		var s string
+		if err := json.Unmarshal([]byte(literal), &s); err == nil {
+			return s, nil
+		}
+		return literal, nil
+	case "integer":
+		return strconv.ParseInt(literal, 10, 64)
+	case "number":
+		return strconv.ParseFloat(literal, 64)
+	case "boolean":
+		return strconv.ParseBool(literal)
+	case "array":
+		var values []any
+		if err := json.Unmarshal([]byte(literal), &values); err == nil {
+			return values, nil
+		}
+		items := schema.Items
+		if items == nil {
+			items = &spec.Schema{}
+		}
+		for part := range strings.SplitSeq(literal, ",") {
+			item, err := parseDefaultValue(strings.TrimSpace(part), items)
+			if err != nil {
+				return nil, fmt.Errorf("array item: %w", err)
+			}
+			values = append(values, item)
+		}
+		return values, nil
+	}
+
+	// Objects and untyped schemas take any JSON value
+	return parseExampleValue(literal), nil
+}
+
+func init() {
+	parsers.Register("swagger:model", NewDefaultParser())
+}
@@ -0,0 +1,80 @@
+package tags
+
+import (
+	"go/ast"
+	"reflect"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestDefaultParser_Field(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		schema  *spec.Schema
+		want    any
+	}{
+		{
+			name:    "string",
+			comment: "// default: available",
+			schema:  &spec.Schema{Type: "string"},
+			want:    "available",
+		},
+		{
+			name:    "numeric string stays a string",
+			comment: "// default: 42",
+			schema:  &spec.Schema{Type: "string"},
+			want:    "42",
+		},
+		{
+			name:    "integer",
+			comment: "// default: 20",
+			schema:  &spec.Schema{Type: "integer"},
+			want:    int64(20),
+		},
+		{
+			name:    "number",
+			comment: "// Default: 0.5",
+			schema:  &spec.Schema{Type: "number"},
+			want:    0.5,
+		},
+		{
+			name:    "boolean",
+			comment: "// default: true",
+			schema:  &spec.Schema{Type: "boolean"},
+			want:    true,
+		},
+		{
+			name:    "array of integers",
+			comment: "// default: 1,2",
+			schema:  &spec.Schema{Type: "array", Items: &spec.Schema{Type: "integer"}},
+			want:    []any{int64(1), int64(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := &ast.CommentGroup{List: []*ast.Comment{{Text: tt.comment}}}
+			if err := parsers.GlobalRegistry().Parse("swagger:model", comments, tt.schema, parsers.ContextField); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(tt.schema.Default, tt.want) {
+				t.Errorf("expected default %#v, got %#v", tt.want, tt.schema.Default)
+			}
+		})
+	}
+}
+
+func TestDefaultParser_InvalidInteger(t *testing.T) {
+	comments := &ast.CommentGroup{List: []*ast.Comment{{Text: "// default: many"}}}
+
+	schema := &spec.Schema{Type: "integer"}
+	if err := parsers.GlobalRegistry().Parse("swagger:model", comments, schema, parsers.ContextField); err == nil {
+		t.Error("expected an error for a non-numeric integer default")
+	}
+	if schema.Default != nil {
+		t.Errorf("expected default to be unset, got %v", schema.Default)
+	}
+}
@@ -0,0 +1,66 @@
+package tags
+
+import (
+	"encoding/json"
+	"go/ast"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestDeprecatedParser_Field(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    bool
+	}{
+		{
+			name:    "deprecated true",
+			comment: "deprecated: true",
+			want:    true,
+		},
+		{
+			name:    "deprecated yes",
+			comment: "Deprecated: yes",
+			want:    true,
+		},
+		{
+			name:    "deprecated false",
+			comment: "deprecated: false",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := &ast.CommentGroup{
+				List: []*ast.Comment{
+					{Text: "// Legacy name of the pet"},
+					{Text: "// " + tt.comment},
+				},
+			}
+
+			schema := &spec.Schema{Type: "string"}
+			if err := parsers.GlobalRegistry().Parse("swagger:model", comments, schema, parsers.ContextField); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if schema.Deprecated != tt.want {
+				t.Errorf("expected deprecated %v, got %v", tt.want, schema.Deprecated)
+			}
+
+			data, err := json.Marshal(schema)
+			if err != nil {
+				t.Fatalf("failed to marshal schema: %v", err)
+			}
+			if got := strings.Contains(string(data), `"deprecated":true`); got != tt.want {
+				t.Errorf("expected deprecated in JSON to be %v, got %s", tt.want, data)
+			}
+			if !tt.want && strings.Contains(string(data), "deprecated") {
+				t.Errorf("expected deprecated to be omitted when false, got %s", data)
+			}
+		})
+	}
+}
@@ -9,9 +9,10 @@ import (
 )
 
 var (
-	// rxDescription matches "Description:" followed by content until next directive or end
+	// rxDescription matches a line starting with "Description:" followed by content until next directive or end
+	// Anchoring to the line start keeps "description:" keys inside Parameters: entries from matching
 	// Stops at lines starting with capital letter followed by colon (e.g., "Security:", "Responses:")
-	rxDescription = regexp.MustCompile(`(?ims)[Dd]escription\s*:\s*(.*?)(?:^[A-Z][a-zA-Z]*:\s*$|\z)`)
+	rxDescription = regexp.MustCompile(`(?ims)^[Dd]escription\s*:\s*(.*?)(?:^[A-Z][a-zA-Z]*:\s*$|\z)`)
 )
 
 // NewDescriptionParser creates a reusable Description parser
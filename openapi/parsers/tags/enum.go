@@ -0,0 +1,91 @@
+package tags
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/parsers/base"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// NewEnumParser creates an Enum parser for field comments
+// Example: "enum: available,pending,sold"
+func NewEnumParser() parsers.TagParser {
+	return base.NewSingleLineParser(
+		"Enum",
+		parsers.RxEnum,
+		[]parsers.ParseContext{parsers.ContextField},
+		parsers.SetterMap{
+			parsers.ContextField: func(target any, value any) error {
+				schema, ok := target.(*spec.Schema)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   "Enum",
+						Context:      parsers.ContextField,
+						ExpectedType: "*spec.Schema",
+						ActualType:   getTypeName(target),
+					}
+				}
+				enumStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   "Enum",
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
+				}
+
+				// For arrays the enumeration constrains the items
+				if schema.Type == "array" && schema.Items != nil {
+					schema = schema.Items
+				}
+
+				values, err := parseEnumValues(enumStr, schema.Type)
+				if err != nil {
+					return &parsers.ErrParseFailure{
+						ParserName: "Enum",
+						Context:    parsers.ContextField,
+						Cause:      err,
+					}
+				}
+				schema.Enum = values
+				return nil
+			},
+		},
+	)
+}
+
+// parseEnumValues splits a comma-separated enum list and converts each value
+// according to the schema type (integer and number values are parsed, anything else is kept as string)
+func parseEnumValues(enumStr, schemaType string) ([]any, error) {
+	var values []any
+	for _, part := range strings.Split(enumStr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		switch schemaType {
+		case "integer":
+			i, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, i)
+		case "number":
+			f, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, f)
+		default:
+			values = append(values, part)
+		}
+	}
+	return values, nil
+}
+
+func init() {
+	parsers.Register("swagger:model", NewEnumParser())
+}
@@ -0,0 +1,78 @@
+package tags
+
+import (
+	"go/ast"
+	"reflect"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestEnumParser(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		schema  *spec.Schema
+		want    []any
+		wantErr bool
+	}{
+		{
+			name:    "string values",
+			comment: "enum: available,pending,sold",
+			schema:  &spec.Schema{Type: "string"},
+			want:    []any{"available", "pending", "sold"},
+		},
+		{
+			name:    "no space after colon",
+			comment: "enum:available, pending , sold",
+			schema:  &spec.Schema{Type: "string"},
+			want:    []any{"available", "pending", "sold"},
+		},
+		{
+			name:    "integer values",
+			comment: "Enum: 1, 2, 3",
+			schema:  &spec.Schema{Type: "integer"},
+			want:    []any{int64(1), int64(2), int64(3)},
+		},
+		{
+			name:    "array items",
+			comment: "enum: red,green",
+			schema:  &spec.Schema{Type: "array", Items: &spec.Schema{Type: "string"}},
+			want:    []any{"red", "green"},
+		},
+		{
+			name:    "invalid integer",
+			comment: "enum: 1,two",
+			schema:  &spec.Schema{Type: "integer"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := &ast.CommentGroup{
+				List: []*ast.Comment{{Text: "// " + tt.comment}},
+			}
+
+			err := parsers.GlobalRegistry().Parse("swagger:model", comments, tt.schema, parsers.ContextField)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := tt.schema.Enum
+			if tt.schema.Type == "array" {
+				if len(got) != 0 {
+					t.Errorf("expected no enum on array schema, got %v", got)
+				}
+				got = tt.schema.Items.Enum
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected enum %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
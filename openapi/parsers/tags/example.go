@@ -3,6 +3,7 @@ package tags
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/reation-io/apikit/openapi/parsers"
 	"github.com/reation-io/apikit/openapi/parsers/base"
@@ -37,34 +38,106 @@ func NewExampleParser() parsers.TagParser {
 					}
 				}
 
-				// Try to parse as JSON first
-				var jsonValue any
-				if err := json.Unmarshal([]byte(exampleStr), &jsonValue); err == nil {
-					schema.Example = jsonValue
+				// Slice fields take a comma-separated list unless the example is already a JSON array
+				if schema.Type == "array" {
+					schema.Example = parseArrayExample(exampleStr)
 					return nil
 				}
 
-				// Try to parse as number
-				if num, err := strconv.ParseFloat(exampleStr, 64); err == nil {
-					schema.Example = num
-					return nil
+				schema.Example = parseExampleValue(exampleStr)
+				return nil
+			},
+		},
+	)
+}
+
+// NewRouteExampleParser creates an Example parser for swagger:route
+// The example is set on every media type of the operation request body,
+// so it must be parsed after the request body has been built
+// Example: "Example: {\"name\": \"Rex\"}"
+func NewRouteExampleParser() parsers.TagParser {
+	return base.NewSingleLineParser(
+		"Example",
+		parsers.RxExample,
+		[]parsers.ParseContext{
+			parsers.ContextRoute,
+		},
+		parsers.SetterMap{
+			parsers.ContextRoute: func(target any, value any) error {
+				operation, ok := target.(*spec.Operation)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   "Example",
+						Context:      parsers.ContextRoute,
+						ExpectedType: "*spec.Operation",
+						ActualType:   getTypeName(target),
+					}
+				}
+				exampleStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   "Example",
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
 				}
 
-				// Try to parse as boolean
-				if b, err := strconv.ParseBool(exampleStr); err == nil {
-					schema.Example = b
+				// Routes without a request body have nothing to attach the example to
+				if operation.RequestBody == nil {
 					return nil
 				}
 
-				// Use as string
-				schema.Example = exampleStr
+				example := parseExampleValue(exampleStr)
+				for _, mediaType := range operation.RequestBody.Content {
+					if mediaType != nil {
+						mediaType.Example = example
+					}
+				}
 				return nil
 			},
 		},
 	)
 }
 
+// parseArrayExample converts an example for a slice field into a JSON array
+// Example: "a.jpg,b.jpg" -> ["a.jpg", "b.jpg"], "[1, 2]" -> [1, 2]
+func parseArrayExample(exampleStr string) []any {
+	var values []any
+	if err := json.Unmarshal([]byte(exampleStr), &values); err == nil {
+		return values
+	}
+
+	for _, part := range strings.Split(exampleStr, ",") {
+		values = append(values, parseExampleValue(strings.TrimSpace(part)))
+	}
+	return values
+}
+
+// parseExampleValue converts a scalar example string into its JSON value
+// JSON is tried first, then numbers and booleans, falling back to the raw string
+func parseExampleValue(exampleStr string) any {
+	// Try to parse as JSON first
+	var jsonValue any
+	if err := json.Unmarshal([]byte(exampleStr), &jsonValue); err == nil {
+		return jsonValue
+	}
+
+	// Try to parse as number
+	if num, err := strconv.ParseFloat(exampleStr, 64); err == nil {
+		return num
+	}
+
+	// Try to parse as boolean
+	if b, err := strconv.ParseBool(exampleStr); err == nil {
+		return b
+	}
+
+	// Use as string
+	return exampleStr
+}
+
 func init() {
 	parsers.Register("swagger:model", NewExampleParser())
+	parsers.Register("swagger:route", NewRouteExampleParser())
 }
 
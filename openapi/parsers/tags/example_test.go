@@ -0,0 +1,72 @@
+package tags
+
+import (
+	"go/ast"
+	"reflect"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestExampleParser(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		schema  *spec.Schema
+		want    any
+	}{
+		{
+			name:    "string value",
+			comment: "example: doggie",
+			schema:  &spec.Schema{Type: "string"},
+			want:    "doggie",
+		},
+		{
+			name:    "number value",
+			comment: "example: 42",
+			schema:  &spec.Schema{Type: "integer"},
+			want:    float64(42),
+		},
+		{
+			name:    "scalar keeps commas",
+			comment: "example: a.jpg,b.jpg",
+			schema:  &spec.Schema{Type: "string"},
+			want:    "a.jpg,b.jpg",
+		},
+		{
+			name:    "slice from comma-separated list",
+			comment: "example: a.jpg,b.jpg",
+			schema:  &spec.Schema{Type: "array", Items: &spec.Schema{Type: "string"}},
+			want:    []any{"a.jpg", "b.jpg"},
+		},
+		{
+			name:    "slice of numbers",
+			comment: "example: 1, 2, 3",
+			schema:  &spec.Schema{Type: "array", Items: &spec.Schema{Type: "integer"}},
+			want:    []any{float64(1), float64(2), float64(3)},
+		},
+		{
+			name:    "slice from JSON array",
+			comment: `example: ["x", "y"]`,
+			schema:  &spec.Schema{Type: "array", Items: &spec.Schema{Type: "string"}},
+			want:    []any{"x", "y"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := &ast.CommentGroup{
+				List: []*ast.Comment{{Text: "// " + tt.comment}},
+			}
+
+			if err := parsers.GlobalRegistry().Parse("swagger:model", comments, tt.schema, parsers.ContextField); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(tt.schema.Example, tt.want) {
+				t.Errorf("expected example %#v, got %#v", tt.want, tt.schema.Example)
+			}
+		})
+	}
+}
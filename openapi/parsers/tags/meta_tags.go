@@ -0,0 +1,155 @@
+package tags
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"strings"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// MetaTagsParser parses the Tags directive for swagger:meta
+// Entries fill the top-level tags list so tools can show tag descriptions
+// Format:
+// Tags:
+// - name: users description: "User management"
+// - name: pets
+//   description: Everything about pets
+type MetaTagsParser struct {
+	parsers.BaseParser
+}
+
+func init() {
+	parsers.GlobalRegistry().Register("swagger:meta", &MetaTagsParser{
+		BaseParser: parsers.NewBaseParser(
+			"MetaTags",
+			parsers.ParserTypeMultiLine,
+			[]parsers.ParseContext{parsers.ContextMeta},
+			nil,
+		),
+	})
+}
+
+// Pattern matches a tag key at the current position (e.g., "name: ")
+var metaTagKeyPattern = regexp.MustCompile(`^\s*(name|description)\s*:\s*`)
+
+// Pattern matches the start of the next tag key inside an unquoted value
+var metaTagNextKeyPattern = regexp.MustCompile(`\s(name|description)\s*:`)
+
+// Matches checks if the comment contains a Tags: section
+func (p *MetaTagsParser) Matches(comment string, ctx parsers.ParseContext) bool {
+	return ctx == parsers.ContextMeta && extractSection(comment, "Tags:") != ""
+}
+
+// Parse extracts the tags from the multi-line Tags: section
+func (p *MetaTagsParser) Parse(comments *ast.CommentGroup, ctx parsers.ParseContext) (any, error) {
+	if ctx != parsers.ContextMeta {
+		return nil, nil
+	}
+
+	section := extractSection(comments.Text(), "Tags:")
+	if section == "" {
+		return nil, nil
+	}
+
+	var tags []*spec.Tag
+	for _, item := range splitSectionItems(section) {
+		tag, err := parseMetaTagItem(item)
+		if err != nil {
+			return nil, &parsers.ErrParseFailure{
+				ParserName: "MetaTags",
+				Context:    ctx,
+				Cause:      err,
+			}
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// Apply adds the parsed tags to the spec, updating tags that already exist
+func (p *MetaTagsParser) Apply(target any, value any, ctx parsers.ParseContext) error {
+	if ctx != parsers.ContextMeta {
+		return nil
+	}
+
+	openapi, ok := target.(*spec.OpenAPI)
+	if !ok {
+		return &parsers.ErrInvalidTarget{
+			ParserName:   "MetaTags",
+			Context:      ctx,
+			ExpectedType: "*spec.OpenAPI",
+			ActualType:   getTypeName(target),
+		}
+	}
+
+	tags, ok := value.([]*spec.Tag)
+	if !ok {
+		// If value is nil, nothing to apply
+		if value == nil {
+			return nil
+		}
+		return &parsers.ErrInvalidValue{
+			ParserName:   "MetaTags",
+			ExpectedType: "[]*spec.Tag",
+			ActualType:   getTypeName(value),
+		}
+	}
+
+	for _, tag := range tags {
+		if existing := findTag(openapi.Tags, tag.Name); existing != nil {
+			if tag.Description != "" {
+				existing.Description = tag.Description
+			}
+			continue
+		}
+		openapi.Tags = append(openapi.Tags, tag)
+	}
+
+	return nil
+}
+
+// findTag looks up a top-level tag by name
+func findTag(tags []*spec.Tag, name string) *spec.Tag {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
+
+// parseMetaTagItem parses the "key: value" pairs of a single tag entry
+// Example: `name: users description: "User management"`
+func parseMetaTagItem(item string) (*spec.Tag, error) {
+	tag := &spec.Tag{}
+
+	rest := item
+	for strings.TrimSpace(rest) != "" {
+		loc := metaTagKeyPattern.FindStringSubmatchIndex(rest)
+		if loc == nil {
+			return nil, fmt.Errorf("unexpected text %q in tag entry", strings.TrimSpace(rest))
+		}
+		key := rest[loc[2]:loc[3]]
+		rest = rest[loc[1]:]
+
+		var value string
+		value, rest = nextItemValue(rest, metaTagNextKeyPattern)
+
+		switch key {
+		case "name":
+			tag.Name = value
+		case "description":
+			tag.Description = value
+		}
+	}
+
+	if tag.Name == "" {
+		return nil, fmt.Errorf("tag entry %q has no name", item)
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,71 @@
+package tags
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestMetaTagsParser(t *testing.T) {
+	comments := `swagger:meta
+
+Title: Pet Store
+Version: 1.0.0
+
+Tags:
+- name: users description: "User management"
+- name: pets
+  description: Everything about pets`
+
+	commentGroup := &ast.CommentGroup{}
+	for _, line := range splitLines(comments) {
+		commentGroup.List = append(commentGroup.List, &ast.Comment{Text: "// " + line})
+	}
+
+	openapi := &spec.OpenAPI{Info: &spec.Info{}}
+
+	if err := parsers.GlobalRegistry().Parse("swagger:meta", commentGroup, openapi, parsers.ContextMeta); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(openapi.Tags) != 2 {
+		t.Fatalf("expected 2 tags, got %d", len(openapi.Tags))
+	}
+
+	want := []spec.Tag{
+		{Name: "users", Description: "User management"},
+		{Name: "pets", Description: "Everything about pets"},
+	}
+	for i, tag := range openapi.Tags {
+		if *tag != want[i] {
+			t.Errorf("tag %d: expected %+v, got %+v", i, want[i], *tag)
+		}
+	}
+
+	// Tag descriptions must not leak into the API description
+	if err := parsers.GlobalRegistry().Parse("swagger:meta", commentGroup, openapi.Info, parsers.ContextMeta); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if openapi.Info.Description != "" {
+		t.Errorf("expected no info description, got %q", openapi.Info.Description)
+	}
+}
+
+func TestMetaTagsParser_MissingName(t *testing.T) {
+	comments := `swagger:meta
+
+Tags:
+- description: "No name"`
+
+	commentGroup := &ast.CommentGroup{}
+	for _, line := range splitLines(comments) {
+		commentGroup.List = append(commentGroup.List, &ast.Comment{Text: "// " + line})
+	}
+
+	openapi := &spec.OpenAPI{}
+	if err := parsers.GlobalRegistry().Parse("swagger:meta", commentGroup, openapi, parsers.ContextMeta); err == nil {
+		t.Error("expected error for tag without name")
+	}
+}
@@ -0,0 +1,244 @@
+package tags
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// ParametersParser parses the Parameters directive for routes
+// Entries override the description/example of parameters derived from the
+// route struct, or add parameters that don't exist yet
+// Format:
+// Parameters:
+// - name: status in: query description: "Status to filter by" example: available
+// - name: X-Request-ID
+//   in: header
+//   description: Correlation ID
+type ParametersParser struct {
+	parsers.BaseParser
+}
+
+func init() {
+	parsers.GlobalRegistry().Register("swagger:route", &ParametersParser{
+		BaseParser: parsers.NewBaseParser(
+			"parameters",
+			parsers.ParserTypeMultiLine,
+			[]parsers.ParseContext{parsers.ContextRoute},
+			nil,
+		),
+	})
+}
+
+// Pattern matches a parameter key at the current position (e.g., "name: ", "in:")
+var parameterKeyPattern = regexp.MustCompile(`^\s*(name|in|description|example|required)\s*:\s*`)
+
+// Pattern matches the start of the next parameter key inside an unquoted value
+var parameterNextKeyPattern = regexp.MustCompile(`\s(name|in|description|example|required)\s*:`)
+
+// validParameterLocations are the supported "in" values
+var validParameterLocations = map[string]bool{
+	"query":  true,
+	"path":   true,
+	"header": true,
+	"cookie": true,
+}
+
+// Matches checks if the comment contains Parameters directive
+func (p *ParametersParser) Matches(comment string, ctx parsers.ParseContext) bool {
+	return ctx == parsers.ContextRoute && strings.Contains(comment, "Parameters:")
+}
+
+// Parse extracts parameters from multi-line Parameters: section
+func (p *ParametersParser) Parse(comments *ast.CommentGroup, ctx parsers.ParseContext) (any, error) {
+	if ctx != parsers.ContextRoute {
+		return nil, nil
+	}
+
+	section := extractSection(comments.Text(), "Parameters:")
+	if section == "" {
+		return nil, nil
+	}
+
+	var params []*ParsedParameter
+	for _, item := range splitSectionItems(section) {
+		param, err := parseParameterItem(item)
+		if err != nil {
+			return nil, &parsers.ErrParseFailure{
+				ParserName: "parameters",
+				Context:    ctx,
+				Cause:      err,
+			}
+		}
+		params = append(params, param)
+	}
+
+	return params, nil
+}
+
+// Apply merges the parsed parameters into the operation
+func (p *ParametersParser) Apply(target any, value any, ctx parsers.ParseContext) error {
+	if ctx != parsers.ContextRoute {
+		return nil
+	}
+
+	operation, ok := target.(*spec.Operation)
+	if !ok {
+		return &parsers.ErrInvalidTarget{
+			ParserName:   "parameters",
+			Context:      ctx,
+			ExpectedType: "*spec.Operation",
+			ActualType:   fmt.Sprintf("%T", target),
+		}
+	}
+
+	params, ok := value.([]*ParsedParameter)
+	if !ok {
+		// If value is nil, nothing to apply
+		if value == nil {
+			return nil
+		}
+		return &parsers.ErrInvalidValue{
+			ParserName:   "parameters",
+			ExpectedType: "[]*ParsedParameter",
+			ActualType:   fmt.Sprintf("%T", value),
+		}
+	}
+
+	for _, parsed := range params {
+		param := findParameter(operation.Parameters, parsed.Name, parsed.In)
+		if param == nil {
+			if parsed.In == "" {
+				return &parsers.ErrParseFailure{
+					ParserName: "parameters",
+					Context:    ctx,
+					Cause:      fmt.Errorf("parameter %q does not exist and has no \"in\" location", parsed.Name),
+				}
+			}
+			param = &spec.Parameter{
+				Name:     parsed.Name,
+				In:       parsed.In,
+				Required: parsed.In == "path",
+				Schema:   &spec.Schema{Type: "string"},
+			}
+			operation.Parameters = append(operation.Parameters, param)
+		}
+
+		if parsed.Description != "" {
+			param.Description = parsed.Description
+		}
+		if parsed.Example != nil {
+			param.Example = parsed.Example
+		}
+		if parsed.Required != nil {
+			param.Required = *parsed.Required
+		}
+	}
+
+	return nil
+}
+
+// ParsedParameter holds a parameter entry from the Parameters section
+type ParsedParameter struct {
+	Name        string
+	In          string
+	Description string
+	Example     any
+	Required    *bool
+}
+
+// findParameter looks up an operation parameter by name and, if given, location
+func findParameter(params []*spec.Parameter, name, in string) *spec.Parameter {
+	for _, param := range params {
+		if param.Name == name && (in == "" || param.In == in) {
+			return param
+		}
+	}
+	return nil
+}
+
+// splitSectionItems splits the section into one string per "- " entry,
+// joining continuation lines onto the entry they belong to
+func splitSectionItems(section string) []string {
+	var items []string
+	for _, line := range strings.Split(section, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "-"); ok {
+			items = append(items, strings.TrimSpace(rest))
+			continue
+		}
+
+		if len(items) > 0 {
+			items[len(items)-1] += " " + line
+		}
+	}
+	return items
+}
+
+// parseParameterItem parses the "key: value" pairs of a single entry
+// Values may be quoted to include spaces or key-like text
+// Example: `name: status in: query description: "Status filter" example: available`
+func parseParameterItem(item string) (*ParsedParameter, error) {
+	param := &ParsedParameter{}
+
+	rest := item
+	for strings.TrimSpace(rest) != "" {
+		loc := parameterKeyPattern.FindStringSubmatchIndex(rest)
+		if loc == nil {
+			return nil, fmt.Errorf("unexpected text %q in parameter entry", strings.TrimSpace(rest))
+		}
+		key := rest[loc[2]:loc[3]]
+		rest = rest[loc[1]:]
+
+		var value string
+		value, rest = nextItemValue(rest, parameterNextKeyPattern)
+
+		switch key {
+		case "name":
+			param.Name = value
+		case "in":
+			param.In = strings.ToLower(value)
+		case "description":
+			param.Description = value
+		case "example":
+			param.Example = parseExampleValue(value)
+		case "required":
+			required := parseBool(value)
+			param.Required = &required
+		}
+	}
+
+	if param.Name == "" {
+		return nil, fmt.Errorf("parameter entry %q has no name", item)
+	}
+	if param.In != "" && !validParameterLocations[param.In] {
+		return nil, fmt.Errorf("parameter %q has unsupported location %q", param.Name, param.In)
+	}
+
+	return param, nil
+}
+
+// nextItemValue reads a value up to the next key matched by nextKey, honoring double quotes
+// Returns the value and the remaining text
+func nextItemValue(s string, nextKey *regexp.Regexp) (string, string) {
+	if strings.HasPrefix(s, `"`) {
+		if quoted, err := strconv.QuotedPrefix(s); err == nil {
+			value, _ := strconv.Unquote(quoted)
+			return value, s[len(quoted):]
+		}
+	}
+
+	if loc := nextKey.FindStringIndex(s); loc != nil {
+		return strings.TrimSpace(s[:loc[0]]), s[loc[0]:]
+	}
+	return strings.TrimSpace(s), ""
+}
@@ -0,0 +1,119 @@
+package tags
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestParametersParser(t *testing.T) {
+	comments := `swagger:route GET /pets/{petId} pet getPet
+
+Summary: Get a pet
+
+Parameters:
+- name: status in: query description: "Status to filter by: available or sold" example: available
+- name: petId description: ID of the pet example: 42
+- name: X-Request-ID
+  in: header
+  description: Correlation ID
+  required: true
+
+Responses:
+- 200: Pet`
+
+	commentGroup := &ast.CommentGroup{}
+	for _, line := range splitLines(comments) {
+		commentGroup.List = append(commentGroup.List, &ast.Comment{Text: "// " + line})
+	}
+
+	// Parameters derived from the route struct
+	operation := &spec.Operation{
+		Parameters: []*spec.Parameter{
+			{Name: "petId", In: "path", Required: true, Schema: &spec.Schema{Type: "integer"}},
+			{Name: "status", In: "query", Description: "status", Schema: &spec.Schema{Type: "string"}},
+		},
+	}
+
+	if err := parsers.GlobalRegistry().Parse("swagger:route", commentGroup, operation, parsers.ContextRoute); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// Parameter descriptions must not leak into the operation description
+	if operation.Description != "" {
+		t.Errorf("expected no operation description, got %q", operation.Description)
+	}
+
+	if len(operation.Parameters) != 3 {
+		t.Fatalf("expected 3 parameters, got %d", len(operation.Parameters))
+	}
+
+	petID := operation.Parameters[0]
+	if petID.Description != "ID of the pet" {
+		t.Errorf("expected petId description override, got %q", petID.Description)
+	}
+	if petID.Example != float64(42) {
+		t.Errorf("expected petId example 42, got %v", petID.Example)
+	}
+	if !petID.Required || petID.Schema.Type != "integer" {
+		t.Errorf("expected petId to keep required and schema, got %+v", petID)
+	}
+
+	status := operation.Parameters[1]
+	if status.Description != "Status to filter by: available or sold" {
+		t.Errorf("expected quoted status description, got %q", status.Description)
+	}
+	if status.Example != "available" {
+		t.Errorf("expected status example 'available', got %v", status.Example)
+	}
+
+	requestID := operation.Parameters[2]
+	if requestID.Name != "X-Request-ID" || requestID.In != "header" {
+		t.Errorf("expected new X-Request-ID header parameter, got %+v", requestID)
+	}
+	if requestID.Description != "Correlation ID" {
+		t.Errorf("expected X-Request-ID description, got %q", requestID.Description)
+	}
+	if !requestID.Required {
+		t.Error("expected X-Request-ID to be required")
+	}
+}
+
+func TestParametersParser_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+	}{
+		{
+			name:    "unsupported location",
+			section: "- name: session in: body",
+		},
+		{
+			name:    "missing name",
+			section: "- in: query description: Missing name",
+		},
+		{
+			name:    "new parameter without location",
+			section: "- name: unknown description: Not derived from the struct",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentGroup := &ast.CommentGroup{
+				List: []*ast.Comment{
+					{Text: "// swagger:route GET /pets pet listPets"},
+					{Text: "// Parameters:"},
+					{Text: "// " + tt.section},
+				},
+			}
+
+			operation := &spec.Operation{}
+			if err := parsers.GlobalRegistry().Parse("swagger:route", commentGroup, operation, parsers.ContextRoute); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -70,7 +70,8 @@ func NewProducesParser() parsers.TagParser {
 				// Parse comma-separated MIME types
 				mimeTypes := parseMimeTypes(producesStr)
 
-				// Store in extensions (will be used when creating responses)
+				// Store in extensions: responses may be parsed after this tag, so the
+				// builder applies the media types once all route tags are parsed
 				if operation.Extensions == nil {
 					operation.Extensions = make(map[string]any)
 				}
@@ -0,0 +1,72 @@
+package tags
+
+import (
+	"encoding/json"
+	"go/ast"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestReadOnlyWriteOnlyParser_Field(t *testing.T) {
+	tests := []struct {
+		name          string
+		comment       string
+		wantReadOnly  bool
+		wantWriteOnly bool
+		wantJSON      string
+	}{
+		{
+			name:         "read only",
+			comment:      "readOnly: true",
+			wantReadOnly: true,
+			wantJSON:     `"readOnly":true`,
+		},
+		{
+			name:          "write only",
+			comment:       "WriteOnly: yes",
+			wantWriteOnly: true,
+			wantJSON:      `"writeOnly":true`,
+		},
+		{
+			name:    "read only false",
+			comment: "readOnly: false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := &ast.CommentGroup{
+				List: []*ast.Comment{
+					{Text: "// Identifier of the pet"},
+					{Text: "// " + tt.comment},
+				},
+			}
+
+			schema := &spec.Schema{Type: "string"}
+			if err := parsers.GlobalRegistry().Parse("swagger:model", comments, schema, parsers.ContextField); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if schema.ReadOnly != tt.wantReadOnly {
+				t.Errorf("expected readOnly %v, got %v", tt.wantReadOnly, schema.ReadOnly)
+			}
+			if schema.WriteOnly != tt.wantWriteOnly {
+				t.Errorf("expected writeOnly %v, got %v", tt.wantWriteOnly, schema.WriteOnly)
+			}
+
+			data, err := json.Marshal(schema)
+			if err != nil {
+				t.Fatalf("failed to marshal schema: %v", err)
+			}
+			if tt.wantJSON != "" && !strings.Contains(string(data), tt.wantJSON) {
+				t.Errorf("expected %s in JSON, got %s", tt.wantJSON, data)
+			}
+			if tt.wantJSON == "" && strings.Contains(string(data), "Only") {
+				t.Errorf("expected readOnly and writeOnly to be omitted, got %s", data)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/reation-io/apikit/openapi/parsers"
@@ -149,6 +150,10 @@ type ParsedResponse struct {
 
 // parseResponseLine parses a single response line
 // Format: "- 200: ResponseType" or "- default: ErrorResponse"
+// An optional media type may follow the type, with or without "as":
+// "- 200: Pet as image/png", "- 200: string text/plain"
+// A quoted description may come last, replacing the default description:
+// "- 404: Error \"user not found\""
 func parseResponseLine(line string) *ParsedResponse {
 	matches := responseLinePattern.FindStringSubmatch(line)
 	if len(matches) != 3 {
@@ -156,23 +161,26 @@ func parseResponseLine(line string) *ParsedResponse {
 	}
 
 	statusCode := strings.TrimSpace(matches[1])
-	responseType := strings.TrimSpace(matches[2])
+	value, description := splitResponseDescription(strings.TrimSpace(matches[2]))
+	responseType, mediaType := splitResponseMediaType(value)
 
 	if statusCode == "" || responseType == "" {
 		return nil
 	}
 
+	if description == "" {
+		description = getDefaultDescription(statusCode)
+	}
+
 	// Create response with schema reference
 	response := &spec.Response{
-		Description: getDefaultDescription(statusCode),
+		Description: description,
 		Content:     make(map[string]*spec.MediaType),
 	}
 
-	// Add JSON content with schema reference
-	response.Content["application/json"] = &spec.MediaType{
-		Schema: &spec.Schema{
-			Ref: fmt.Sprintf("#/components/schemas/%s", responseType),
-		},
+	// Add content with schema reference
+	response.Content[mediaType] = &spec.MediaType{
+		Schema: responseSchema(responseType),
 	}
 
 	return &ParsedResponse{
@@ -181,6 +189,76 @@ func parseResponseLine(line string) *ParsedResponse {
 	}
 }
 
+// splitResponseDescription separates a trailing quoted description from the response type
+// Returns an empty description when the line has none
+// Example: `Error "user not found"` -> ("Error", "user not found")
+func splitResponseDescription(value string) (string, string) {
+	start := strings.Index(value, `"`)
+	if start <= 0 {
+		return value, ""
+	}
+
+	description, err := strconv.Unquote(value[start:])
+	if err != nil {
+		return value, ""
+	}
+
+	return strings.TrimSpace(value[:start]), strings.TrimSpace(description)
+}
+
+// splitResponseMediaType separates the response type from an optional media type
+// Defaults to application/json when no media type is given
+// Example: "Pet as image/png" -> ("Pet", "image/png"), "Pet" -> ("Pet", "application/json")
+func splitResponseMediaType(value string) (string, string) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 || !strings.Contains(fields[len(fields)-1], "/") {
+		return value, "application/json"
+	}
+
+	mediaType := fields[len(fields)-1]
+	fields = fields[:len(fields)-1]
+	if len(fields) > 1 && strings.EqualFold(fields[len(fields)-1], "as") {
+		fields = fields[:len(fields)-1]
+	}
+
+	return strings.Join(fields, " "), mediaType
+}
+
+// responseSchema returns the schema for a response type
+// Primitive Go types map to JSON types, slices and string-keyed maps wrap their
+// element schema, and anything else references a component schema
+// Example: "[]Pet" -> array of #/components/schemas/Pet
+func responseSchema(responseType string) *spec.Schema {
+	if elem, ok := strings.CutPrefix(responseType, "[]"); ok {
+		return &spec.Schema{
+			Type:  "array",
+			Items: responseSchema(elem),
+		}
+	}
+	if elem, ok := strings.CutPrefix(responseType, "map[string]"); ok {
+		return &spec.Schema{
+			Type:                 "object",
+			AdditionalProperties: responseSchema(elem),
+		}
+	}
+
+	switch responseType {
+	case "string":
+		return &spec.Schema{Type: "string"}
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return &spec.Schema{Type: "integer"}
+	case "float32", "float64":
+		return &spec.Schema{Type: "number"}
+	case "bool":
+		return &spec.Schema{Type: "boolean"}
+	default:
+		return &spec.Schema{
+			Ref: fmt.Sprintf("#/components/schemas/%s", responseType),
+		}
+	}
+}
+
 // getDefaultDescription returns a default description for common status codes
 func getDefaultDescription(statusCode string) string {
 	descriptions := map[string]string{
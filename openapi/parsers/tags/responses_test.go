@@ -163,6 +163,97 @@ summary: List users`,
 	}
 }
 
+func TestResponsesParser_MediaType(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		wantMediaType string
+		wantRef       string
+		wantType      string
+	}{
+		{
+			name:          "bare type keeps JSON",
+			line:          "- 200: Pet",
+			wantMediaType: "application/json",
+			wantRef:       "#/components/schemas/Pet",
+		},
+		{
+			name:          "media type with as",
+			line:          "- 200: Pet as image/png",
+			wantMediaType: "image/png",
+			wantRef:       "#/components/schemas/Pet",
+		},
+		{
+			name:          "text/plain string",
+			line:          "- 200: string text/plain",
+			wantMediaType: "text/plain",
+			wantType:      "string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := parseResponseLine(tt.line)
+			if parsed == nil {
+				t.Fatalf("expected response for %q", tt.line)
+			}
+
+			content := parsed.Response.Content
+			if len(content) != 1 {
+				t.Fatalf("expected exactly one content entry, got %v", content)
+			}
+
+			mediaType := content[tt.wantMediaType]
+			if mediaType == nil || mediaType.Schema == nil {
+				t.Fatalf("expected content for %q, got %v", tt.wantMediaType, content)
+			}
+			if mediaType.Schema.Ref != tt.wantRef {
+				t.Errorf("expected ref %q, got %q", tt.wantRef, mediaType.Schema.Ref)
+			}
+			if mediaType.Schema.Type != tt.wantType {
+				t.Errorf("expected type %q, got %q", tt.wantType, mediaType.Schema.Type)
+			}
+		})
+	}
+}
+
+func TestResponsesParser_CollectionTypes(t *testing.T) {
+	// Slice of models
+	parsed := parseResponseLine("- 200: []Pet")
+	if parsed == nil {
+		t.Fatal("expected response for slice type")
+	}
+	schema := parsed.Response.Content["application/json"].Schema
+	if schema.Type != "array" || schema.Ref != "" {
+		t.Errorf("expected array schema without ref, got %+v", schema)
+	}
+	if schema.Items == nil || schema.Items.Ref != "#/components/schemas/Pet" {
+		t.Errorf("expected items to reference Pet, got %+v", schema.Items)
+	}
+
+	// Map of primitives
+	parsed = parseResponseLine("- 200: map[string]int32")
+	if parsed == nil {
+		t.Fatal("expected response for map type")
+	}
+	schema = parsed.Response.Content["application/json"].Schema
+	if schema.Type != "object" {
+		t.Errorf("expected object schema, got %+v", schema)
+	}
+	additional, ok := schema.AdditionalProperties.(*spec.Schema)
+	if !ok || additional.Type != "integer" {
+		t.Errorf("expected integer additionalProperties, got %#v", schema.AdditionalProperties)
+	}
+
+	// Map of models
+	parsed = parseResponseLine("- 200: map[string]Pet")
+	schema = parsed.Response.Content["application/json"].Schema
+	additional, ok = schema.AdditionalProperties.(*spec.Schema)
+	if !ok || additional.Ref != "#/components/schemas/Pet" {
+		t.Errorf("expected additionalProperties to reference Pet, got %#v", schema.AdditionalProperties)
+	}
+}
+
 func splitLines(s string) []string {
 	lines := []string{}
 	current := ""
@@ -179,3 +270,52 @@ func splitLines(s string) []string {
 	}
 	return lines
 }
+
+func TestResponsesParser_Description(t *testing.T) {
+	tests := []struct {
+		name            string
+		line            string
+		wantDescription string
+		wantMediaType   string
+		wantRef         string
+	}{
+		{
+			name:            "quoted description",
+			line:            `- 404: Error "user not found"`,
+			wantDescription: "user not found",
+			wantMediaType:   "application/json",
+			wantRef:         "#/components/schemas/Error",
+		},
+		{
+			name:            "description after media type",
+			line:            `- 200: Pet as image/png "the pet's photo"`,
+			wantDescription: "the pet's photo",
+			wantMediaType:   "image/png",
+			wantRef:         "#/components/schemas/Pet",
+		},
+		{
+			name:            "unquoted line keeps the default",
+			line:            "- 404: Error",
+			wantDescription: "Not Found",
+			wantMediaType:   "application/json",
+			wantRef:         "#/components/schemas/Error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := parseResponseLine(tt.line)
+			if parsed == nil {
+				t.Fatalf("expected response for %q", tt.line)
+			}
+
+			if parsed.Response.Description != tt.wantDescription {
+				t.Errorf("expected description %q, got %q", tt.wantDescription, parsed.Response.Description)
+			}
+			mediaType := parsed.Response.Content[tt.wantMediaType]
+			if mediaType == nil || mediaType.Schema.Ref != tt.wantRef {
+				t.Errorf("expected %s content referencing %q, got %v", tt.wantMediaType, tt.wantRef, parsed.Response.Content)
+			}
+		})
+	}
+}
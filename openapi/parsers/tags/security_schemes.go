@@ -2,6 +2,10 @@ package tags
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/reation-io/apikit/openapi/parsers"
 	"github.com/reation-io/apikit/openapi/parsers/base"
@@ -9,12 +13,17 @@ import (
 )
 
 // NewSecuritySchemesParser creates a SecuritySchemes parser for swagger:meta
+// Supports apiKey, http, oauth2 and openIdConnect schemes
 // Parses YAML content like:
 // SecuritySchemes:
 //   bearer:
 //     type: http
 //     scheme: bearer
 //     bearerFormat: JWT
+//   api_key:
+//     type: apiKey
+//     name: X-API-Key
+//     in: header
 func NewSecuritySchemesParser() parsers.TagParser {
 	return base.NewYAMLParser(
 		"SecuritySchemes",
@@ -54,6 +63,17 @@ func NewSecuritySchemesParser() parsers.TagParser {
 					}
 				}
 
+				// Reject schemes missing the fields required by their type
+				for _, name := range slices.Sorted(maps.Keys(schemes)) {
+					if err := validateSecurityScheme(name, schemes[name]); err != nil {
+						return &parsers.ErrParseFailure{
+							ParserName: "SecuritySchemes",
+							Context:    parsers.ContextMeta,
+							Cause:      err,
+						}
+					}
+				}
+
 				// Initialize Components if needed
 				if openapi.Components == nil {
 					openapi.Components = &spec.Components{}
@@ -73,6 +93,69 @@ func NewSecuritySchemesParser() parsers.TagParser {
 	)
 }
 
+// validateSecurityScheme checks the fields required for the scheme's type
+func validateSecurityScheme(name string, scheme *spec.SecurityScheme) error {
+	if scheme == nil {
+		return fmt.Errorf("security scheme %q is empty", name)
+	}
+
+	switch scheme.Type {
+	case "apiKey":
+		if scheme.Name == "" {
+			return fmt.Errorf("security scheme %q: apiKey requires name", name)
+		}
+		if scheme.In != "query" && scheme.In != "header" && scheme.In != "cookie" {
+			return fmt.Errorf("security scheme %q: apiKey requires in to be query, header or cookie", name)
+		}
+	case "http":
+		if scheme.Scheme == "" {
+			return fmt.Errorf("security scheme %q: http requires scheme", name)
+		}
+	case "oauth2":
+		return validateOAuthFlows(name, scheme.Flows)
+	case "openIdConnect":
+		if scheme.OpenIdConnectURL == "" {
+			return fmt.Errorf("security scheme %q: openIdConnect requires openIdConnectUrl", name)
+		}
+	case "":
+		return fmt.Errorf("security scheme %q has no type", name)
+	default:
+		return fmt.Errorf("security scheme %q has unsupported type %q", name, scheme.Type)
+	}
+
+	return nil
+}
+
+// validateOAuthFlows checks that at least one flow is defined with its required URLs
+func validateOAuthFlows(name string, flows *spec.OAuthFlows) error {
+	if flows == nil || (flows.Implicit == nil && flows.Password == nil &&
+		flows.ClientCredentials == nil && flows.AuthorizationCode == nil) {
+		return fmt.Errorf("security scheme %q: oauth2 requires at least one flow", name)
+	}
+
+	var errs []error
+	check := func(flow string, f *spec.OAuthFlow, needsAuthorizationURL, needsTokenURL bool) {
+		if f == nil {
+			return
+		}
+		if needsAuthorizationURL && f.AuthorizationURL == "" {
+			errs = append(errs, fmt.Errorf("security scheme %q: %s flow requires authorizationUrl", name, flow))
+		}
+		if needsTokenURL && f.TokenURL == "" {
+			errs = append(errs, fmt.Errorf("security scheme %q: %s flow requires tokenUrl", name, flow))
+		}
+		if f.Scopes == nil {
+			errs = append(errs, fmt.Errorf("security scheme %q: %s flow requires scopes", name, flow))
+		}
+	}
+	check("implicit", flows.Implicit, true, false)
+	check("password", flows.Password, false, true)
+	check("clientCredentials", flows.ClientCredentials, false, true)
+	check("authorizationCode", flows.AuthorizationCode, true, true)
+
+	return errors.Join(errs...)
+}
+
 func init() {
 	parsers.Register("swagger:meta", NewSecuritySchemesParser())
 }
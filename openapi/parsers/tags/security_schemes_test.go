@@ -0,0 +1,138 @@
+package tags
+
+import (
+	"go/ast"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestSecuritySchemesParser(t *testing.T) {
+	// Indentation matches what gofmt writes for an indented comment block
+	comments := "swagger:meta\n" +
+		"\n" +
+		"Title: Pet Store\n" +
+		"\n" +
+		"SecuritySchemes:\n" +
+		"\n" +
+		"\tapi_key:\n" +
+		"\t  type: apiKey\n" +
+		"\t  name: X-API-Key\n" +
+		"\t  in: header\n" +
+		"\tpetstore_auth:\n" +
+		"\t  type: oauth2\n" +
+		"\t  flows:\n" +
+		"\t    implicit:\n" +
+		"\t      authorizationUrl: https://example.com/oauth/authorize\n" +
+		"\t      scopes:\n" +
+		"\t        write:pets: modify pets\n" +
+		"\t        read:pets: read pets\n" +
+		"\n" +
+		"Version: 1.0.0"
+
+	openapi := &spec.OpenAPI{Info: &spec.Info{}}
+	commentGroup := indentedComments(comments)
+
+	if err := parsers.GlobalRegistry().Parse("swagger:meta", commentGroup, openapi, parsers.ContextMeta); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if openapi.Components == nil || len(openapi.Components.SecuritySchemes) != 2 {
+		t.Fatalf("expected 2 security schemes, got %+v", openapi.Components)
+	}
+
+	apiKey := openapi.Components.SecuritySchemes["api_key"]
+	if apiKey == nil || apiKey.Type != "apiKey" || apiKey.Name != "X-API-Key" || apiKey.In != "header" {
+		t.Errorf("unexpected api_key scheme: %+v", apiKey)
+	}
+
+	auth := openapi.Components.SecuritySchemes["petstore_auth"]
+	if auth == nil || auth.Flows == nil || auth.Flows.Implicit == nil {
+		t.Fatalf("expected petstore_auth with an implicit flow, got %+v", auth)
+	}
+	if auth.Flows.Implicit.AuthorizationURL != "https://example.com/oauth/authorize" {
+		t.Errorf("unexpected authorizationUrl %q", auth.Flows.Implicit.AuthorizationURL)
+	}
+	if auth.Flows.Implicit.Scopes["write:pets"] != "modify pets" || auth.Flows.Implicit.Scopes["read:pets"] != "read pets" {
+		t.Errorf("unexpected scopes %+v", auth.Flows.Implicit.Scopes)
+	}
+
+	// Directives after the block are still parsed
+	if err := parsers.GlobalRegistry().Parse("swagger:meta", commentGroup, openapi.Info, parsers.ContextMeta); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if openapi.Info.Version != "1.0.0" {
+		t.Errorf("expected version 1.0.0, got %q", openapi.Info.Version)
+	}
+}
+
+func TestSecuritySchemesParser_MissingFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		scheme  string
+		wantErr string
+	}{
+		{
+			name:    "apiKey without name",
+			scheme:  "  type: apiKey\n  in: header",
+			wantErr: "apiKey requires name",
+		},
+		{
+			name:    "apiKey with invalid location",
+			scheme:  "  type: apiKey\n  name: key\n  in: body",
+			wantErr: "apiKey requires in to be query, header or cookie",
+		},
+		{
+			name:    "http without scheme",
+			scheme:  "  type: http",
+			wantErr: "http requires scheme",
+		},
+		{
+			name:    "oauth2 without flows",
+			scheme:  "  type: oauth2",
+			wantErr: "oauth2 requires at least one flow",
+		},
+		{
+			name:    "oauth2 password flow without tokenUrl",
+			scheme:  "  type: oauth2\n  flows:\n    password:\n      scopes: {}",
+			wantErr: "password flow requires tokenUrl",
+		},
+		{
+			name:    "unsupported type",
+			scheme:  "  type: mutualTLS",
+			wantErr: `unsupported type "mutualTLS"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := strings.ReplaceAll("  "+tt.scheme, "\n", "\n  ")
+			comments := "swagger:meta\n\nSecuritySchemes:\n  auth:\n" + scheme
+
+			openapi := &spec.OpenAPI{}
+			err := parsers.GlobalRegistry().Parse("swagger:meta", indentedComments(comments), openapi, parsers.ContextMeta)
+			if err == nil {
+				t.Fatal("expected error for invalid security scheme")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// indentedComments builds a comment group from the given lines
+// Tab-indented lines are written the way gofmt formats comment blocks
+func indentedComments(comments string) *ast.CommentGroup {
+	commentGroup := &ast.CommentGroup{}
+	for _, line := range splitLines(comments) {
+		if strings.HasPrefix(line, "\t") {
+			commentGroup.List = append(commentGroup.List, &ast.Comment{Text: "//" + line})
+			continue
+		}
+		commentGroup.List = append(commentGroup.List, &ast.Comment{Text: "// " + line})
+	}
+	return commentGroup
+}
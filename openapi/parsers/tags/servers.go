@@ -2,6 +2,10 @@ package tags
 
 import (
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/reation-io/apikit/openapi/parsers"
 	"github.com/reation-io/apikit/openapi/parsers/base"
@@ -13,8 +17,13 @@ import (
 // Servers:
 //   - url: https://api.example.com/v1
 //     description: Production server
-//   - url: https://staging-api.example.com/v1
-//     description: Staging server
+//   - url: https://{region}.example.com/v1 description: "Regional server"
+//     variables:
+//       region:
+//         default: eu
+//         enum: [eu, us]
+//
+// The url and description of an entry may share its first line
 func NewServersParser() parsers.TagParser {
 	return base.NewYAMLParser(
 		"Servers",
@@ -54,13 +63,95 @@ func NewServersParser() parsers.TagParser {
 					}
 				}
 
+				for i, server := range servers {
+					if err := validateServer(server); err != nil {
+						return &parsers.ErrParseFailure{
+							ParserName: "Servers",
+							Context:    parsers.ContextMeta,
+							Cause:      fmt.Errorf("server %d: %w", i+1, err),
+						}
+					}
+				}
+
 				// Set servers
 				openapi.Servers = servers
 
 				return nil
 			},
 		},
-	)
+	).WithNormalizer(normalizeServerItems)
+}
+
+// Pattern matches a server key at the current position (e.g., "url: ")
+var serverKeyPattern = regexp.MustCompile(`^\s*(url|description)\s*:\s*`)
+
+// Pattern matches the start of the next server key inside an unquoted value
+var serverNextKeyPattern = regexp.MustCompile(`\s(url|description)\s*:`)
+
+// normalizeServerItems rewrites entries like `- url: x description: "y"`
+// into one YAML key per line so the block can be unmarshaled
+func normalizeServerItems(block string) string {
+	lines := strings.Split(block, "\n")
+	normalized := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		item, ok := strings.CutPrefix(trimmed, "- ")
+		if !ok {
+			normalized = append(normalized, line)
+			continue
+		}
+
+		pairs, ok := parseServerPairs(item)
+		if !ok {
+			normalized = append(normalized, line)
+			continue
+		}
+
+		indent := strings.Repeat(" ", len(line)-len(trimmed))
+		for i, pair := range pairs {
+			prefix := indent + "  "
+			if i == 0 {
+				prefix = indent + "- "
+			}
+			normalized = append(normalized, prefix+pair[0]+": "+strconv.Quote(pair[1]))
+		}
+	}
+	return strings.Join(normalized, "\n")
+}
+
+// parseServerPairs splits the first line of a server entry into key/value pairs
+// Returns false when the line contains anything but url and description keys
+func parseServerPairs(item string) ([][2]string, bool) {
+	var pairs [][2]string
+
+	rest := item
+	for strings.TrimSpace(rest) != "" {
+		loc := serverKeyPattern.FindStringSubmatchIndex(rest)
+		if loc == nil {
+			return nil, false
+		}
+		key := rest[loc[2]:loc[3]]
+		rest = rest[loc[1]:]
+
+		var value string
+		value, rest = nextItemValue(rest, serverNextKeyPattern)
+		pairs = append(pairs, [2]string{key, value})
+	}
+
+	return pairs, len(pairs) > 0
+}
+
+// validateServer checks the fields required for a server entry
+func validateServer(server *spec.Server) error {
+	if server == nil || server.URL == "" {
+		return fmt.Errorf("server has no url")
+	}
+	for name, variable := range server.Variables {
+		if variable == nil || variable.Default == "" {
+			return fmt.Errorf("server variable %q requires default", name)
+		}
+	}
+	return nil
 }
 
 func init() {
@@ -0,0 +1,69 @@
+package tags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestNormalizeServerItems(t *testing.T) {
+	block := `- url: https://api.example.com description: "Prod: primary"
+- url: https://{env}.example.com
+  description: Configurable
+  variables:
+    env:
+      default: staging`
+
+	want := `- url: "https://api.example.com"
+  description: "Prod: primary"
+- url: "https://{env}.example.com"
+  description: Configurable
+  variables:
+    env:
+      default: staging`
+
+	if got := normalizeServerItems(block); got != want {
+		t.Errorf("normalizeServerItems() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestServersParser_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments string
+		wantErr  string
+	}{
+		{
+			name: "missing url",
+			comments: `swagger:meta
+Servers:
+- description: "No url"`,
+			wantErr: "server 1: server has no url",
+		},
+		{
+			name: "variable without default",
+			comments: `swagger:meta
+Servers:
+- url: https://{env}.example.com
+  variables:
+    env:
+      enum: [prod, staging]`,
+			wantErr: `server variable "env" requires default`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openapi := &spec.OpenAPI{}
+			err := parsers.GlobalRegistry().Parse("swagger:meta", indentedComments(tt.comments), openapi, parsers.ContextMeta)
+			if err == nil {
+				t.Fatal("expected error for invalid server")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
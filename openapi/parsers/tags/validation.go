@@ -1,6 +1,7 @@
 package tags
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/reation-io/apikit/openapi/parsers"
@@ -88,6 +89,116 @@ func NewMaximumParser() parsers.TagParser {
 	)
 }
 
+// NewExclusiveMinimumParser creates an ExclusiveMinimum parser for field comments
+// It marks the minimum as exclusive (value > minimum)
+func NewExclusiveMinimumParser() parsers.TagParser {
+	return base.NewSingleLineParser(
+		"ExclusiveMinimum",
+		parsers.RxExclusiveMinimum,
+		[]parsers.ParseContext{parsers.ContextField},
+		parsers.SetterMap{
+			parsers.ContextField: func(target any, value any) error {
+				schema, ok := target.(*spec.Schema)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   "ExclusiveMinimum",
+						Context:      parsers.ContextField,
+						ExpectedType: "*spec.Schema",
+						ActualType:   getTypeName(target),
+					}
+				}
+				exclusiveStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   "ExclusiveMinimum",
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
+				}
+				schema.ExclusiveMinimum = parseBool(exclusiveStr)
+				return nil
+			},
+		},
+	)
+}
+
+// NewExclusiveMaximumParser creates an ExclusiveMaximum parser for field comments
+// It marks the maximum as exclusive (value < maximum)
+func NewExclusiveMaximumParser() parsers.TagParser {
+	return base.NewSingleLineParser(
+		"ExclusiveMaximum",
+		parsers.RxExclusiveMaximum,
+		[]parsers.ParseContext{parsers.ContextField},
+		parsers.SetterMap{
+			parsers.ContextField: func(target any, value any) error {
+				schema, ok := target.(*spec.Schema)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   "ExclusiveMaximum",
+						Context:      parsers.ContextField,
+						ExpectedType: "*spec.Schema",
+						ActualType:   getTypeName(target),
+					}
+				}
+				exclusiveStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   "ExclusiveMaximum",
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
+				}
+				schema.ExclusiveMaximum = parseBool(exclusiveStr)
+				return nil
+			},
+		},
+	)
+}
+
+// NewMultipleOfParser creates a MultipleOf parser for field comments
+// Example: "multipleOf: 0.01" for currency amounts
+func NewMultipleOfParser() parsers.TagParser {
+	return base.NewSingleLineParser(
+		"MultipleOf",
+		parsers.RxMultipleOf,
+		[]parsers.ParseContext{parsers.ContextField},
+		parsers.SetterMap{
+			parsers.ContextField: func(target any, value any) error {
+				schema, ok := target.(*spec.Schema)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   "MultipleOf",
+						Context:      parsers.ContextField,
+						ExpectedType: "*spec.Schema",
+						ActualType:   getTypeName(target),
+					}
+				}
+				multipleStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   "MultipleOf",
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
+				}
+				multiple, err := strconv.ParseFloat(multipleStr, 64)
+				if err == nil && multiple <= 0 {
+					err = fmt.Errorf("multipleOf must be greater than 0, got %s", multipleStr)
+				}
+				if err != nil {
+					return &parsers.ErrParseFailure{
+						ParserName: "MultipleOf",
+						Context:    parsers.ContextField,
+						Cause:      err,
+					}
+				}
+				schema.MultipleOf = &multiple
+				return nil
+			},
+		},
+	)
+}
+
 // NewMinLengthParser creates a MinLength parser for field comments
 func NewMinLengthParser() parsers.TagParser {
 	return base.NewSingleLineParser(
@@ -168,6 +279,129 @@ func NewMaxLengthParser() parsers.TagParser {
 	)
 }
 
+// NewMinItemsParser creates a MinItems parser for field comments
+// It only applies to array schemas
+func NewMinItemsParser() parsers.TagParser {
+	return base.NewSingleLineParser(
+		"MinItems",
+		parsers.RxMinItems,
+		[]parsers.ParseContext{parsers.ContextField},
+		parsers.SetterMap{
+			parsers.ContextField: func(target any, value any) error {
+				schema, ok := target.(*spec.Schema)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   "MinItems",
+						Context:      parsers.ContextField,
+						ExpectedType: "*spec.Schema",
+						ActualType:   getTypeName(target),
+					}
+				}
+				minItemsStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   "MinItems",
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
+				}
+				if schema.Type != "array" {
+					return nil
+				}
+				minItems, err := strconv.ParseInt(minItemsStr, 10, 64)
+				if err != nil {
+					return &parsers.ErrParseFailure{
+						ParserName: "MinItems",
+						Context:    parsers.ContextField,
+						Cause:      err,
+					}
+				}
+				schema.MinItems = &minItems
+				return nil
+			},
+		},
+	)
+}
+
+// NewMaxItemsParser creates a MaxItems parser for field comments
+// It only applies to array schemas
+func NewMaxItemsParser() parsers.TagParser {
+	return base.NewSingleLineParser(
+		"MaxItems",
+		parsers.RxMaxItems,
+		[]parsers.ParseContext{parsers.ContextField},
+		parsers.SetterMap{
+			parsers.ContextField: func(target any, value any) error {
+				schema, ok := target.(*spec.Schema)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   "MaxItems",
+						Context:      parsers.ContextField,
+						ExpectedType: "*spec.Schema",
+						ActualType:   getTypeName(target),
+					}
+				}
+				maxItemsStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   "MaxItems",
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
+				}
+				if schema.Type != "array" {
+					return nil
+				}
+				maxItems, err := strconv.ParseInt(maxItemsStr, 10, 64)
+				if err != nil {
+					return &parsers.ErrParseFailure{
+						ParserName: "MaxItems",
+						Context:    parsers.ContextField,
+						Cause:      err,
+					}
+				}
+				schema.MaxItems = &maxItems
+				return nil
+			},
+		},
+	)
+}
+
+// NewUniqueItemsParser creates a UniqueItems parser for field comments
+// It only applies to array schemas
+func NewUniqueItemsParser() parsers.TagParser {
+	return base.NewSingleLineParser(
+		"UniqueItems",
+		parsers.RxUniqueItems,
+		[]parsers.ParseContext{parsers.ContextField},
+		parsers.SetterMap{
+			parsers.ContextField: func(target any, value any) error {
+				schema, ok := target.(*spec.Schema)
+				if !ok {
+					return &parsers.ErrInvalidTarget{
+						ParserName:   "UniqueItems",
+						Context:      parsers.ContextField,
+						ExpectedType: "*spec.Schema",
+						ActualType:   getTypeName(target),
+					}
+				}
+				uniqueStr, ok := value.(string)
+				if !ok {
+					return &parsers.ErrInvalidValue{
+						ParserName:   "UniqueItems",
+						ExpectedType: "string",
+						ActualType:   getTypeName(value),
+					}
+				}
+				if schema.Type == "array" {
+					schema.UniqueItems = parseBool(uniqueStr)
+				}
+				return nil
+			},
+		},
+	)
+}
+
 // NewPatternParser creates a Pattern parser for field comments
 func NewPatternParser() parsers.TagParser {
 	return base.NewSingleLineParser(
@@ -203,7 +437,13 @@ func NewPatternParser() parsers.TagParser {
 func init() {
 	parsers.Register("swagger:model", NewMinimumParser())
 	parsers.Register("swagger:model", NewMaximumParser())
+	parsers.Register("swagger:model", NewExclusiveMinimumParser())
+	parsers.Register("swagger:model", NewExclusiveMaximumParser())
+	parsers.Register("swagger:model", NewMultipleOfParser())
 	parsers.Register("swagger:model", NewMinLengthParser())
 	parsers.Register("swagger:model", NewMaxLengthParser())
 	parsers.Register("swagger:model", NewPatternParser())
+	parsers.Register("swagger:model", NewMinItemsParser())
+	parsers.Register("swagger:model", NewMaxItemsParser())
+	parsers.Register("swagger:model", NewUniqueItemsParser())
 }
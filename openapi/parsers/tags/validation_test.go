@@ -0,0 +1,128 @@
+package tags
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/reation-io/apikit/openapi/parsers"
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+func TestNumericValidationParsers_Field(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []string
+		wantJSON []string
+	}{
+		{
+			name:     "exclusive minimum",
+			comments: []string{"minimum: 0", "exclusiveMinimum: true"},
+			wantJSON: []string{`"minimum":0`, `"exclusiveMinimum":true`},
+		},
+		{
+			name:     "exclusive maximum",
+			comments: []string{"maximum: 100", "ExclusiveMaximum: yes"},
+			wantJSON: []string{`"maximum":100`, `"exclusiveMaximum":true`},
+		},
+		{
+			name:     "multiple of currency",
+			comments: []string{"multipleOf: 0.01"},
+			wantJSON: []string{`"multipleOf":0.01`},
+		},
+		{
+			name:     "multiple of integer",
+			comments: []string{"MultipleOf: 5"},
+			wantJSON: []string{`"multipleOf":5`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := &ast.CommentGroup{List: []*ast.Comment{{Text: "// Amount in dollars"}}}
+			for _, c := range tt.comments {
+				comments.List = append(comments.List, &ast.Comment{Text: "// " + c})
+			}
+
+			schema := &spec.Schema{Type: "number"}
+			if err := parsers.GlobalRegistry().Parse("swagger:model", comments, schema, parsers.ContextField); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			data, err := json.Marshal(schema)
+			if err != nil {
+				t.Fatalf("failed to marshal schema: %v", err)
+			}
+			for _, want := range tt.wantJSON {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("expected %s in JSON, got %s", want, data)
+				}
+			}
+		})
+	}
+}
+
+func TestMultipleOfParser_Invalid(t *testing.T) {
+	for _, value := range []string{"0", "-1", "abc"} {
+		t.Run(value, func(t *testing.T) {
+			comments := &ast.CommentGroup{List: []*ast.Comment{{Text: "// multipleOf: " + value}}}
+
+			schema := &spec.Schema{Type: "number"}
+			if err := parsers.GlobalRegistry().Parse("swagger:model", comments, schema, parsers.ContextField); err == nil {
+				t.Errorf("expected error for multipleOf %q", value)
+			}
+			if schema.MultipleOf != nil {
+				t.Errorf("expected multipleOf to be unset, got %v", *schema.MultipleOf)
+			}
+		})
+	}
+}
+
+func TestArrayValidationParsers_Field(t *testing.T) {
+	src := `
+package main
+
+type Pet struct {
+	// Tags of the pet
+	// minItems: 1
+	// maxItems: 10
+	// uniqueItems: true
+	Tags []string
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse file: %v", err)
+	}
+	structType := file.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type.(*ast.StructType)
+	comments := structType.Fields.List[0].Doc
+
+	schema := &spec.Schema{Type: "array", Items: &spec.Schema{Type: "string"}}
+	if err := parsers.GlobalRegistry().Parse("swagger:model", comments, schema, parsers.ContextField); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if schema.MinItems == nil || *schema.MinItems != 1 {
+		t.Errorf("expected minItems 1, got %v", schema.MinItems)
+	}
+	if schema.MaxItems == nil || *schema.MaxItems != 10 {
+		t.Errorf("expected maxItems 10, got %v", schema.MaxItems)
+	}
+	if !schema.UniqueItems {
+		t.Error("expected uniqueItems")
+	}
+
+	// Array constraints are ignored on other types
+	scalar := &spec.Schema{Type: "string"}
+	if err := parsers.GlobalRegistry().Parse("swagger:model", comments, scalar, parsers.ContextField); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if scalar.MinItems != nil || scalar.MaxItems != nil || scalar.UniqueItems {
+		t.Errorf("expected no array constraints on a string schema, got %+v", scalar)
+	}
+}
@@ -0,0 +1,258 @@
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Change describes a difference between two versions of a specification
+type Change struct {
+	// Breaking is true when clients of the old version may fail against the new one
+	Breaking bool
+
+	// Message describes the change and where it happened
+	Message string
+}
+
+func (c Change) String() string {
+	if c.Breaking {
+		return "breaking: " + c.Message
+	}
+	return c.Message
+}
+
+// Diff compares the specification with a newer version and reports the changes
+// that matter to API clients: added and removed paths and operations, parameters
+// becoming required, removed response codes and incompatible schema types
+// Changes are reported in path and method order, followed by component schemas
+func Diff(old, new *OpenAPI) []Change {
+	d := &specDiff{}
+
+	oldPaths, newPaths := specPathItems(old), specPathItems(new)
+	for _, path := range sortedUnion(oldPaths, newPaths) {
+		oldItem, newItem := oldPaths[path], newPaths[path]
+		switch {
+		case newItem == nil:
+			d.breaking("removed path %s", path)
+		case oldItem == nil:
+			d.compatible("added path %s", path)
+		default:
+			d.diffPathItem(path, oldItem, newItem)
+		}
+	}
+
+	oldSchemas, newSchemas := componentSchemas(old), componentSchemas(new)
+	for _, name := range slices.Sorted(maps.Keys(oldSchemas)) {
+		if newSchema, ok := newSchemas[name]; ok {
+			d.diffSchema("components.schemas."+name, oldSchemas[name], newSchema)
+		}
+	}
+
+	return d.changes
+}
+
+// HasBreakingChanges reports whether any of the changes is breaking
+func HasBreakingChanges(changes []Change) bool {
+	return slices.ContainsFunc(changes, func(c Change) bool { return c.Breaking })
+}
+
+// specDiff accumulates the changes found while comparing two specifications
+type specDiff struct {
+	changes []Change
+}
+
+func (d *specDiff) breaking(format string, args ...any) {
+	d.changes = append(d.changes, Change{Breaking: true, Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *specDiff) compatible(format string, args ...any) {
+	d.changes = append(d.changes, Change{Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *specDiff) diffPathItem(path string, oldItem, newItem *PathItem) {
+	oldOps, newOps := operationsByMethod(oldItem), operationsByMethod(newItem)
+	for _, entry := range pathOperations(oldItem) {
+		if newOps[entry.method] == nil {
+			d.breaking("removed operation %s %s", entry.method, path)
+		}
+	}
+	for _, entry := range pathOperations(newItem) {
+		location := entry.method + " " + path
+		oldOp := oldOps[entry.method]
+		if oldOp == nil {
+			d.compatible("added operation %s", location)
+			continue
+		}
+
+		// Path-level parameters apply to every operation of the path
+		oldParams := parametersByKey(oldItem.Parameters, oldOp.Parameters)
+		newParams := parametersByKey(newItem.Parameters, entry.operation.Parameters)
+		d.diffParameters(location, oldParams, newParams)
+		d.diffRequestBody(location, oldOp.RequestBody, entry.operation.RequestBody)
+		d.diffResponses(location, oldOp.Responses, entry.operation.Responses)
+	}
+}
+
+func (d *specDiff) diffParameters(location string, oldParams, newParams map[string]*Parameter) {
+	for _, key := range sortedUnion(oldParams, newParams) {
+		oldParam, newParam := oldParams[key], newParams[key]
+		switch {
+		case newParam == nil:
+			d.compatible("%s: removed %s", location, key)
+		case oldParam == nil && newParam.Required:
+			d.breaking("%s: added required %s", location, key)
+		case oldParam == nil:
+			d.compatible("%s: added optional %s", location, key)
+		default:
+			if newParam.Required && !oldParam.Required {
+				d.breaking("%s: %s became required", location, key)
+			} else if oldParam.Required && !newParam.Required {
+				d.compatible("%s: %s became optional", location, key)
+			}
+			d.diffSchema(location+" "+key, oldParam.Schema, newParam.Schema)
+		}
+	}
+}
+
+func (d *specDiff) diffRequestBody(location string, oldBody, newBody *RequestBody) {
+	switch {
+	case oldBody == nil && newBody == nil:
+		return
+	case oldBody == nil && newBody.Required:
+		d.breaking("%s: added required request body", location)
+	case oldBody == nil:
+		d.compatible("%s: added optional request body", location)
+	case newBody == nil:
+		d.compatible("%s: removed request body", location)
+	default:
+		if newBody.Required && !oldBody.Required {
+			d.breaking("%s: request body became required", location)
+		}
+		d.diffContent(location+" request body", oldBody.Content, newBody.Content)
+	}
+}
+
+func (d *specDiff) diffResponses(location string, oldResponses, newResponses *Responses) {
+	oldCodes, newCodes := responsesByCode(oldResponses), responsesByCode(newResponses)
+	for _, code := range sortedUnion(oldCodes, newCodes) {
+		oldResp, newResp := oldCodes[code], newCodes[code]
+		switch {
+		case newResp == nil:
+			d.breaking("%s: removed response %s", location, code)
+		case oldResp == nil:
+			d.compatible("%s: added response %s", location, code)
+		default:
+			d.diffContent(location+" response "+code, oldResp.Content, newResp.Content)
+		}
+	}
+}
+
+// diffContent compares the schemas of the media types present in both versions
+func (d *specDiff) diffContent(location string, oldContent, newContent map[string]*MediaType) {
+	for _, mediaType := range slices.Sorted(maps.Keys(oldContent)) {
+		oldMedia, newMedia := oldContent[mediaType], newContent[mediaType]
+		if oldMedia != nil && newMedia != nil {
+			d.diffSchema(location+" "+mediaType, oldMedia.Schema, newMedia.Schema)
+		}
+	}
+}
+
+// diffSchema reports incompatible type changes in a schema tree
+// References are compared by name; the referenced schemas are compared as components
+func (d *specDiff) diffSchema(location string, oldSchema, newSchema *Schema) {
+	if oldSchema == nil || newSchema == nil {
+		return
+	}
+
+	if oldSchema.Ref != newSchema.Ref {
+		d.breaking("%s: schema changed from %s to %s", location, schemaTypeName(oldSchema), schemaTypeName(newSchema))
+		return
+	}
+	if oldSchema.Type != newSchema.Type {
+		d.breaking("%s: type changed from %s to %s", location, schemaTypeName(oldSchema), schemaTypeName(newSchema))
+		return
+	}
+
+	d.diffSchema(location+"[]", oldSchema.Items, newSchema.Items)
+	for _, name := range slices.Sorted(maps.Keys(oldSchema.Properties)) {
+		if newProperty, ok := newSchema.Properties[name]; ok {
+			d.diffSchema(location+"."+name, oldSchema.Properties[name], newProperty)
+		}
+	}
+}
+
+// schemaTypeName describes a schema by its reference or type
+func schemaTypeName(s *Schema) string {
+	switch {
+	case s.Ref != "":
+		return s.Ref
+	case s.Type != "":
+		return s.Type
+	}
+	return "any"
+}
+
+// specPathItems returns the path items of a specification, tolerating missing paths
+func specPathItems(o *OpenAPI) map[string]*PathItem {
+	if o == nil || o.Paths == nil {
+		return nil
+	}
+	return o.Paths.PathItems
+}
+
+// componentSchemas returns the component schemas of a specification, tolerating missing components
+func componentSchemas(o *OpenAPI) map[string]*Schema {
+	if o == nil || o.Components == nil {
+		return nil
+	}
+	return o.Components.Schemas
+}
+
+// operationsByMethod indexes the operations of a path item by HTTP method
+func operationsByMethod(item *PathItem) map[string]*Operation {
+	ops := make(map[string]*Operation)
+	for _, entry := range pathOperations(item) {
+		ops[entry.method] = entry.operation
+	}
+	return ops
+}
+
+// parametersByKey indexes parameters by location and name (e.g., "query parameter limit")
+// Operation parameters override path-level parameters with the same key
+func parametersByKey(groups ...[]*Parameter) map[string]*Parameter {
+	params := make(map[string]*Parameter)
+	for _, group := range groups {
+		for _, param := range group {
+			if param != nil {
+				params[fmt.Sprintf("%s parameter %s", param.In, param.Name)] = param
+			}
+		}
+	}
+	return params
+}
+
+// responsesByCode indexes responses by status code, including "default"
+func responsesByCode(r *Responses) map[string]*Response {
+	codes := make(map[string]*Response)
+	if r == nil {
+		return codes
+	}
+	maps.Copy(codes, r.StatusCodeResponses)
+	if r.Default != nil {
+		codes["default"] = r.Default
+	}
+	return codes
+}
+
+// sortedUnion returns the keys present in either map, sorted
+func sortedUnion[V any](a, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,100 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+// diffTestSpec builds a spec with a single GET /pets operation
+func diffTestSpec(params []*Parameter, responseType string) *OpenAPI {
+	return &OpenAPI{
+		Paths: &Paths{PathItems: map[string]*PathItem{
+			"/pets": {
+				Get: &Operation{
+					Parameters: params,
+					Responses: &Responses{StatusCodeResponses: map[string]*Response{
+						"200": {Content: map[string]*MediaType{"application/json": {Schema: &Schema{Type: responseType}}}},
+						"404": {Description: "Not Found"},
+					}},
+				},
+			},
+		}},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	limit := &Parameter{Name: "limit", In: "query", Schema: &Schema{Type: "integer"}}
+	requiredLimit := &Parameter{Name: "limit", In: "query", Required: true, Schema: &Schema{Type: "integer"}}
+	stringLimit := &Parameter{Name: "limit", In: "query", Schema: &Schema{Type: "string"}}
+
+	tests := []struct {
+		name string
+		old  *OpenAPI
+		new  *OpenAPI
+		want []Change
+	}{
+		{
+			name: "no changes",
+			old:  diffTestSpec(nil, "array"),
+			new:  diffTestSpec(nil, "array"),
+		},
+		{
+			name: "removed endpoint",
+			old:  diffTestSpec(nil, "array"),
+			new:  &OpenAPI{Paths: &Paths{PathItems: map[string]*PathItem{}}},
+			want: []Change{{Breaking: true, Message: "removed path /pets"}},
+		},
+		{
+			name: "added optional parameter",
+			old:  diffTestSpec(nil, "array"),
+			new:  diffTestSpec([]*Parameter{limit}, "array"),
+			want: []Change{{Message: "GET /pets: added optional query parameter limit"}},
+		},
+		{
+			name: "parameter became required",
+			old:  diffTestSpec([]*Parameter{limit}, "array"),
+			new:  diffTestSpec([]*Parameter{requiredLimit}, "array"),
+			want: []Change{{Breaking: true, Message: "GET /pets: query parameter limit became required"}},
+		},
+		{
+			name: "parameter type changed",
+			old:  diffTestSpec([]*Parameter{limit}, "array"),
+			new:  diffTestSpec([]*Parameter{stringLimit}, "array"),
+			want: []Change{{Breaking: true, Message: "GET /pets query parameter limit: type changed from integer to string"}},
+		},
+		{
+			name: "response type changed",
+			old:  diffTestSpec(nil, "array"),
+			new:  diffTestSpec(nil, "object"),
+			want: []Change{{Breaking: true, Message: "GET /pets response 200 application/json: type changed from array to object"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.old, tt.new)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff_RemovedResponseCode(t *testing.T) {
+	old := diffTestSpec(nil, "array")
+	new := diffTestSpec(nil, "array")
+	delete(new.Paths.PathItems["/pets"].Get.Responses.StatusCodeResponses, "404")
+	new.Paths.PathItems["/pets"].Post = &Operation{}
+
+	got := Diff(old, new)
+	want := []Change{
+		{Breaking: true, Message: "GET /pets: removed response 404"},
+		{Message: "added operation POST /pets"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+	if !HasBreakingChanges(got) {
+		t.Error("expected breaking changes")
+	}
+}
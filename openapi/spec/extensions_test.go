@@ -0,0 +1,96 @@
+package spec
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExtensions_RoundTrip(t *testing.T) {
+	rateLimit := map[string]any{"limit": "100", "window": "1m"}
+
+	doc := &OpenAPI{
+		OpenAPI: "3.0.3",
+		Info: &Info{
+			Title:      "Pets",
+			Version:    "1.0.0",
+			Extensions: map[string]any{"x-logo": "logo.png", "x-specs": []string{"public"}},
+		},
+		Paths: &Paths{PathItems: map[string]*PathItem{
+			"/pets": {Get: &Operation{
+				OperationID: "listPets",
+				Responses:   &Responses{StatusCodeResponses: map[string]*Response{"200": {Description: "OK"}}},
+				Extensions:  map[string]any{"x-rate-limit": rateLimit, "x-specs": []string{"public"}},
+			}},
+		}},
+		Extensions: map[string]any{"x-audience": "external", "x-specs": []string{"public"}},
+	}
+
+	codecs := []struct {
+		name      string
+		marshal   func(any) ([]byte, error)
+		unmarshal func([]byte, any) error
+	}{
+		{name: "json", marshal: json.Marshal, unmarshal: json.Unmarshal},
+		{name: "yaml", marshal: yaml.Marshal, unmarshal: yaml.Unmarshal},
+	}
+
+	for _, codec := range codecs {
+		t.Run(codec.name, func(t *testing.T) {
+			data, err := codec.marshal(doc)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if strings.Contains(string(data), "x-specs") {
+				t.Errorf("internal x-specs extension leaked into output:\n%s", data)
+			}
+
+			var got OpenAPI
+			if err := codec.unmarshal(data, &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if want := map[string]any{"x-audience": "external"}; !reflect.DeepEqual(got.Extensions, want) {
+				t.Errorf("OpenAPI.Extensions = %#v, want %#v", got.Extensions, want)
+			}
+			if got.Info == nil || got.Info.Title != "Pets" {
+				t.Fatalf("Info = %#v, want title Pets", got.Info)
+			}
+			if want := map[string]any{"x-logo": "logo.png"}; !reflect.DeepEqual(got.Info.Extensions, want) {
+				t.Errorf("Info.Extensions = %#v, want %#v", got.Info.Extensions, want)
+			}
+
+			op := got.Paths.PathItems["/pets"].Get
+			if op == nil || op.OperationID != "listPets" {
+				t.Fatalf("GET /pets = %#v, want operationId listPets", op)
+			}
+			if want := map[string]any{"x-rate-limit": rateLimit}; !reflect.DeepEqual(op.Extensions, want) {
+				t.Errorf("Operation.Extensions = %#v, want %#v", op.Extensions, want)
+			}
+			if op.Responses == nil || op.Responses.StatusCodeResponses["200"] == nil {
+				t.Errorf("Responses = %#v, want a 200 response", op.Responses)
+			}
+		})
+	}
+}
+
+func TestExtensions_MarshalJSONOrder(t *testing.T) {
+	info := &Info{
+		Title:      "Pets",
+		Version:    "1.0.0",
+		Extensions: map[string]any{"x-b": 2, "x-a": 1, "custom": true},
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	want := `{"title":"Pets","version":"1.0.0","x-a":1,"x-b":2}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
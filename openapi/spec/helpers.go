@@ -1,6 +1,22 @@
 package spec
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// internalExtensions lists extension keys used internally by the builder
+// that must never be written to or read from a document
+// Example: x-consumes and x-produces hold the swagger:meta Consumes/Produces defaults
+var internalExtensions = map[string]bool{
+	"x-specs":    true,
+	"x-consumes": true,
+	"x-produces": true,
+}
 
 // marshalMap is a helper function to marshal a map to JSON
 func marshalMap(m any) ([]byte, error) {
@@ -11,3 +27,115 @@ func marshalMap(m any) ([]byte, error) {
 func unmarshalMap(data []byte, m any) error {
 	return json.Unmarshal(data, m)
 }
+
+// isExtensionKey reports whether key is a public x-* extension
+func isExtensionKey(key string) bool {
+	return strings.HasPrefix(key, "x-") && !internalExtensions[key]
+}
+
+// extensionKeys returns the public extension keys of ext in sorted order
+func extensionKeys(ext map[string]any) []string {
+	keys := make([]string, 0, len(ext))
+	for k := range ext {
+		if isExtensionKey(k) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// marshalWithExtensions marshals v to a JSON object and appends the public
+// extensions after the standard fields, preserving their order
+func marshalWithExtensions(v any, ext map[string]any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	keys := extensionKeys(ext)
+	if len(keys) == 0 {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimSuffix(data, []byte("}")))
+	for i, k := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		value, err := json.Marshal(ext[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalYAMLWithExtensions encodes v as a YAML mapping and appends the
+// public extensions after the standard fields
+func marshalYAMLWithExtensions(v any, ext map[string]any) (any, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return nil, err
+	}
+	for _, k := range extensionKeys(ext) {
+		var value yaml.Node
+		if err := value.Encode(ext[k]); err != nil {
+			return nil, err
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}
+		node.Content = append(node.Content, key, &value)
+	}
+	return &node, nil
+}
+
+// unmarshalExtensions collects the public extensions of a JSON object
+func unmarshalExtensions(data []byte) (map[string]any, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	var ext map[string]any
+	for k, v := range raw {
+		if !isExtensionKey(k) {
+			continue
+		}
+		var value any
+		if err := json.Unmarshal(v, &value); err != nil {
+			return nil, err
+		}
+		if ext == nil {
+			ext = make(map[string]any)
+		}
+		ext[k] = value
+	}
+	return ext, nil
+}
+
+// unmarshalYAMLExtensions collects the public extensions of a YAML mapping
+func unmarshalYAMLExtensions(node *yaml.Node) (map[string]any, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	var ext map[string]any
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k := node.Content[i].Value
+		if !isExtensionKey(k) {
+			continue
+		}
+		var value any
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return nil, err
+		}
+		if ext == nil {
+			ext = make(map[string]any)
+		}
+		ext[k] = value
+	}
+	return ext, nil
+}
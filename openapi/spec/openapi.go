@@ -1,6 +1,11 @@
 package spec
 
-import "gopkg.in/yaml.v3"
+import (
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
 
 // OpenAPI representa la estructura raíz de una especificación OpenAPI 3.0
 type OpenAPI struct {
@@ -15,6 +20,45 @@ type OpenAPI struct {
 	Extensions   map[string]any        `json:"-" yaml:"-"` // Extensions for custom properties
 }
 
+// openAPIAlias evita la recursión al serializar OpenAPI
+type openAPIAlias OpenAPI
+
+// MarshalJSON implementa json.Marshaler
+func (o *OpenAPI) MarshalJSON() ([]byte, error) {
+	return marshalWithExtensions((*openAPIAlias)(o), o.Extensions)
+}
+
+// MarshalYAML implementa yaml.Marshaler
+func (o *OpenAPI) MarshalYAML() (any, error) {
+	return marshalYAMLWithExtensions((*openAPIAlias)(o), o.Extensions)
+}
+
+// UnmarshalJSON implementa json.Unmarshaler
+func (o *OpenAPI) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*openAPIAlias)(o)); err != nil {
+		return err
+	}
+	ext, err := unmarshalExtensions(data)
+	if err != nil {
+		return err
+	}
+	o.Extensions = ext
+	return nil
+}
+
+// UnmarshalYAML implementa yaml.Unmarshaler
+func (o *OpenAPI) UnmarshalYAML(node *yaml.Node) error {
+	if err := node.Decode((*openAPIAlias)(o)); err != nil {
+		return err
+	}
+	ext, err := unmarshalYAMLExtensions(node)
+	if err != nil {
+		return err
+	}
+	o.Extensions = ext
+	return nil
+}
+
 // Info contiene metadata sobre la API
 type Info struct {
 	Title          string         `json:"title" yaml:"title"`
@@ -26,6 +70,45 @@ type Info struct {
 	Extensions     map[string]any `json:"-" yaml:"-"` // Extensions for custom properties
 }
 
+// infoAlias evita la recursión al serializar Info
+type infoAlias Info
+
+// MarshalJSON implementa json.Marshaler
+func (i *Info) MarshalJSON() ([]byte, error) {
+	return marshalWithExtensions((*infoAlias)(i), i.Extensions)
+}
+
+// MarshalYAML implementa yaml.Marshaler
+func (i *Info) MarshalYAML() (any, error) {
+	return marshalYAMLWithExtensions((*infoAlias)(i), i.Extensions)
+}
+
+// UnmarshalJSON implementa json.Unmarshaler
+func (i *Info) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*infoAlias)(i)); err != nil {
+		return err
+	}
+	ext, err := unmarshalExtensions(data)
+	if err != nil {
+		return err
+	}
+	i.Extensions = ext
+	return nil
+}
+
+// UnmarshalYAML implementa yaml.Unmarshaler
+func (i *Info) UnmarshalYAML(node *yaml.Node) error {
+	if err := node.Decode((*infoAlias)(i)); err != nil {
+		return err
+	}
+	ext, err := unmarshalYAMLExtensions(node)
+	if err != nil {
+		return err
+	}
+	i.Extensions = ext
+	return nil
+}
+
 // Contact contiene información de contacto
 type Contact struct {
 	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
@@ -97,6 +180,30 @@ type PathItem struct {
 	Parameters  []*Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 }
 
+// SetOperation assigns the operation to the field of the HTTP method
+// Unknown methods are ignored
+// Example: SetOperation("get", op) sets p.Get
+func (p *PathItem) SetOperation(method string, operation *Operation) {
+	switch strings.ToUpper(method) {
+	case "GET":
+		p.Get = operation
+	case "POST":
+		p.Post = operation
+	case "PUT":
+		p.Put = operation
+	case "DELETE":
+		p.Delete = operation
+	case "PATCH":
+		p.Patch = operation
+	case "OPTIONS":
+		p.Options = operation
+	case "HEAD":
+		p.Head = operation
+	case "TRACE":
+		p.Trace = operation
+	}
+}
+
 // Tag agrupa operaciones
 type Tag struct {
 	Name         string        `json:"name" yaml:"name"`
@@ -1,5 +1,11 @@
 package spec
 
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Operation describe una operación en un path
 type Operation struct {
 	Tags         []string              `json:"tags,omitempty" yaml:"tags,omitempty"`
@@ -17,6 +23,45 @@ type Operation struct {
 	Extensions   map[string]any        `json:"-" yaml:"-"` // Extensions for custom properties
 }
 
+// operationAlias evita la recursión al serializar Operation
+type operationAlias Operation
+
+// MarshalJSON implementa json.Marshaler
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	return marshalWithExtensions((*operationAlias)(o), o.Extensions)
+}
+
+// MarshalYAML implementa yaml.Marshaler
+func (o *Operation) MarshalYAML() (any, error) {
+	return marshalYAMLWithExtensions((*operationAlias)(o), o.Extensions)
+}
+
+// UnmarshalJSON implementa json.Unmarshaler
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*operationAlias)(o)); err != nil {
+		return err
+	}
+	ext, err := unmarshalExtensions(data)
+	if err != nil {
+		return err
+	}
+	o.Extensions = ext
+	return nil
+}
+
+// UnmarshalYAML implementa yaml.Unmarshaler
+func (o *Operation) UnmarshalYAML(node *yaml.Node) error {
+	if err := node.Decode((*operationAlias)(o)); err != nil {
+		return err
+	}
+	ext, err := unmarshalYAMLExtensions(node)
+	if err != nil {
+		return err
+	}
+	o.Extensions = ext
+	return nil
+}
+
 // Parameter describe un parámetro de operación
 type Parameter struct {
 	Name            string              `json:"name" yaml:"name"`
@@ -25,6 +70,8 @@ type Parameter struct {
 	Required        bool                `json:"required,omitempty" yaml:"required,omitempty"`
 	Deprecated      bool                `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 	AllowEmptyValue bool                `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
+	Style           string              `json:"style,omitempty" yaml:"style,omitempty"`
+	Explode         *bool               `json:"explode,omitempty" yaml:"explode,omitempty"`
 	Schema          *Schema             `json:"schema,omitempty" yaml:"schema,omitempty"`
 	Example         any                 `json:"example,omitempty" yaml:"example,omitempty"`
 	Examples        map[string]*Example `json:"examples,omitempty" yaml:"examples,omitempty"`
@@ -103,6 +150,33 @@ func (r *Responses) MarshalYAML() (any, error) {
 	return m, nil
 }
 
+// UnmarshalJSON implementa json.Unmarshaler
+func (r *Responses) UnmarshalJSON(data []byte) error {
+	m := make(map[string]*Response)
+	if err := unmarshalMap(data, &m); err != nil {
+		return err
+	}
+	r.setResponses(m)
+	return nil
+}
+
+// UnmarshalYAML implementa yaml.Unmarshaler
+func (r *Responses) UnmarshalYAML(node *yaml.Node) error {
+	m := make(map[string]*Response)
+	if err := node.Decode(&m); err != nil {
+		return err
+	}
+	r.setResponses(m)
+	return nil
+}
+
+// setResponses separa la respuesta default de las respuestas por código de estado
+func (r *Responses) setResponses(m map[string]*Response) {
+	r.Default = m["default"]
+	delete(m, "default")
+	r.StatusCodeResponses = m
+}
+
 // Response describe una respuesta
 type Response struct {
 	Description string                `json:"description" yaml:"description"`
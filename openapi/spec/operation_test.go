@@ -0,0 +1,98 @@
+package spec
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestResponses_RoundTrip(t *testing.T) {
+	responses := &Responses{
+		Default: &Response{
+			Description: "Error",
+			Content: map[string]*MediaType{
+				"application/json": {Schema: &Schema{Ref: "#/components/schemas/Error"}},
+			},
+		},
+		StatusCodeResponses: map[string]*Response{
+			"200": {
+				Description: "OK",
+				Headers: map[string]*Header{
+					"X-Total-Count": {Schema: &Schema{Type: "integer"}},
+				},
+				Content: map[string]*MediaType{
+					"application/json": {Schema: &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/Pet"}}},
+				},
+			},
+			"404": {Description: "Not Found"},
+		},
+	}
+
+	codecs := []struct {
+		name      string
+		marshal   func(any) ([]byte, error)
+		unmarshal func([]byte, any) error
+	}{
+		{name: "json", marshal: json.Marshal, unmarshal: json.Unmarshal},
+		{name: "yaml", marshal: yaml.Marshal, unmarshal: yaml.Unmarshal},
+	}
+
+	for _, codec := range codecs {
+		t.Run(codec.name, func(t *testing.T) {
+			data, err := codec.marshal(responses)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			var got Responses
+			if err := codec.unmarshal(data, &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if _, ok := got.StatusCodeResponses["default"]; ok {
+				t.Error("expected default to be split from the status code responses")
+			}
+			if !reflect.DeepEqual(&got, responses) {
+				t.Errorf("round trip mismatch:\ngot  %+v\nwant %+v\n%s", got, *responses, data)
+			}
+		})
+	}
+}
+
+func TestOperation_RoundTripYAML(t *testing.T) {
+	op := &Operation{
+		OperationID: "getPet",
+		Responses: &Responses{
+			StatusCodeResponses: map[string]*Response{"200": {Description: "OK"}},
+			Default:             &Response{Description: "Error"},
+		},
+	}
+
+	data, err := yaml.Marshal(op)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var got Operation
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Responses == nil || got.Responses.Default == nil || got.Responses.StatusCodeResponses["200"] == nil {
+		t.Fatalf("expected responses to survive the round trip, got %+v from\n%s", got.Responses, data)
+	}
+}
+
+func TestOperationIDs_Duplicates(t *testing.T) {
+	ids := NewOperationIDs()
+	ids.Add("listPets", "GET /pets")
+	ids.Add("", "GET /health")
+	ids.Add("getPet", "GET /pets/{id}")
+	ids.Add("listPets", "GET /animals")
+
+	want := []string{`duplicate operationId "listPets": GET /pets, GET /animals`}
+	if got := ids.Duplicates(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Duplicates() = %v, want %v", got, want)
+	}
+}
@@ -1,5 +1,13 @@
 package spec
 
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Schema represents a JSON Schema (OpenAPI 3.0)
 type Schema struct {
 	// Core schema properties
@@ -31,6 +39,10 @@ type Schema struct {
 	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
 	AdditionalProperties any                `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 
+	// PropertyOrder lists property names in the order they are serialized
+	// (e.g., struct declaration order); properties missing from it follow, sorted by name
+	PropertyOrder []string `json:"-" yaml:"-"`
+
 	// Array properties
 	Items *Schema `json:"items,omitempty" yaml:"items,omitempty"`
 
@@ -51,6 +63,114 @@ type Schema struct {
 	XML        *XML `json:"xml,omitempty" yaml:"xml,omitempty"`
 }
 
+// schemaAlias avoids recursion when marshaling Schema
+type schemaAlias Schema
+
+// MarshalJSON implements json.Marshaler, writing properties in PropertyOrder
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if len(s.PropertyOrder) == 0 || len(s.Properties) == 0 {
+		return json.Marshal((*schemaAlias)(s))
+	}
+
+	// Marshal the other fields, then append the ordered properties
+	alias := schemaAlias(*s)
+	alias.Properties = nil
+	data, err := json.Marshal(&alias)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimSuffix(data, []byte("}")))
+	if len(data) > 2 {
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"properties":{`)
+	for i, name := range s.orderedPropertyNames() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		value, err := json.Marshal(s.Properties[name])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteString("}}")
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML implements yaml.Marshaler, writing properties in PropertyOrder
+func (s *Schema) MarshalYAML() (any, error) {
+	if len(s.PropertyOrder) == 0 || len(s.Properties) == 0 {
+		return (*schemaAlias)(s), nil
+	}
+
+	alias := schemaAlias(*s)
+	alias.Properties = nil
+	var node yaml.Node
+	if err := node.Encode(&alias); err != nil {
+		return nil, err
+	}
+
+	properties := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, name := range s.orderedPropertyNames() {
+		var value yaml.Node
+		if err := value.Encode(s.Properties[name]); err != nil {
+			return nil, err
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}
+		properties.Content = append(properties.Content, key, &value)
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "properties"}
+	node.Content = append(node.Content, key, properties)
+	return &node, nil
+}
+
+// PromoteProperties copies the properties of an embedded struct's schema that s doesn't define yet,
+// keeping their order
+// Fields promoted through a nil *Base are omitted from JSON, so they aren't required
+func (s *Schema) PromoteProperties(embedded *Schema, isPointer bool) {
+	if s.Properties == nil {
+		s.Properties = make(map[string]*Schema)
+	}
+	for _, name := range embedded.orderedPropertyNames() {
+		if _, ok := s.Properties[name]; ok {
+			continue
+		}
+		s.Properties[name] = embedded.Properties[name]
+		s.PropertyOrder = append(s.PropertyOrder, name)
+		if !isPointer && slices.Contains(embedded.Required, name) {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// orderedPropertyNames returns the names in PropertyOrder that are properties,
+// followed by the remaining property names sorted
+func (s *Schema) orderedPropertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	seen := make(map[string]bool, len(s.Properties))
+	for _, name := range s.PropertyOrder {
+		if _, ok := s.Properties[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
+	for name := range s.Properties {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	slices.Sort(rest)
+	return append(names, rest...)
+}
+
 // XML represents XML metadata
 type XML struct {
 	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
@@ -0,0 +1,113 @@
+package spec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema_PropertyOrder(t *testing.T) {
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"name":    {Type: "string"},
+			"id":      {Type: "integer"},
+			"tags":    {Type: "array", Items: &Schema{Type: "string"}},
+			"created": {Type: "string", Format: "date-time"},
+		},
+		PropertyOrder: []string{"name", "id", "tags"},
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	want := `{"type":"object","properties":{"name":{"type":"string"},"id":{"type":"integer"},"tags":{"type":"array","items":{"type":"string"}},"created":{"type":"string","format":"date-time"}}}`
+	if string(data) != want {
+		t.Errorf("JSON = %s\nwant   %s", data, want)
+	}
+
+	out, err := yaml.Marshal(schema)
+	if err != nil {
+		t.Fatalf("yaml.Marshal failed: %v", err)
+	}
+	assertOrder(t, string(out), "name:", "id:", "tags:", "created:")
+
+	// The order only affects serialization
+	var decoded Schema
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if len(decoded.Properties) != 4 {
+		t.Errorf("expected 4 properties after round trip, got %d", len(decoded.Properties))
+	}
+}
+
+func TestSchema_NoPropertyOrder(t *testing.T) {
+	schema := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{"b": {Type: "string"}, "a": {Type: "string"}},
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	want := `{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"string"}}}`
+	if string(data) != want {
+		t.Errorf("JSON = %s\nwant   %s", data, want)
+	}
+}
+
+// assertOrder checks that the substrings appear in s in the given order
+func assertOrder(t *testing.T, s string, substrings ...string) {
+	t.Helper()
+
+	last := -1
+	for _, sub := range substrings {
+		idx := strings.Index(s, sub)
+		if idx <= last {
+			t.Errorf("expected %q after the previous keys in:\n%s", sub, s)
+			return
+		}
+		last = idx
+	}
+}
+
+func TestSchema_PromoteProperties(t *testing.T) {
+	embedded := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"id":   {Type: "integer"},
+			"name": {Type: "string"},
+		},
+		PropertyOrder: []string{"id", "name"},
+		Required:      []string{"id", "name"},
+	}
+
+	schema := &Schema{
+		Type:          "object",
+		Properties:    map[string]*Schema{"name": {Type: "integer"}},
+		PropertyOrder: []string{"name"},
+	}
+	schema.PromoteProperties(embedded, false)
+
+	if got := strings.Join(schema.PropertyOrder, ","); got != "name,id" {
+		t.Errorf("property order = %s, want name,id", got)
+	}
+	if schema.Properties["name"].Type != "integer" {
+		t.Error("expected the existing name property to take precedence")
+	}
+	if got := strings.Join(schema.Required, ","); got != "id" {
+		t.Errorf("required = %s, want id", got)
+	}
+
+	// Pointer embeds may be nil, so their fields aren't required
+	pointer := &Schema{Type: "object"}
+	pointer.PromoteProperties(embedded, true)
+	if len(pointer.Properties) != 2 || len(pointer.Required) != 0 {
+		t.Errorf("expected 2 optional properties, got %v required of %d", pointer.Required, len(pointer.Properties))
+	}
+}
@@ -0,0 +1,292 @@
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// schemaRefPrefix is the prefix of references to component schemas
+const schemaRefPrefix = "#/components/schemas/"
+
+// ValidationError lists the problems found while validating a specification
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid OpenAPI spec: %s", strings.Join(e.Problems, "; "))
+}
+
+// Validate checks the specification for structural problems:
+// missing required fields, operations without responses, duplicate
+// operationIds and references to schemas that don't exist in components
+// Returns a *ValidationError listing every problem found, or nil
+func (o *OpenAPI) Validate() error {
+	v := &specValidator{spec: o, seen: make(map[string]bool)}
+	v.validate()
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+// specValidator accumulates validation problems
+type specValidator struct {
+	spec     *OpenAPI
+	problems []string
+	seen     map[string]bool
+}
+
+// addf records a problem, ignoring repeats of the same problem
+func (v *specValidator) addf(format string, args ...any) {
+	problem := fmt.Sprintf(format, args...)
+	if v.seen[problem] {
+		return
+	}
+	v.seen[problem] = true
+	v.problems = append(v.problems, problem)
+}
+
+func (v *specValidator) validate() {
+	if v.spec.OpenAPI == "" {
+		v.addf("missing openapi version")
+	}
+	if v.spec.Info == nil {
+		v.addf("missing info")
+	} else {
+		if v.spec.Info.Title == "" {
+			v.addf("missing info.title")
+		}
+		if v.spec.Info.Version == "" {
+			v.addf("missing info.version")
+		}
+	}
+
+	if v.spec.Components != nil {
+		v.validateComponents(v.spec.Components)
+	}
+
+	if v.spec.Paths == nil {
+		v.addf("missing paths")
+		return
+	}
+
+	operationIDs := NewOperationIDs()
+
+	for _, path := range slices.Sorted(maps.Keys(v.spec.Paths.PathItems)) {
+		item := v.spec.Paths.PathItems[path]
+		if item == nil {
+			continue
+		}
+
+		for _, param := range item.Parameters {
+			v.validateParameter(param, path)
+		}
+
+		for _, entry := range pathOperations(item) {
+			location := entry.method + " " + path
+			op := entry.operation
+
+			operationIDs.Add(op.OperationID, location)
+			v.validateOperation(op, location)
+		}
+	}
+
+	for _, duplicate := range operationIDs.Duplicates() {
+		v.addf("%s", duplicate)
+	}
+}
+
+// OperationIDs records the operations using each operationId, in order of first use,
+// so that operationIds shared by several operations can be reported
+type OperationIDs struct {
+	locations map[string][]string
+	order     []string
+}
+
+// NewOperationIDs creates an empty operationId index
+func NewOperationIDs() *OperationIDs {
+	return &OperationIDs{locations: make(map[string][]string)}
+}
+
+// Add records the location of an operation using an operationId
+// Operations without an operationId are ignored
+// Example: Add("listPets", "GET /pets")
+func (x *OperationIDs) Add(id, location string) {
+	if id == "" {
+		return
+	}
+	if _, seen := x.locations[id]; !seen {
+		x.order = append(x.order, id)
+	}
+	x.locations[id] = append(x.locations[id], location)
+}
+
+// Duplicates describes every operationId used by more than one operation
+// Example: duplicate operationId "listPets": GET /pets, GET /animals
+func (x *OperationIDs) Duplicates() []string {
+	var duplicates []string
+	for _, id := range x.order {
+		if locations := x.locations[id]; len(locations) > 1 {
+			duplicates = append(duplicates, fmt.Sprintf("duplicate operationId %q: %s", id, strings.Join(locations, ", ")))
+		}
+	}
+	return duplicates
+}
+
+// operationEntry pairs an operation with its HTTP method
+type operationEntry struct {
+	method    string
+	operation *Operation
+}
+
+// pathOperations returns the operations of a path item in a fixed method order
+func pathOperations(item *PathItem) []operationEntry {
+	all := []operationEntry{
+		{"GET", item.Get},
+		{"PUT", item.Put},
+		{"POST", item.Post},
+		{"DELETE", item.Delete},
+		{"OPTIONS", item.Options},
+		{"HEAD", item.Head},
+		{"PATCH", item.Patch},
+		{"TRACE", item.Trace},
+	}
+
+	var entries []operationEntry
+	for _, entry := range all {
+		if entry.operation != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func (v *specValidator) validateComponents(c *Components) {
+	for _, name := range slices.Sorted(maps.Keys(c.Schemas)) {
+		v.validateSchema(c.Schemas[name], "components.schemas."+name)
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Responses)) {
+		v.validateResponse(c.Responses[name], "components.responses."+name)
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Parameters)) {
+		v.validateParameter(c.Parameters[name], "components.parameters."+name)
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.RequestBodies)) {
+		if body := c.RequestBodies[name]; body != nil {
+			v.validateContent(body.Content, "components.requestBodies."+name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Headers)) {
+		if header := c.Headers[name]; header != nil {
+			v.validateSchema(header.Schema, "components.headers."+name)
+		}
+	}
+}
+
+func (v *specValidator) validateOperation(op *Operation, location string) {
+	for _, param := range op.Parameters {
+		v.validateParameter(param, location)
+	}
+
+	if op.RequestBody != nil {
+		v.validateContent(op.RequestBody.Content, location+" request body")
+	}
+
+	if op.Responses == nil || (op.Responses.Default == nil && len(op.Responses.StatusCodeResponses) == 0) {
+		v.addf("%s: operation has no responses", location)
+		return
+	}
+
+	if op.Responses.Default != nil {
+		v.validateResponse(op.Responses.Default, location+" response default")
+	}
+	for _, code := range slices.Sorted(maps.Keys(op.Responses.StatusCodeResponses)) {
+		v.validateResponse(op.Responses.StatusCodeResponses[code], location+" response "+code)
+	}
+}
+
+func (v *specValidator) validateParameter(param *Parameter, location string) {
+	if param == nil {
+		return
+	}
+	if param.Name == "" {
+		v.addf("%s: parameter is missing a name", location)
+	}
+	if param.In == "" {
+		v.addf("%s: parameter %q is missing \"in\"", location, param.Name)
+	}
+	v.validateSchema(param.Schema, location+" parameter "+param.Name)
+}
+
+func (v *specValidator) validateResponse(resp *Response, location string) {
+	if resp == nil {
+		return
+	}
+	if resp.Description == "" {
+		v.addf("%s: response is missing a description", location)
+	}
+	for _, name := range slices.Sorted(maps.Keys(resp.Headers)) {
+		if header := resp.Headers[name]; header != nil {
+			v.validateSchema(header.Schema, location+" header "+name)
+		}
+	}
+	v.validateContent(resp.Content, location)
+}
+
+func (v *specValidator) validateContent(content map[string]*MediaType, location string) {
+	for _, mediaType := range slices.Sorted(maps.Keys(content)) {
+		if media := content[mediaType]; media != nil {
+			v.validateSchema(media.Schema, location)
+		}
+	}
+}
+
+// validateSchema checks that every $ref in the schema tree points to an existing component schema
+func (v *specValidator) validateSchema(schema *Schema, location string) {
+	if schema == nil {
+		return
+	}
+
+	v.validateRef(schema.Ref, location)
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		v.validateSchema(schema.Properties[name], location)
+	}
+	v.validateSchema(schema.Items, location)
+	v.validateSchema(schema.Not, location)
+	for _, group := range [][]*Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, s := range group {
+			v.validateSchema(s, location)
+		}
+	}
+
+	// additionalProperties is either a bool or a schema
+	switch additional := schema.AdditionalProperties.(type) {
+	case *Schema:
+		v.validateSchema(additional, location)
+	case map[string]any:
+		if ref, ok := additional["$ref"].(string); ok {
+			v.validateRef(ref, location)
+		}
+	}
+}
+
+// validateRef reports references to component schemas that don't exist
+// External references are not resolved
+func (v *specValidator) validateRef(ref, location string) {
+	name, ok := strings.CutPrefix(ref, schemaRefPrefix)
+	if !ok {
+		return
+	}
+
+	if v.spec.Components != nil {
+		if _, exists := v.spec.Components.Schemas[name]; exists {
+			return
+		}
+	}
+	v.addf("%s: reference %s not found", location, ref)
+}
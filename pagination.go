@@ -0,0 +1,100 @@
+package apikit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Query parameters used in the pagination links written by WritePaginated
+const (
+	PageParam    = "page"
+	PerPageParam = "per_page"
+)
+
+// Page is a single page of a paginated list
+// Page numbers start at 1
+type Page[T any] struct {
+	Items   []T `json:"items"`
+	Total   int `json:"total"`
+	Page    int `json:"page"`
+	PerPage int `json:"perPage"`
+}
+
+// NewPage creates a page of items out of total results
+// A page number below 1 is treated as the first page
+func NewPage[T any](items []T, total, page, perPage int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{
+		Items:   items,
+		Total:   total,
+		Page:    max(page, 1),
+		PerPage: perPage,
+	}
+}
+
+// TotalPages returns the number of pages, which is at least 1 even for an empty result set
+func (p Page[T]) TotalPages() int {
+	if p.PerPage <= 0 || p.Total <= 0 {
+		return 1
+	}
+	return (p.Total + p.PerPage - 1) / p.PerPage
+}
+
+// HasPrev reports whether there's a page before this one
+func (p Page[T]) HasPrev() bool {
+	return p.Page > 1
+}
+
+// HasNext reports whether there's a page after this one
+func (p Page[T]) HasNext() bool {
+	return p.Page < p.TotalPages()
+}
+
+// LinkHeader builds an RFC 5988 Link header for the page, with first, prev,
+// next and last relations
+// Links reuse the request path and query, replacing the page and per_page parameters
+// Example: `</items?page=1&per_page=10>; rel="first", </items?page=3&per_page=10>; rel="last"`
+func (p Page[T]) LinkHeader(u *url.URL) string {
+	if p.PerPage <= 0 {
+		return ""
+	}
+
+	link := func(page int, rel string) string {
+		query := u.Query()
+		query.Set(PageParam, strconv.Itoa(page))
+		query.Set(PerPageParam, strconv.Itoa(p.PerPage))
+		target := url.URL{Path: u.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf("<%s>; rel=%q", target.String(), rel)
+	}
+
+	last := p.TotalPages()
+	links := []string{link(1, "first")}
+	if p.HasPrev() {
+		links = append(links, link(min(p.Page-1, last), "prev"))
+	}
+	if p.HasNext() {
+		links = append(links, link(p.Page+1, "next"))
+	}
+	links = append(links, link(last, "last"))
+
+	return strings.Join(links, ", ")
+}
+
+// WritePaginated writes a page of items as a JSON array
+// It sets X-Total-Count to the total number of results and a Link header
+// pointing to the first, previous, next and last pages of the request URL
+func WritePaginated[T any](w http.ResponseWriter, r *http.Request, items []T, total, page, perPage int) {
+	p := NewPage(items, total, page, perPage)
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	if link := p.LinkHeader(r.URL); link != "" {
+		w.Header().Set("Link", link)
+	}
+
+	WriteJSON(w, p.Items)
+}
@@ -0,0 +1,97 @@
+package apikit
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWritePaginated_Links(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		page int
+		want string
+	}{
+		{
+			name: "first page",
+			url:  "/items?status=open",
+			page: 1,
+			want: `</items?page=1&per_page=10&status=open>; rel="first", ` +
+				`</items?page=2&per_page=10&status=open>; rel="next", ` +
+				`</items?page=3&per_page=10&status=open>; rel="last"`,
+		},
+		{
+			name: "middle page",
+			url:  "/items?page=2&per_page=10",
+			page: 2,
+			want: `</items?page=1&per_page=10>; rel="first", ` +
+				`</items?page=1&per_page=10>; rel="prev", ` +
+				`</items?page=3&per_page=10>; rel="next", ` +
+				`</items?page=3&per_page=10>; rel="last"`,
+		},
+		{
+			name: "last page",
+			url:  "/items?page=3&per_page=10",
+			page: 3,
+			want: `</items?page=1&per_page=10>; rel="first", ` +
+				`</items?page=2&per_page=10>; rel="prev", ` +
+				`</items?page=3&per_page=10>; rel="last"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", tt.url, nil)
+
+			WritePaginated(w, r, []string{"a", "b"}, 25, tt.page, 10)
+
+			if got := w.Header().Get("Link"); got != tt.want {
+				t.Errorf("Link header:\ngot  %s\nwant %s", got, tt.want)
+			}
+			if got := w.Header().Get("X-Total-Count"); got != "25" {
+				t.Errorf("expected X-Total-Count 25, got %q", got)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != `["a","b"]` {
+				t.Errorf("expected items as JSON array, got %s", got)
+			}
+		})
+	}
+}
+
+func TestWritePaginated_Empty(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/items", nil)
+
+	WritePaginated[string](w, r, nil, 0, 1, 20)
+
+	want := `</items?page=1&per_page=20>; rel="first", </items?page=1&per_page=20>; rel="last"`
+	if got := w.Header().Get("Link"); got != want {
+		t.Errorf("Link header:\ngot  %s\nwant %s", got, want)
+	}
+	if got := w.Header().Get("X-Total-Count"); got != "0" {
+		t.Errorf("expected X-Total-Count 0, got %q", got)
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != "[]" {
+		t.Errorf("expected empty JSON array, got %s", got)
+	}
+}
+
+func TestPage_TotalPages(t *testing.T) {
+	tests := []struct {
+		total, perPage, want int
+	}{
+		{total: 0, perPage: 10, want: 1},
+		{total: 10, perPage: 10, want: 1},
+		{total: 11, perPage: 10, want: 2},
+		{total: 5, perPage: 0, want: 1},
+	}
+
+	for _, tt := range tests {
+		p := NewPage([]int{}, tt.total, 1, tt.perPage)
+		if got := p.TotalPages(); got != tt.want {
+			t.Errorf("TotalPages(total=%d, perPage=%d) = %d, want %d", tt.total, tt.perPage, got, tt.want)
+		}
+	}
+}
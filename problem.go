@@ -0,0 +1,83 @@
+package apikit
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ErrorFormat defines how HandleError serializes errors
+type ErrorFormat int
+
+const (
+	// FormatJSON serializes errors as the Error struct (default)
+	FormatJSON ErrorFormat = iota
+
+	// FormatProblemJSON serializes errors as RFC 7807 problem details
+	FormatProblemJSON
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// errorFormat is the format used by HandleError
+var errorFormat = FormatJSON
+
+// SetErrorFormat sets the format used by HandleError to serialize errors
+// It should be called once during application startup
+func SetErrorFormat(format ErrorFormat) {
+	errorFormat = format
+}
+
+// Problem represents an RFC 7807 problem details object
+type Problem struct {
+	// URI reference identifying the problem type
+	Type string `json:"type"`
+
+	// Short summary of the problem type
+	Title string `json:"title"`
+
+	// HTTP status code
+	Status int `json:"status"`
+
+	// Explanation specific to this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+
+	// URI reference identifying this occurrence (request ID)
+	Instance string `json:"instance,omitempty"`
+
+	// Additional error details (e.g., per-field validation errors)
+	Errors any `json:"errors,omitempty"`
+}
+
+// NewProblem converts an Error into RFC 7807 problem details
+// Mapping: status=Code, title=ErrorCode, detail=Message, instance=RequestID, errors=Details
+func NewProblem(e *Error) *Problem {
+	title := e.ErrorCode
+	if title == "" {
+		title = http.StatusText(e.Code)
+	}
+
+	return &Problem{
+		Type:     "about:blank",
+		Title:    title,
+		Status:   e.Code,
+		Detail:   e.Message,
+		Instance: e.RequestID,
+		Errors:   e.Details,
+	}
+}
+
+// WriteProblem writes an error as an RFC 7807 problem+json response
+// Codes outside 100-999 are written as 500 Internal Server Error
+func WriteProblem(w http.ResponseWriter, e *Error) {
+	if status := validStatus(e.Code); status != e.Code {
+		// Copy so shared error values are not modified
+		normalized := *e
+		normalized.Code = status
+		e = &normalized
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(e.Code)
+	json.NewEncoder(w).Encode(NewProblem(e))
+}
@@ -0,0 +1,158 @@
+package apikit
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewProblem(t *testing.T) {
+	err := UnprocessableEntity("validation failed").
+		WithRequestID("req-123").
+		WithDetails(map[string]string{"email": "email is required"})
+
+	p := NewProblem(err)
+
+	if p.Type != "about:blank" {
+		t.Errorf("expected type 'about:blank', got %q", p.Type)
+	}
+	if p.Title != "Unprocessable Entity" {
+		t.Errorf("expected title 'Unprocessable Entity', got %q", p.Title)
+	}
+	if p.Status != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", p.Status)
+	}
+	if p.Detail != "validation failed" {
+		t.Errorf("expected detail 'validation failed', got %q", p.Detail)
+	}
+	if p.Instance != "req-123" {
+		t.Errorf("expected instance 'req-123', got %q", p.Instance)
+	}
+	if p.Errors == nil {
+		t.Error("expected errors to be set from details")
+	}
+}
+
+func TestNewProblem_DefaultTitle(t *testing.T) {
+	p := NewProblem(NewError(http.StatusTeapot, "short and stout"))
+	if p.Title != http.StatusText(http.StatusTeapot) {
+		t.Errorf("expected title %q, got %q", http.StatusText(http.StatusTeapot), p.Title)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteProblem(w, NotFound("user").WithRequestID("req-1"))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("expected Content-Type 'application/problem+json', got %q", ct)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	expected := map[string]any{
+		"type":     "about:blank",
+		"title":    "Not Found",
+		"status":   float64(404),
+		"detail":   "user not found",
+		"instance": "req-1",
+	}
+	for key, value := range expected {
+		if body[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, body[key])
+		}
+	}
+}
+
+func TestWriteProblem_InvalidStatus(t *testing.T) {
+	err := &Error{Message: "no code"}
+
+	w := httptest.NewRecorder()
+	WriteProblem(w, err)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if problem.Status != http.StatusInternalServerError || problem.Title != "Internal Server Error" {
+		t.Errorf("expected a 500 problem, got %+v", problem)
+	}
+	if err.Code != 0 {
+		t.Errorf("expected the error to be left unchanged, got code %d", err.Code)
+	}
+}
+
+func TestHandleError_ErrorFormat(t *testing.T) {
+	defer SetErrorFormat(FormatJSON)
+
+	tests := []struct {
+		name        string
+		format      ErrorFormat
+		err         error
+		status      int
+		contentType string
+		keys        []string
+	}{
+		{
+			name:        "default JSON format",
+			format:      FormatJSON,
+			err:         BadRequest("invalid input"),
+			status:      http.StatusBadRequest,
+			contentType: "application/json",
+			keys:        []string{"code", "errorCode", "message"},
+		},
+		{
+			name:        "problem JSON format",
+			format:      FormatProblemJSON,
+			err:         BadRequest("invalid input"),
+			status:      http.StatusBadRequest,
+			contentType: "application/problem+json",
+			keys:        []string{"type", "title", "status", "detail"},
+		},
+		{
+			name:        "problem JSON format with plain error",
+			format:      FormatProblemJSON,
+			err:         errors.New("boom"),
+			status:      http.StatusInternalServerError,
+			contentType: "application/problem+json",
+			keys:        []string{"type", "title", "status", "detail"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetErrorFormat(tt.format)
+
+			w := httptest.NewRecorder()
+			HandleError(w, tt.err)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("expected Content-Type %q, got %q", tt.contentType, ct)
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			for _, key := range tt.keys {
+				if _, ok := body[key]; !ok {
+					t.Errorf("expected key %q in body %v", key, body)
+				}
+			}
+		})
+	}
+}
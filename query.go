@@ -0,0 +1,19 @@
+package apikit
+
+import "strings"
+
+// SplitQueryValues splits each value of a query parameter on sep, for parameters
+// serialized with a delimited style
+// Empty elements are dropped
+// Example: ["go|api", "http"] with "|" -> ["go", "api", "http"]
+func SplitQueryValues(values []string, sep string) []string {
+	var split []string
+	for _, value := range values {
+		for part := range strings.SplitSeq(value, sep) {
+			if part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
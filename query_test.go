@@ -0,0 +1,29 @@
+package apikit
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitQueryValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		sep    string
+		want   []string
+	}{
+		{name: "pipe delimited", values: []string{"go|api|http"}, sep: "|", want: []string{"go", "api", "http"}},
+		{name: "space delimited", values: []string{"go api"}, sep: " ", want: []string{"go", "api"}},
+		{name: "repeated parameter", values: []string{"go|api", "http"}, sep: "|", want: []string{"go", "api", "http"}},
+		{name: "empty elements dropped", values: []string{"go||api|"}, sep: "|", want: []string{"go", "api"}},
+		{name: "no values", values: nil, sep: "|", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitQueryValues(tt.values, tt.sep); !slices.Equal(got, tt.want) {
+				t.Errorf("SplitQueryValues(%q, %q) = %q, want %q", tt.values, tt.sep, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package apikit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the request ID
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the client-provided request IDs that are propagated
+const maxRequestIDLength = 128
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// RequestID is a middleware that assigns every request an ID
+// A valid X-Request-ID from the client is kept, otherwise a random one is generated
+// The ID is echoed in the response header and stored in the request context,
+// where HandleErrorCtx picks it up for error bodies
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request ID stored by the RequestID middleware
+// Returns empty string if the context has none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID reports whether a client-provided request ID can be propagated:
+// non-empty, bounded in length and made of printable ASCII without spaces
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random 128-bit request ID
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
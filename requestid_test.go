@@ -0,0 +1,139 @@
+package apikit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{name: "propagates client ID", incoming: "req-123", wantSame: true},
+		{name: "generates when missing", incoming: ""},
+		{name: "replaces invalid ID", incoming: "bad id\twith spaces"},
+		{name: "replaces oversized ID", incoming: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = ""
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				r.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			handler.ServeHTTP(w, r)
+
+			got := w.Header().Get(RequestIDHeader)
+			if got == "" {
+				t.Fatal("expected X-Request-ID response header")
+			}
+			if got != seen {
+				t.Errorf("expected context ID %q to match header %q", seen, got)
+			}
+			if tt.wantSame && got != tt.incoming {
+				t.Errorf("expected client ID %q to be kept, got %q", tt.incoming, got)
+			}
+			if !tt.wantSame && (got == tt.incoming || len(got) != 32) {
+				t.Errorf("expected a generated 32-character ID, got %q", got)
+			}
+		})
+	}
+}
+
+func TestHandleErrorCtx(t *testing.T) {
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-123")
+	shared := NotFound("pet")
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		err    error
+		format ErrorFormat
+		want   map[string]any
+	}{
+		{
+			name: "api error",
+			ctx:  ctx,
+			err:  shared,
+			want: map[string]any{"code": float64(404), "message": "pet not found", "errorCode": "Not Found", "requestId": "req-123"},
+		},
+		{
+			name: "plain error",
+			ctx:  ctx,
+			err:  errors.New("boom"),
+			want: map[string]any{"error": "boom", "requestId": "req-123"},
+		},
+		{
+			name:   "problem details",
+			ctx:    ctx,
+			err:    errors.New("boom"),
+			format: FormatProblemJSON,
+			want:   map[string]any{"type": "about:blank", "title": "Internal Server Error", "status": float64(500), "detail": "boom", "instance": "req-123"},
+		},
+		{
+			name: "no request ID",
+			ctx:  context.Background(),
+			err:  errors.New("boom"),
+			want: map[string]any{"error": "boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetErrorFormat(tt.format)
+			defer SetErrorFormat(FormatJSON)
+
+			w := httptest.NewRecorder()
+			HandleErrorCtx(tt.ctx, w, tt.err)
+
+			var got map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode error body: %v", err)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("%s = %v, want %v (body %s)", key, got[key], want, w.Body.String())
+				}
+			}
+			if _, ok := tt.want["requestId"]; !ok && got["requestId"] != nil {
+				t.Errorf("expected no requestId, got %s", w.Body.String())
+			}
+		})
+	}
+
+	if shared.RequestID != "" {
+		t.Errorf("expected the shared error to be left unchanged, got request ID %q", shared.RequestID)
+	}
+}
+
+func TestWrap_RequestID(t *testing.T) {
+	handler := RequestID(Wrap(func(ctx context.Context, req struct{}) (struct{}, error) {
+		return struct{}{}, Conflict("already exists")
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set(RequestIDHeader, "req-456")
+	handler.ServeHTTP(w, r)
+
+	if w.Header().Get(RequestIDHeader) != "req-456" {
+		t.Errorf("expected X-Request-ID header req-456, got %q", w.Header().Get(RequestIDHeader))
+	}
+	if !strings.Contains(w.Body.String(), `"requestId":"req-456"`) {
+		t.Errorf("expected request ID in error body, got %s", w.Body.String())
+	}
+}
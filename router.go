@@ -0,0 +1,369 @@
+package apikit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+// Router registers typed handlers wrapped with Wrap on an http.ServeMux and
+// records them so the API can be described with OpenAPI without code generation
+type Router struct {
+	mux    *http.ServeMux
+	routes []route
+}
+
+// route is a handler registered on a Router
+type route struct {
+	method string
+	path   string
+	req    reflect.Type
+	resp   reflect.Type
+}
+
+// NewRouter creates an empty router
+func NewRouter() *Router {
+	return &Router{mux: http.NewServeMux()}
+}
+
+// ServeHTTP dispatches the request to the handler registered for its method and path
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt.mux.ServeHTTP(w, r)
+}
+
+// Handle registers fn for the method and path, using the Go 1.22 ServeMux pattern syntax
+// Example: Handle(router, "GET", "/pets/{id}", GetPet)
+func Handle[Req any, Resp any](rt *Router, method, path string, fn func(context.Context, Req) (Resp, error)) {
+	method = strings.ToUpper(method)
+	rt.mux.Handle(method+" "+path, Wrap(fn))
+	rt.routes = append(rt.routes, route{
+		method: method,
+		path:   path,
+		req:    reflect.TypeFor[Req](),
+		resp:   reflect.TypeFor[Resp](),
+	})
+}
+
+// OpenAPI describes the registered routes as an OpenAPI 3.0 specification
+// Parameters come from the path, query, header and cookie tags of the request
+// types, the request body from its json:"body" field (or the remaining fields),
+// and named structs are added to the component schemas
+func (rt *Router) OpenAPI() *spec.OpenAPI {
+	g := &schemaGenerator{
+		schemas: make(map[string]*spec.Schema),
+		names:   make(map[reflect.Type]string),
+	}
+
+	openapi := &spec.OpenAPI{
+		OpenAPI: "3.0.3",
+		Info: &spec.Info{
+			Title:   "API",
+			Version: "1.0.0",
+		},
+		Paths: &spec.Paths{
+			PathItems: make(map[string]*spec.PathItem),
+		},
+	}
+
+	for _, r := range rt.routes {
+		p := openAPIPath(r.path)
+		if openapi.Paths.PathItems[p] == nil {
+			openapi.Paths.PathItems[p] = &spec.PathItem{}
+		}
+		openapi.Paths.PathItems[p].SetOperation(r.method, g.operation(r))
+	}
+
+	if len(g.schemas) > 0 {
+		openapi.Components = &spec.Components{Schemas: g.schemas}
+	}
+	return openapi
+}
+
+// openAPIPath converts a ServeMux pattern path to an OpenAPI path
+// Example: "/files/{path...}" -> "/files/{path}", "/{$}" -> "/"
+func openAPIPath(p string) string {
+	p = strings.ReplaceAll(p, "...}", "}")
+	if trimmed, ok := strings.CutSuffix(p, "{$}"); ok {
+		p = trimmed
+		if p != "/" {
+			p = strings.TrimSuffix(p, "/")
+		}
+	}
+	return p
+}
+
+// schemaGenerator builds OpenAPI schemas from Go types by reflection,
+// collecting named structs as component schemas
+type schemaGenerator struct {
+	schemas map[string]*spec.Schema
+
+	// names are the component names given to the named structs
+	names map[reflect.Type]string
+}
+
+// operation describes a registered route
+func (g *schemaGenerator) operation(rt route) *spec.Operation {
+	op := &spec.Operation{
+		Responses: &spec.Responses{
+			StatusCodeResponses: make(map[string]*spec.Response),
+		},
+	}
+
+	req := rt.req
+	for req.Kind() == reflect.Pointer {
+		req = req.Elem()
+	}
+
+	var bound map[string]bool
+	if binding := newRequestBinding(req); binding != nil {
+		bound = make(map[string]bool)
+		for _, p := range binding.params {
+			field := req.FieldByIndex(p.index)
+			bound[field.Name] = true
+			op.Parameters = append(op.Parameters, g.parameter(p, field))
+		}
+		if binding.body != nil {
+			op.RequestBody = g.requestBody(g.schema(req.FieldByIndex(binding.body).Type))
+		}
+	}
+
+	// Without a json:"body" field the remaining fields are decoded from the body
+	if op.RequestBody == nil && rt.method != http.MethodGet && rt.method != http.MethodHead {
+		if req.Kind() != reflect.Struct {
+			op.RequestBody = g.requestBody(g.schema(req))
+		} else if schema := g.structSchema(req, bound); len(schema.Properties) > 0 {
+			if len(bound) == 0 && req.Name() != "" {
+				schema = g.schema(req)
+			}
+			op.RequestBody = g.requestBody(schema)
+		}
+	}
+
+	response := &spec.Response{Description: http.StatusText(http.StatusOK)}
+	if rt.resp != reflect.TypeFor[*HttpResponse]() && rt.resp.Kind() != reflect.Interface {
+		response.Content = map[string]*spec.MediaType{
+			"application/json": {Schema: g.schema(rt.resp)},
+		}
+	}
+	op.Responses.StatusCodeResponses["200"] = response
+
+	return op
+}
+
+// parameter describes a field bound from the path, query, header or cookie
+func (g *schemaGenerator) parameter(p boundParam, field reflect.StructField) *spec.Parameter {
+	param := &spec.Parameter{
+		Name:     p.name,
+		In:       p.source,
+		Required: p.source == "path" || hasValidateRule(field, "required"),
+		Schema:   g.schema(field.Type),
+	}
+	if p.defValue != "" {
+		// Non-string defaults such as "10" or "true" keep their JSON type
+		var value any = p.defValue
+		if param.Schema.Type != "string" {
+			_ = json.Unmarshal([]byte(p.defValue), &value)
+		}
+		param.Schema.Default = value
+	}
+	return param
+}
+
+// requestBody wraps the schema in a JSON request body
+func (g *schemaGenerator) requestBody(schema *spec.Schema) *spec.RequestBody {
+	return &spec.RequestBody{
+		Required: true,
+		Content: map[string]*spec.MediaType{
+			"application/json": {Schema: schema},
+		},
+	}
+}
+
+// schema returns the schema of t, referencing named structs from the components
+func (g *schemaGenerator) schema(t reflect.Type) *spec.Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	if schema := wellKnownReflectSchema(t); schema != nil {
+		schema.Nullable = nullable
+		return schema
+	}
+
+	var schema *spec.Schema
+	switch t.Kind() {
+	case reflect.String:
+		schema = &spec.Schema{Type: "string"}
+	case reflect.Bool:
+		schema = &spec.Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema = &spec.Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		schema = &spec.Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		schema = &spec.Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		schema = &spec.Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			schema = g.structSchema(t, nil)
+			break
+		}
+		// Register before building the properties so recursive types terminate
+		name, ok := g.names[t]
+		if !ok {
+			name = g.componentName(t)
+			g.names[t] = name
+			g.schemas[name] = &spec.Schema{}
+			*g.schemas[name] = *g.structSchema(t, nil)
+		}
+		return &spec.Schema{Ref: "#/components/schemas/" + name}
+	default:
+		// Interfaces and other dynamic types accept any value
+		return &spec.Schema{}
+	}
+
+	schema.Nullable = nullable
+	return schema
+}
+
+// componentName returns an unused component name for a named struct
+// Generic type arguments are folded into the name, and a type sharing its name
+// with one from another package is prefixed with its package name
+// Example: Page[models.User] -> "PageUser", a second User from models -> "modelsUser"
+func (g *schemaGenerator) componentName(t reflect.Type) string {
+	name := joinIdentifiers(t.Name())
+	if _, taken := g.schemas[name]; !taken {
+		return name
+	}
+
+	base := joinIdentifiers(path.Base(t.PkgPath()) + " " + t.Name())
+	name = base
+	for i := 2; ; i++ {
+		if _, taken := g.schemas[name]; !taken {
+			return name
+		}
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// joinIdentifiers joins the identifiers of a type name in camel case, dropping
+// package qualifiers and the characters not allowed in component names
+// Example: "Page[example.com/models.User]" -> "PageUser", "go-kit Error" -> "goKitError"
+func joinIdentifiers(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return strings.ContainsRune("[]*, ", r)
+	}) {
+		// Keep the type name of a package-qualified argument
+		word = word[strings.LastIndex(word, ".")+1:]
+		for _, part := range strings.FieldsFunc(word, func(r rune) bool {
+			return r != '_' && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') && !('0' <= r && r <= '9')
+		}) {
+			if b.Len() > 0 {
+				part = strings.ToUpper(part[:1]) + part[1:]
+			}
+			b.WriteString(part)
+		}
+	}
+	return b.String()
+}
+
+// structSchema builds the object schema of a struct, promoting embedded struct
+// fields and skipping the fields named in skip
+// Plain fields are required; pointer and omitempty fields are not, unless
+// they have a validate:"required" rule
+func (g *schemaGenerator) structSchema(t reflect.Type, skip map[string]bool) *spec.Schema {
+	schema := &spec.Schema{
+		Type:       "object",
+		Properties: make(map[string]*spec.Schema),
+	}
+
+	var embedded []reflect.StructField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if skip[field.Name] {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// Untagged embedded structs are promoted once the direct fields are known,
+		// since those take precedence
+		if field.Anonymous && name == "" {
+			base := field.Type
+			if base.Kind() == reflect.Pointer {
+				base = base.Elem()
+			}
+			if base.Kind() == reflect.Struct {
+				embedded = append(embedded, field)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = g.schema(field.Type)
+		schema.PropertyOrder = append(schema.PropertyOrder, name)
+
+		omitempty := strings.Contains(","+options+",", ",omitempty,")
+		if hasValidateRule(field, "required") || (!omitempty && field.Type.Kind() != reflect.Pointer) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	for _, field := range embedded {
+		base, isPointer := field.Type, field.Type.Kind() == reflect.Pointer
+		if isPointer {
+			base = base.Elem()
+		}
+		schema.PromoteProperties(g.structSchema(base, skip), isPointer)
+	}
+
+	return schema
+}
+
+// hasValidateRule reports whether the field's validate tag contains the rule
+// Example: validate:"required,min=1" has the rule "required"
+func hasValidateRule(field reflect.StructField, rule string) bool {
+	for r := range strings.SplitSeq(field.Tag.Get("validate"), ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// wellKnownReflectSchema returns the schema of well-known external types,
+// matching the schemas used by the openapi builder
+// Returns nil if the type is not well-known
+func wellKnownReflectSchema(t reflect.Type) *spec.Schema {
+	switch {
+	case t.PkgPath() == "time" && t.Name() == "Time":
+		return &spec.Schema{Type: "string", Format: "date-time"}
+	case t.PkgPath() == "time" && t.Name() == "Duration":
+		return &spec.Schema{Type: "string"}
+	case path.Base(t.PkgPath()) == "uuid" && t.Name() == "UUID":
+		return &spec.Schema{Type: "string", Format: "uuid"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return &spec.Schema{Type: "string", Format: "byte"}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,236 @@
+package apikit
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reation-io/apikit/openapi/spec"
+)
+
+type routerPet struct {
+	ID        int         `json:"id"`
+	Name      string      `json:"name"`
+	Tag       *string     `json:"tag"`
+	Owner     routerOwner `json:"owner,omitempty"`
+	CreatedAt time.Time   `json:"createdAt,omitempty"`
+}
+
+type routerOwner struct {
+	Name string `json:"name"`
+}
+
+type routerGetPetRequest struct {
+	ID     int    `path:"id"`
+	Fields string `query:"fields" default:"all"`
+	Limit  int    `query:"limit" default:"10" validate:"required"`
+}
+
+type routerCreatePetRequest struct {
+	RequestID string    `header:"X-Request-ID"`
+	Body      routerPet `json:"body"`
+}
+
+func newTestRouter() *Router {
+	router := NewRouter()
+	Handle(router, "GET", "/pets/{id}", func(ctx context.Context, req routerGetPetRequest) (routerPet, error) {
+		return routerPet{ID: req.ID, Name: req.Fields}, nil
+	})
+	Handle(router, "post", "/pets", func(ctx context.Context, req routerCreatePetRequest) (*routerPet, error) {
+		return &req.Body, nil
+	})
+	return router
+}
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	router := newTestRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets/7?fields=name", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"id":7`) || !strings.Contains(w.Body.String(), `"name":"name"`) {
+		t.Errorf("unexpected body %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"id":1,"name":"Rex"}`)))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"name":"Rex"`) {
+		t.Errorf("expected the created pet, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRouter_OpenAPI(t *testing.T) {
+	openapi := newTestRouter().OpenAPI()
+
+	if openapi.OpenAPI != "3.0.3" || openapi.Info == nil {
+		t.Fatalf("unexpected spec header: %+v", openapi)
+	}
+
+	paths := slices.Sorted(maps.Keys(openapi.Paths.PathItems))
+	if want := []string{"/pets", "/pets/{id}"}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+
+	get := openapi.Paths.PathItems["/pets/{id}"].Get
+	if get == nil {
+		t.Fatal("expected GET /pets/{id}")
+	}
+	if get.RequestBody != nil {
+		t.Errorf("expected no request body for GET, got %+v", get.RequestBody)
+	}
+	if len(get.Parameters) != 3 {
+		t.Fatalf("expected 3 parameters, got %d", len(get.Parameters))
+	}
+	id, fields, limit := get.Parameters[0], get.Parameters[1], get.Parameters[2]
+	if id.Name != "id" || id.In != "path" || !id.Required || id.Schema.Type != "integer" {
+		t.Errorf("unexpected id parameter: %+v", id)
+	}
+	if fields.Name != "fields" || fields.In != "query" || fields.Required || fields.Schema.Default != "all" {
+		t.Errorf("unexpected fields parameter: %+v", fields)
+	}
+	if !limit.Required || limit.Schema.Default != float64(10) {
+		t.Errorf("unexpected limit parameter: %+v", limit)
+	}
+	if got := get.Responses.StatusCodeResponses["200"].Content["application/json"].Schema.Ref; got != "#/components/schemas/routerPet" {
+		t.Errorf("GET response schema = %q, want routerPet ref", got)
+	}
+
+	post := openapi.Paths.PathItems["/pets"].Post
+	if post == nil {
+		t.Fatal("expected POST /pets")
+	}
+	if len(post.Parameters) != 1 || post.Parameters[0].In != "header" || post.Parameters[0].Name != "X-Request-ID" {
+		t.Errorf("unexpected POST parameters: %+v", post.Parameters)
+	}
+	if post.RequestBody == nil || post.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/routerPet" {
+		t.Errorf("expected a routerPet request body, got %+v", post.RequestBody)
+	}
+
+	pet := openapi.Components.Schemas["routerPet"]
+	if pet == nil {
+		t.Fatal("expected routerPet schema")
+	}
+	if want := []string{"id", "name"}; !reflect.DeepEqual(pet.Required, want) {
+		t.Errorf("required = %v, want %v", pet.Required, want)
+	}
+	if !pet.Properties["tag"].Nullable {
+		t.Error("expected pointer field tag to be nullable")
+	}
+	if got := pet.Properties["createdAt"]; got.Type != "string" || got.Format != "date-time" {
+		t.Errorf("createdAt = %+v, want a date-time string", got)
+	}
+	if got := pet.Properties["owner"].Ref; got != "#/components/schemas/routerOwner" {
+		t.Errorf("owner = %q, want routerOwner ref", got)
+	}
+	if openapi.Components.Schemas["routerOwner"] == nil {
+		t.Error("expected routerOwner schema")
+	}
+}
+
+type routerAudit struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+type routerBase struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type routerTicket struct {
+	routerBase
+	*routerAudit
+	Title string `json:"title"`
+
+	// Name shadows routerBase.Name
+	Name *string `json:"name"`
+}
+
+func TestRouter_OpenAPIEmbeddedStructs(t *testing.T) {
+	router := NewRouter()
+	Handle(router, "GET", "/tickets/{id}", func(ctx context.Context, req routerGetPetRequest) (routerTicket, error) {
+		return routerTicket{}, nil
+	})
+
+	ticket := router.OpenAPI().Components.Schemas["routerTicket"]
+	if ticket == nil {
+		t.Fatal("expected routerTicket schema")
+	}
+
+	// Direct fields come first, then the promoted ones in declaration order
+	if want := []string{"title", "name", "id", "createdBy"}; !reflect.DeepEqual(ticket.PropertyOrder, want) {
+		t.Errorf("property order = %v, want %v", ticket.PropertyOrder, want)
+	}
+	if !ticket.Properties["name"].Nullable {
+		t.Error("expected the direct name field to shadow the promoted one")
+	}
+
+	// Fields promoted through a pointer embed may be absent, so they aren't required
+	if want := []string{"title", "id"}; !reflect.DeepEqual(ticket.Required, want) {
+		t.Errorf("required = %v, want %v", ticket.Required, want)
+	}
+}
+
+// Contact shares its name with spec.Contact
+type Contact struct {
+	Phone string `json:"phone"`
+}
+
+func TestRouter_OpenAPIComponentNames(t *testing.T) {
+	router := NewRouter()
+	Handle(router, "GET", "/pets", func(ctx context.Context, req struct{}) (Page[routerPet], error) {
+		return Page[routerPet]{}, nil
+	})
+	Handle(router, "GET", "/contact", func(ctx context.Context, req struct{}) (Contact, error) {
+		return Contact{}, nil
+	})
+	Handle(router, "GET", "/owner", func(ctx context.Context, req struct{}) (spec.Contact, error) {
+		return spec.Contact{}, nil
+	})
+
+	openapi := router.OpenAPI()
+	names := slices.Sorted(maps.Keys(openapi.Components.Schemas))
+	if want := []string{"Contact", "PageRouterPet", "routerOwner", "routerPet", "specContact"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("component names = %v, want %v", names, want)
+	}
+
+	refs := map[string]string{
+		"/pets":    "#/components/schemas/PageRouterPet",
+		"/contact": "#/components/schemas/Contact",
+		"/owner":   "#/components/schemas/specContact",
+	}
+	for p, want := range refs {
+		got := openapi.Paths.PathItems[p].Get.Responses.StatusCodeResponses["200"].Content["application/json"].Schema.Ref
+		if got != want {
+			t.Errorf("%s response $ref = %q, want %q", p, got, want)
+		}
+	}
+
+	if ref := openapi.Components.Schemas["PageRouterPet"].Properties["items"].Items.Ref; ref != "#/components/schemas/routerPet" {
+		t.Errorf("items $ref = %q, want the routerPet component", ref)
+	}
+	if phone := openapi.Components.Schemas["Contact"].Properties["phone"]; phone == nil {
+		t.Error("expected the local Contact to keep its own properties")
+	}
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	tests := map[string]string{
+		"/pets/{id}":       "/pets/{id}",
+		"/files/{path...}": "/files/{path}",
+		"/{$}":             "/",
+		"/pets/{$}":        "/pets",
+	}
+	for in, want := range tests {
+		if got := openAPIPath(in); got != want {
+			t.Errorf("openAPIPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
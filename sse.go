@@ -0,0 +1,85 @@
+package apikit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SSEEvent is a single Server-Sent Event
+// Event is optional; Data is written as-is for strings and []byte, JSON-encoded otherwise
+type SSEEvent struct {
+	Event string
+	Data  any
+}
+
+// SSEStream is returned by handlers that push Server-Sent Events
+// The generated wrapper sends each event until the channel is closed
+// or the client disconnects
+type SSEStream <-chan SSEEvent
+
+// SSEWriter writes Server-Sent Events to an http.ResponseWriter,
+// flushing after every event when the writer supports http.Flusher
+type SSEWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// NewSSEWriter sets the event-stream headers and returns a writer for sending events
+func NewSSEWriter(w http.ResponseWriter) *SSEWriter {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	sse := &SSEWriter{w: w}
+	if flusher, ok := w.(http.Flusher); ok {
+		sse.flusher = flusher
+		flusher.Flush()
+	}
+	return sse
+}
+
+// Send writes a single event and flushes it to the client
+// Multi-line data is split into several "data:" lines as required by the SSE format
+func (s *SSEWriter) Send(event string, data any) error {
+	payload, err := sseData(data)
+	if err != nil {
+		return fmt.Errorf("encoding event data: %w", err)
+	}
+
+	var b strings.Builder
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(payload, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	if _, err := s.w.Write([]byte(b.String())); err != nil {
+		return err
+	}
+
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+	return nil
+}
+
+// sseData converts event data into its textual representation
+func sseData(data any) (string, error) {
+	switch v := data.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+}
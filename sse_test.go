@@ -0,0 +1,101 @@
+package apikit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSSEWriter_Send(t *testing.T) {
+	w := httptest.NewRecorder()
+	sse := NewSSEWriter(w)
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected Content-Type text/event-stream, got %q", ct)
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != "no-cache" {
+		t.Errorf("expected Cache-Control no-cache, got %q", cc)
+	}
+
+	if err := sse.Send("", "hello"); err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+	if got := w.Body.String(); got != "data: hello\n\n" {
+		t.Errorf("expected plain data frame, got %q", got)
+	}
+	if !w.Flushed {
+		t.Error("expected event to be flushed")
+	}
+}
+
+func TestSSEWriter_SendFraming(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		data  any
+		want  string
+	}{
+		{
+			name:  "named event with JSON data",
+			event: "update",
+			data:  map[string]int{"count": 3},
+			want:  "event: update\ndata: {\"count\":3}\n\n",
+		},
+		{
+			name: "multi-line data",
+			data: "line one\nline two",
+			want: "data: line one\ndata: line two\n\n",
+		},
+		{
+			name: "byte data",
+			data: []byte("raw"),
+			want: "data: raw\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			sse := NewSSEWriter(w)
+
+			if err := sse.Send(tt.event, tt.data); err != nil {
+				t.Fatalf("Send() failed: %v", err)
+			}
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSSEWriter_EncodingError(t *testing.T) {
+	w := httptest.NewRecorder()
+	sse := NewSSEWriter(w)
+
+	if err := sse.Send("", make(chan int)); err == nil {
+		t.Error("expected error for unencodable data")
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected nothing written, got %q", w.Body.String())
+	}
+}
+
+// nonFlusher is a ResponseWriter that does not implement http.Flusher
+type nonFlusher struct {
+	http.ResponseWriter
+}
+
+func TestSSEWriter_WithoutFlusher(t *testing.T) {
+	rec := httptest.NewRecorder()
+	sse := NewSSEWriter(nonFlusher{rec})
+
+	if err := sse.Send("ping", "1"); err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+	if got := rec.Body.String(); got != "event: ping\ndata: 1\n\n" {
+		t.Errorf("expected framed event, got %q", got)
+	}
+	if rec.Flushed {
+		t.Error("expected no flush without http.Flusher")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/go-playground/locales"
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
@@ -14,6 +15,9 @@ import (
 )
 
 var (
+	// defaultValidator backs the package-level functions
+	defaultValidator *Validator
+
 	validate   *validator.Validate
 	translator ut.Translator
 	once       sync.Once
@@ -24,10 +28,32 @@ func init() {
 }
 
 func initValidator() {
-	validate = validator.New()
+	defaultValidator = New()
+	validate = defaultValidator.validate
+	translator = defaultValidator.translator
+}
+
+// Validator is an isolated validator with its own custom validations and translators
+// Use New to configure validation independently of the package-level default instance
+type Validator struct {
+	validate   *validator.Validate
+	translator ut.Translator
+	uni        *ut.UniversalTranslator
+
+	// translators holds the translators added with RegisterLocale, keyed by locale
+	translators   map[string]ut.Translator
+	translatorsMu sync.RWMutex
+}
+
+// New creates a validator that uses JSON tag names and English messages by default
+func New() *Validator {
+	v := &Validator{
+		validate:    validator.New(),
+		translators: make(map[string]ut.Translator),
+	}
 
 	// Use JSON tag names in error messages instead of struct field names
-	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+	v.validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
 		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
 		if name == "-" {
 			return ""
@@ -40,54 +66,185 @@ func initValidator() {
 
 	// Initialize universal translator
 	en := en.New()
-	uni := ut.New(en, en)
-	translator, _ = uni.GetTranslator("en")
+	v.uni = ut.New(en, en)
+	v.translator, _ = v.uni.GetTranslator("en")
 
 	// Register default translations
-	if err := entranslations.RegisterDefaultTranslations(validate, translator); err != nil {
+	if err := entranslations.RegisterDefaultTranslations(v.validate, v.translator); err != nil {
 		panic("failed to register default translations: " + err.Error())
 	}
+
+	return v
 }
 
 // Validate returns the validator instance
 func Validate() *validator.Validate {
-	return validate
+	return defaultValidator.Validate()
 }
 
 // Translator returns the universal translator instance
 func Translator() ut.Translator {
-	return translator
+	return defaultValidator.Translator()
+}
+
+// RegisterLocale adds a translator for the given locale to the default validator
+func RegisterLocale(locale string, registerFn func(*validator.Validate, ut.Translator) error) error {
+	return defaultValidator.RegisterLocale(locale, registerFn)
+}
+
+// TranslatorFor returns the default validator's translator for locale
+func TranslatorFor(locale string) ut.Translator {
+	return defaultValidator.TranslatorFor(locale)
 }
 
 // RegisterValidation registers a custom validation with translation
 func RegisterValidation(f func(v *validator.Validate, translator ut.Translator)) {
-	f(validate, translator)
+	defaultValidator.RegisterValidation(f)
 }
 
 // Struct validates a struct without context
 func Struct(s any) error {
-	if err := validate.Struct(s); err != nil {
-		return FormatError(err)
+	return defaultValidator.Struct(s)
+}
+
+// StructCtx validates a struct with context
+func StructCtx(ctx context.Context, s any) error {
+	return defaultValidator.StructCtx(ctx, s)
+}
+
+// StructExceptCtx validates a struct with context, omitting specified fields
+func StructExceptCtx(ctx context.Context, s any, omitField ...string) error {
+	return defaultValidator.StructExceptCtx(ctx, s, omitField...)
+}
+
+// FormatError formats validator errors using the universal translator
+func FormatError(err error) error {
+	return defaultValidator.FormatError(err)
+}
+
+// FormatErrorLocale formats validator errors using the translator for locale,
+// falling back to the default translator when the locale isn't registered
+func FormatErrorLocale(err error, locale string) error {
+	return defaultValidator.FormatErrorLocale(err, locale)
+}
+
+// Validate returns the underlying go-playground validator
+func (v *Validator) Validate() *validator.Validate {
+	return v.validate
+}
+
+// Translator returns the default translator
+func (v *Validator) Translator() ut.Translator {
+	return v.translator
+}
+
+// RegisterLocale adds a translator for the given locale (e.g. "es", "pt_BR")
+// and registers its messages with registerFn, typically a
+// translations/<lang>.RegisterDefaultTranslations function
+// Plural rules follow the default English locale
+func (v *Validator) RegisterLocale(locale string, registerFn func(*validator.Validate, ut.Translator) error) error {
+	locale = normalizeLocale(locale)
+	if locale == "" {
+		return fmt.Errorf("locale must not be empty")
+	}
+
+	v.translatorsMu.Lock()
+	defer v.translatorsMu.Unlock()
+
+	if err := v.uni.AddTranslator(namedLocale{Translator: en.New(), name: locale}, true); err != nil {
+		return fmt.Errorf("adding translator for %s: %w", locale, err)
+	}
+	trans, _ := v.uni.GetTranslator(locale)
+
+	if err := registerFn(v.validate, trans); err != nil {
+		return fmt.Errorf("registering translations for %s: %w", locale, err)
+	}
+
+	v.translators[locale] = trans
+	return nil
+}
+
+// TranslatorFor returns the translator registered for locale
+// A regional locale ("es-MX") falls back to its language ("es"),
+// and unregistered locales fall back to the default translator
+func (v *Validator) TranslatorFor(locale string) ut.Translator {
+	locale = normalizeLocale(locale)
+
+	v.translatorsMu.RLock()
+	defer v.translatorsMu.RUnlock()
+
+	if trans, ok := v.translators[locale]; ok {
+		return trans
+	}
+	if lang, _, ok := strings.Cut(locale, "_"); ok {
+		if trans, ok := v.translators[lang]; ok {
+			return trans
+		}
+	}
+	return v.translator
+}
+
+// RegisterValidation registers a custom validation with translation
+func (v *Validator) RegisterValidation(f func(v *validator.Validate, translator ut.Translator)) {
+	f(v.validate, v.translator)
+}
+
+// Struct validates a struct without context
+func (v *Validator) Struct(s any) error {
+	if err := v.validate.Struct(s); err != nil {
+		return formatError(err, v.translator, s)
 	}
 	return nil
 }
 
 // StructCtx validates a struct with context
-func StructCtx(ctx context.Context, s any) error {
-	if err := validate.StructCtx(ctx, s); err != nil {
-		return FormatError(err)
+func (v *Validator) StructCtx(ctx context.Context, s any) error {
+	if err := v.validate.StructCtx(ctx, s); err != nil {
+		return formatError(err, v.translator, s)
 	}
 	return nil
 }
 
 // StructExceptCtx validates a struct with context, omitting specified fields
-func StructExceptCtx(ctx context.Context, s any, omitField ...string) error {
-	if err := validate.StructExceptCtx(ctx, s, omitField...); err != nil {
-		return FormatError(err)
+func (v *Validator) StructExceptCtx(ctx context.Context, s any, omitField ...string) error {
+	if err := v.validate.StructExceptCtx(ctx, s, omitField...); err != nil {
+		return formatError(err, v.translator, s)
 	}
 	return nil
 }
 
+// FormatError formats validator errors using the default translator
+func (v *Validator) FormatError(err error) error {
+	return formatError(err, v.translator, nil)
+}
+
+// FormatErrorLocale formats validator errors using the translator for locale
+func (v *Validator) FormatErrorLocale(err error, locale string) error {
+	return formatError(err, v.TranslatorFor(locale), nil)
+}
+
+// normalizeLocale converts a locale to the go-playground form
+// Example: "pt-BR" -> "pt_BR", " ES " -> "es"
+func normalizeLocale(locale string) string {
+	lang, region, ok := strings.Cut(strings.ReplaceAll(strings.TrimSpace(locale), "-", "_"), "_")
+	lang = strings.ToLower(lang)
+	if !ok {
+		return lang
+	}
+	return lang + "_" + strings.ToUpper(region)
+}
+
+// namedLocale reports a custom locale name for an existing locale implementation
+type namedLocale struct {
+	locales.Translator
+	name string
+}
+
+// Locale returns the custom locale name
+func (l namedLocale) Locale() string {
+	return l.name
+}
+
 // FieldError represents a single field validation error
 type FieldError struct {
 	Field   string `json:"field"`
@@ -112,8 +269,10 @@ func (v ValidationError) Error() string {
 	return fmt.Sprintf("validation failed: %s", strings.Join(messages, "; "))
 }
 
-// FormatError formats validator errors using the universal translator
-func FormatError(err error) error {
+// formatError formats validator errors with the given translator
+// When the validated value is known, a `message` tag on the failing field
+// replaces the translated message
+func formatError(err error, trans ut.Translator, s any) error {
 	if err == nil {
 		return nil
 	}
@@ -123,11 +282,20 @@ func FormatError(err error) error {
 		return err
 	}
 
+	var root reflect.Type
+	if s != nil {
+		root = reflect.TypeOf(s)
+	}
+
 	var fieldErrors []FieldError
 	for _, e := range validationErrors {
+		message, ok := customMessage(root, e)
+		if !ok {
+			message = e.Translate(trans)
+		}
 		fieldErrors = append(fieldErrors, FieldError{
-			Field:   e.Field(),
-			Message: e.Translate(translator),
+			Field:   fieldPath(e),
+			Message: message,
 		})
 	}
 
@@ -136,3 +304,62 @@ func FormatError(err error) error {
 		FieldErrors: fieldErrors,
 	}
 }
+
+// fieldPath returns the dotted path of the failing field using json names
+// The namespace already uses the registered tag names at each level
+// and dive indices, so only the root struct name needs to be dropped
+// Example: "CreateUserRequest.address.zip" -> "address.zip"
+// Example: "CreateOrderRequest.items[2].price" -> "items[2].price"
+func fieldPath(e validator.FieldError) string {
+	_, path, ok := strings.Cut(e.Namespace(), ".")
+	if !ok || path == "" {
+		return e.Field()
+	}
+	return path
+}
+
+// customMessage returns the `message` tag of the failing struct field
+// The field is located by walking the Go field names of the struct namespace
+// Example: "CreateUserRequest.Items[1].Price" -> Items (element type) -> Price
+func customMessage(root reflect.Type, e validator.FieldError) (string, bool) {
+	if root == nil {
+		return "", false
+	}
+
+	segments := strings.Split(e.StructNamespace(), ".")
+	if len(segments) < 2 {
+		return "", false
+	}
+
+	t := root
+	var field reflect.StructField
+	for _, segment := range segments[1:] {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return "", false
+		}
+
+		name, _, _ := strings.Cut(segment, "[")
+		var ok bool
+		field, ok = t.FieldByName(name)
+		if !ok {
+			return "", false
+		}
+
+		// Each [index] or [key] steps into the element type
+		t = field.Type
+		for range strings.Count(segment, "]") {
+			for t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			if t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map {
+				return "", false
+			}
+			t = t.Elem()
+		}
+	}
+
+	return field.Tag.Lookup("message")
+}
@@ -7,6 +7,7 @@ import (
 
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	estranslations "github.com/go-playground/validator/v10/translations/es"
 )
 
 type validationTestStruct struct {
@@ -15,6 +16,25 @@ type validationTestStruct struct {
 	Age   int    `json:"age" validate:"gte=0,lte=120"`
 }
 
+type validationTestAddress struct {
+	Street string `json:"street" validate:"required"`
+	Zip    string `json:"zip" validate:"required,len=5"`
+}
+
+type validationTestNested struct {
+	Name    string                `json:"name" validate:"required"`
+	Address validationTestAddress `json:"address"`
+}
+
+type validationTestItem struct {
+	Name  string  `json:"name" validate:"required"`
+	Price float64 `json:"price" validate:"gt=0"`
+}
+
+type validationTestOrder struct {
+	Items []validationTestItem `json:"items" validate:"required,dive"`
+}
+
 type validationTestStructWithJSON struct {
 	UserName string `json:"userName" validate:"required"`
 	Password string `json:"password" validate:"required,min=8"`
@@ -351,3 +371,166 @@ func TestMultipleValidationErrors(t *testing.T) {
 		t.Errorf("expected at least 3 field errors, got %d", len(valErr.FieldErrors))
 	}
 }
+
+func TestFormatErrorLocale(t *testing.T) {
+	if err := RegisterLocale("es", estranslations.RegisterDefaultTranslations); err != nil {
+		t.Fatalf("RegisterLocale() failed: %v", err)
+	}
+
+	input := validationTestStruct{Email: "john@example.com"}
+	message := func(err error) string {
+		valErr, ok := err.(ValidationError)
+		if !ok {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+		if len(valErr.FieldErrors) != 1 {
+			t.Fatalf("expected 1 field error, got %d", len(valErr.FieldErrors))
+		}
+		return valErr.FieldErrors[0].Message
+	}
+
+	english := message(Struct(input))
+	spanish := message(FormatErrorLocale(validate.Struct(input), "es"))
+	if english == spanish {
+		t.Errorf("expected localized message to differ, both were %q", english)
+	}
+	if !strings.Contains(spanish, "requerido") {
+		t.Errorf("expected Spanish message, got %q", spanish)
+	}
+
+	// Regional variants fall back to the language
+	if got := message(FormatErrorLocale(validate.Struct(input), "es-MX")); got != spanish {
+		t.Errorf("expected es-MX to use es translator, got %q", got)
+	}
+
+	// Unregistered locales fall back to the default translator
+	if got := message(FormatErrorLocale(validate.Struct(input), "xx")); got != english {
+		t.Errorf("expected fallback to default message %q, got %q", english, got)
+	}
+}
+
+func TestTranslatorFor(t *testing.T) {
+	if got := TranslatorFor("unknown"); got != Translator() {
+		t.Error("expected default translator for unregistered locale")
+	}
+	if err := RegisterLocale("", estranslations.RegisterDefaultTranslations); err == nil {
+		t.Error("expected error for empty locale")
+	}
+}
+
+func TestNestedFieldPath(t *testing.T) {
+	input := validationTestNested{
+		Name:    "John",
+		Address: validationTestAddress{Street: "Main St", Zip: "123"},
+	}
+
+	err := Struct(input)
+	valErr, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	if len(valErr.FieldErrors) != 1 {
+		t.Fatalf("expected 1 field error, got %d", len(valErr.FieldErrors))
+	}
+	if got := valErr.FieldErrors[0].Field; got != "address.zip" {
+		t.Errorf("expected field path 'address.zip', got %q", got)
+	}
+}
+
+func TestDiveFieldPathIndex(t *testing.T) {
+	input := validationTestOrder{
+		Items: []validationTestItem{
+			{Name: "first", Price: 10},
+			{Name: "second", Price: -1},
+		},
+	}
+
+	err := Struct(input)
+	valErr, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	if len(valErr.FieldErrors) != 1 {
+		t.Fatalf("expected 1 field error, got %d", len(valErr.FieldErrors))
+	}
+	if got := valErr.FieldErrors[0].Field; got != "items[1].price" {
+		t.Errorf("expected field path 'items[1].price', got %q", got)
+	}
+}
+
+type validationTestCode struct {
+	Code string `json:"code" validate:"code"`
+}
+
+func TestNew_IsolatedInstances(t *testing.T) {
+	upper := New()
+	upper.RegisterValidation(func(v *validator.Validate, tr ut.Translator) {
+		_ = v.RegisterValidation("code", func(fl validator.FieldLevel) bool {
+			return fl.Field().String() == strings.ToUpper(fl.Field().String())
+		})
+	})
+
+	lower := New()
+	lower.RegisterValidation(func(v *validator.Validate, tr ut.Translator) {
+		_ = v.RegisterValidation("code", func(fl validator.FieldLevel) bool {
+			return fl.Field().String() == strings.ToLower(fl.Field().String())
+		})
+	})
+
+	input := validationTestCode{Code: "ABC"}
+	if err := upper.Struct(input); err != nil {
+		t.Errorf("expected upper-case code to pass, got %v", err)
+	}
+
+	err := lower.Struct(input)
+	valErr, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	if len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].Field != "code" {
+		t.Errorf("expected single error on 'code', got %+v", valErr.FieldErrors)
+	}
+
+	// Rules registered on instances don't leak into the default validator
+	if upper.Validate() == Validate() || lower.Validate() == Validate() {
+		t.Error("expected instances to have their own validator")
+	}
+}
+
+type validationTestMessage struct {
+	Email string                      `json:"email" validate:"required" message:"email address is mandatory"`
+	Name  string                      `json:"name" validate:"required"`
+	Items []validationTestMessageItem `json:"items" validate:"dive"`
+}
+
+type validationTestMessageItem struct {
+	SKU string `json:"sku" validate:"required" message:"every item needs a SKU"`
+}
+
+func TestCustomMessageTag(t *testing.T) {
+	input := validationTestMessage{
+		Items: []validationTestMessageItem{{SKU: "A1"}, {}},
+	}
+
+	err := Struct(&input)
+	valErr, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+
+	messages := make(map[string]string)
+	for _, fe := range valErr.FieldErrors {
+		messages[fe.Field] = fe.Message
+	}
+
+	if got := messages["email"]; got != "email address is mandatory" {
+		t.Errorf("expected custom message for email, got %q", got)
+	}
+	if got := messages["items[1].sku"]; got != "every item needs a SKU" {
+		t.Errorf("expected custom message for nested item, got %q", got)
+	}
+	// Fields without a message tag keep the translated message
+	if got := messages["name"]; !strings.Contains(got, "required") {
+		t.Errorf("expected translated message for name, got %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+package apikit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/reation-io/apikit/validator"
+)
+
+// maxWrapBodySize limits the request bodies decoded by Wrap (10MB),
+// matching the default of the generated wrappers
+const maxWrapBodySize = 10 * 1024 * 1024
+
+// Wrap adapts a typed handler to an http.HandlerFunc without code generation
+// The JSON body is decoded into Req, or into its json:"body" field when it has
+// one, and fields tagged path, query, header or cookie are bound from the
+// request like in generated handlers
+// Struct requests are validated with their validate tags, and the result is
+// rendered with HandleResponse, so handlers may return an *HttpResponse or an
+// *Error just like generated handlers
+// Error bodies include the request ID set by the RequestID middleware
+func Wrap[Req any, Resp any](fn func(context.Context, Req) (Resp, error)) http.HandlerFunc {
+	binding := newRequestBinding(reflect.TypeFor[Req]())
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req Req
+
+		// Pointer requests are allocated so their fields can be bound
+		target := reflect.ValueOf(&req).Elem()
+		if binding != nil {
+			for target.Kind() == reflect.Pointer {
+				target.Set(reflect.New(target.Type().Elem()))
+				target = target.Elem()
+			}
+		}
+
+		err := decodeWrapBody(w, r, binding.bodyTarget(target))
+		if err == nil {
+			// Bound after the body so request values win over body fields
+			err = binding.bind(r, target)
+		}
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				HandleErrorCtx(r.Context(), w, RequestEntityTooLarge(fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit)))
+				return
+			}
+			HandleErrorCtx(r.Context(), w, BadRequest("failed to parse request").WithCause(err))
+			return
+		}
+
+		if err := validateWrapRequest(r.Context(), &req); err != nil {
+			// Preserve structured validation errors
+			if valErr, ok := err.(validator.ValidationError); ok {
+				HandleErrorCtx(r.Context(), w, UnprocessableEntity(valErr.Message).WithDetails(valErr.FieldErrors))
+			} else {
+				HandleErrorCtx(r.Context(), w, UnprocessableEntity("validation failed").WithCause(err))
+			}
+			return
+		}
+
+		resp, err := fn(r.Context(), req)
+		if err != nil {
+			HandleErrorCtx(r.Context(), w, err)
+			return
+		}
+		HandleResponse(w, resp, nil)
+	}
+}
+
+// decodeWrapBody decodes the JSON request body into target
+// An empty body leaves target unchanged
+func decodeWrapBody(w http.ResponseWriter, r *http.Request, target any) error {
+	if r.Body == nil {
+		return nil
+	}
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWrapBodySize))
+	if err != nil {
+		return fmt.Errorf("reading body: %w", err)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("parsing JSON: %w", err)
+	}
+	return nil
+}
+
+// validateWrapRequest validates req when it is a struct or a non-nil pointer to one
+// Other request types (slices, maps, scalars) have no validate tags to check
+func validateWrapRequest(ctx context.Context, req any) error {
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	return validator.StructCtx(ctx, v.Addr().Interface())
+}
@@ -0,0 +1,171 @@
+package apikit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type wrapCreatePetRequest struct {
+	Name string `json:"name" validate:"required"`
+	Age  int    `json:"age" validate:"gte=0"`
+}
+
+type wrapPet struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestWrap(t *testing.T) {
+	handler := Wrap(func(ctx context.Context, req wrapCreatePetRequest) (wrapPet, error) {
+		if req.Name == "taken" {
+			return wrapPet{}, Conflict("pet name already taken")
+		}
+		return wrapPet{ID: 1, Name: req.Name}, nil
+	})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "success",
+			body:       `{"name":"Rex","age":3}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"id":1,"name":"Rex"}`,
+		},
+		{
+			name:       "validation failure",
+			body:       `{"age":3}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `"field":"name"`,
+		},
+		{
+			name:       "malformed JSON",
+			body:       `{"name":`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "failed to parse request",
+		},
+		{
+			name:       "handler error",
+			body:       `{"name":"taken"}`,
+			wantStatus: http.StatusConflict,
+			wantBody:   "pet name already taken",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(tt.body))
+			handler(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("expected body to contain %q, got %s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWrap_HttpResponseAndSliceRequest(t *testing.T) {
+	handler := Wrap(func(ctx context.Context, names []string) (*HttpResponse, error) {
+		return NewHttpResponse(http.StatusCreated, map[string]int{"count": len(names)}), nil
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`["Rex","Fido"]`))
+	handler(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var got map[string]int
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got["count"] != 2 {
+		t.Errorf("expected count 2, got %v", got)
+	}
+}
+
+type wrapUpdatePetRequest struct {
+	ID        int      `path:"id" validate:"gt=0"`
+	Fields    string   `query:"fields"`
+	Tags      []string `query:"tag"`
+	Limit     *int     `query:"limit"`
+	Format    string   `query:"format" default:"full"`
+	RequestID string   `header:"X-Request-ID"`
+	Body      wrapPet  `json:"body"`
+}
+
+func TestWrap_Binding(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("PUT /pets/{id}", Wrap(func(ctx context.Context, req *wrapUpdatePetRequest) (*wrapUpdatePetRequest, error) {
+		return req, nil
+	}))
+
+	t.Run("path, query, header and body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, "/pets/42?fields=name&tag=a&tag=b&limit=5", strings.NewReader(`{"id":7,"name":"Rex"}`))
+		r.Header.Set("X-Request-ID", "req-1")
+		mux.ServeHTTP(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var got wrapUpdatePetRequest
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.ID != 42 {
+			t.Errorf("expected path id 42, got %d", got.ID)
+		}
+		if got.Fields != "name" {
+			t.Errorf("expected query fields %q, got %q", "name", got.Fields)
+		}
+		if len(got.Tags) != 2 || got.Tags[0] != "a" || got.Tags[1] != "b" {
+			t.Errorf("expected tags [a b], got %v", got.Tags)
+		}
+		if got.Limit == nil || *got.Limit != 5 {
+			t.Errorf("expected limit 5, got %v", got.Limit)
+		}
+		if got.Format != "full" {
+			t.Errorf("expected default format %q, got %q", "full", got.Format)
+		}
+		if got.RequestID != "req-1" {
+			t.Errorf("expected request id %q, got %q", "req-1", got.RequestID)
+		}
+		if got.Body.ID != 7 || got.Body.Name != "Rex" {
+			t.Errorf("expected body {7 Rex}, got %+v", got.Body)
+		}
+	})
+
+	t.Run("invalid path value", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, "/pets/abc", nil)
+		mux.ServeHTTP(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("bound values are validated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, "/pets/0", nil)
+		mux.ServeHTTP(w, r)
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}